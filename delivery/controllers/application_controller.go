@@ -0,0 +1,162 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/tsigemariamzewdu/JobMate-backend/delivery/dto"
+	"github.com/tsigemariamzewdu/JobMate-backend/delivery/utils"
+	"github.com/tsigemariamzewdu/JobMate-backend/domain"
+	usecase "github.com/tsigemariamzewdu/JobMate-backend/domain/interfaces/usecases"
+	"github.com/tsigemariamzewdu/JobMate-backend/domain/models"
+)
+
+type ApplicationController struct {
+	applicationUsecase usecase.IJobApplicationUsecase
+}
+
+func NewApplicationController(u usecase.IJobApplicationUsecase) *ApplicationController {
+	return &ApplicationController{applicationUsecase: u}
+}
+
+// POST /users/me/applications
+func (c *ApplicationController) SaveJob(ctx *gin.Context) {
+	userID := ctx.GetString("userID")
+	if userID == "" {
+		ctx.JSON(http.StatusUnauthorized, utils.ErrorPayload("Unauthorized", nil))
+		return
+	}
+
+	var req dto.SaveJobRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, utils.ErrorPayload("Invalid input", err.Error()))
+		return
+	}
+	if req.JobID == "" && req.Job == nil {
+		ctx.JSON(http.StatusBadRequest, utils.ErrorPayload("Either job_id or job must be provided", nil))
+		return
+	}
+
+	var job *models.Job
+	if req.Job != nil {
+		j := dto.FromJobDTO(*req.Job)
+		job = &j
+	}
+
+	app, err := c.applicationUsecase.SaveJob(ctx, userID, req.JobID, job, req.Notes)
+	if err != nil {
+		writeApplicationError(ctx, err, "Failed to save job")
+		return
+	}
+
+	ctx.Header("Location", "/users/me/applications/"+app.ID)
+	ctx.JSON(http.StatusCreated, utils.SuccessPayload("Job saved successfully", dto.ToApplicationDTO(app)))
+}
+
+// GET /users/me/applications?status=applied
+func (c *ApplicationController) ListApplications(ctx *gin.Context) {
+	userID := ctx.GetString("userID")
+	if userID == "" {
+		ctx.JSON(http.StatusUnauthorized, utils.ErrorPayload("Unauthorized", nil))
+		return
+	}
+
+	apps, err := c.applicationUsecase.List(ctx, userID, models.ApplicationStatus(ctx.Query("status")))
+	if err != nil {
+		writeApplicationError(ctx, err, "Failed to list applications")
+		return
+	}
+
+	out := make([]dto.ApplicationDTO, 0, len(apps))
+	for i := range apps {
+		out = append(out, dto.ToApplicationDTO(&apps[i]))
+	}
+	ctx.JSON(http.StatusOK, utils.SuccessPayload("Applications retrieved successfully", out))
+}
+
+// GET /users/me/applications/:id
+func (c *ApplicationController) GetApplication(ctx *gin.Context) {
+	userID := ctx.GetString("userID")
+	if userID == "" {
+		ctx.JSON(http.StatusUnauthorized, utils.ErrorPayload("Unauthorized", nil))
+		return
+	}
+
+	app, err := c.applicationUsecase.Get(ctx, userID, ctx.Param("id"))
+	if err != nil {
+		writeApplicationError(ctx, err, "Failed to fetch application")
+		return
+	}
+	ctx.JSON(http.StatusOK, utils.SuccessPayload("Application retrieved successfully", dto.ToApplicationDTO(app)))
+}
+
+// PATCH /users/me/applications/:id
+func (c *ApplicationController) UpdateApplication(ctx *gin.Context) {
+	userID := ctx.GetString("userID")
+	if userID == "" {
+		ctx.JSON(http.StatusUnauthorized, utils.ErrorPayload("Unauthorized", nil))
+		return
+	}
+
+	var req dto.UpdateApplicationRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, utils.ErrorPayload("Invalid input", err.Error()))
+		return
+	}
+	if req.Status == nil && req.Notes == nil {
+		ctx.JSON(http.StatusBadRequest, utils.ErrorPayload("Either status or notes must be provided", nil))
+		return
+	}
+
+	update := models.ApplicationUpdate{Notes: req.Notes, StatusNote: req.Note}
+	if req.Status != nil {
+		status := models.ApplicationStatus(*req.Status)
+		update.Status = &status
+	}
+	if req.Date != nil {
+		update.Date = *req.Date
+	}
+
+	app, err := c.applicationUsecase.Update(ctx, userID, ctx.Param("id"), update)
+	if err != nil {
+		writeApplicationError(ctx, err, "Failed to update application")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, utils.SuccessPayload("Application updated successfully", dto.ToApplicationDTO(app)))
+}
+
+// DELETE /users/me/applications/:id
+func (c *ApplicationController) DeleteApplication(ctx *gin.Context) {
+	userID := ctx.GetString("userID")
+	if userID == "" {
+		ctx.JSON(http.StatusUnauthorized, utils.ErrorPayload("Unauthorized", nil))
+		return
+	}
+
+	if err := c.applicationUsecase.Delete(ctx, userID, ctx.Param("id")); err != nil {
+		writeApplicationError(ctx, err, "Failed to delete application")
+		return
+	}
+	ctx.JSON(http.StatusOK, utils.SuccessPayload("Application deleted successfully", nil))
+}
+
+func writeApplicationError(ctx *gin.Context, err error, fallback string) {
+	switch {
+	case errors.Is(err, domain.ErrInvalidApplicationID), errors.Is(err, domain.ErrInvalidJobID):
+		ctx.JSON(http.StatusBadRequest, utils.ErrorPayload("Invalid ID", nil))
+	case errors.Is(err, domain.ErrInvalidApplicationStatus):
+		ctx.JSON(http.StatusBadRequest, utils.ErrorPayload("Status must be one of saved, applied, interviewing, offer, rejected", nil))
+	case errors.Is(err, domain.ErrInvalidInput):
+		ctx.JSON(http.StatusBadRequest, utils.ErrorPayload("Job must include a title and link", nil))
+	case errors.Is(err, domain.ErrApplicationNotFound):
+		ctx.JSON(http.StatusNotFound, utils.ErrorPayload("Application not found", nil))
+	case errors.Is(err, domain.ErrJobNotFound):
+		ctx.JSON(http.StatusNotFound, utils.ErrorPayload("Job not found", nil))
+	case errors.Is(err, domain.ErrJobAlreadySaved):
+		ctx.JSON(http.StatusConflict, utils.ErrorPayload("Job already saved", nil))
+	default:
+		ctx.JSON(http.StatusInternalServerError, utils.ErrorPayload(fallback, err.Error()))
+	}
+}
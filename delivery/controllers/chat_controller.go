@@ -2,6 +2,7 @@ package controllers
 
 import (
 	"context"
+	"errors"
 	"net/http"
 	"strconv"
 	"time"
@@ -9,6 +10,7 @@ import (
 	"github.com/gin-gonic/gin"
 
 	dto "github.com/tsigemariamzewdu/JobMate-backend/delivery/dto"
+	"github.com/tsigemariamzewdu/JobMate-backend/domain"
 	chatUsecase "github.com/tsigemariamzewdu/JobMate-backend/domain/interfaces/usecases"
 )
 
@@ -23,6 +25,12 @@ func NewChatController(chatUsecase chatUsecase.IChatUsecase) *ChatController {
 }
 
 func (c *ChatController) SendMessage(gCtx *gin.Context) {
+  userID := gCtx.GetString("userID")
+  if userID == "" {
+    gCtx.JSON(http.StatusUnauthorized, gin.H{"message": "unauthorized"})
+    return
+  }
+
   var request dto.ChatRequest
 
   if err := gCtx.ShouldBindJSON(&request); err != nil {
@@ -33,7 +41,11 @@ func (c *ChatController) SendMessage(gCtx *gin.Context) {
   ctx, cancel := context.WithTimeout(gCtx.Request.Context(), 10*time.Second) // Added timeout context
   defer cancel()
 
-  conversation, err := c.ChatUsecase.SendMessage(ctx, request.UserID, request.Message)
+  conversation, err := c.ChatUsecase.SendMessage(ctx, userID, request.Message)
+  if errors.Is(err, domain.ErrAIQuotaExceeded) {
+    gCtx.JSON(http.StatusTooManyRequests, gin.H{"message": "you have reached today's AI usage limit"})
+    return
+  }
   if err != nil {
     gCtx.JSON(http.StatusInternalServerError, gin.H{"message": err.Error()})
     return
@@ -42,10 +54,63 @@ func (c *ChatController) SendMessage(gCtx *gin.Context) {
   gCtx.JSON(http.StatusOK, dto.ToChatResponse(conversation))
 }
 
+// StreamMessage relays the reply as Server-Sent Events: "token" events carry each piece of text,
+// then a "done" event carries the saved message, or an "error" event if the AI call failed.
+func (c *ChatController) StreamMessage(gCtx *gin.Context) {
+  userID := gCtx.GetString("userID")
+  if userID == "" {
+    gCtx.JSON(http.StatusUnauthorized, gin.H{"message": "unauthorized"})
+    return
+  }
+
+  var request dto.ChatRequest
+  if err := gCtx.ShouldBindJSON(&request); err != nil {
+    gCtx.JSON(http.StatusBadRequest, gin.H{"message": err.Error()})
+    return
+  }
+
+  // streams outlive the 10s limit of SendMessage; a client disconnect cancels the request context
+  ctx, cancel := context.WithTimeout(gCtx.Request.Context(), 2*time.Minute)
+  defer cancel()
+
+  gCtx.Header("Content-Type", "text/event-stream")
+  gCtx.Header("Cache-Control", "no-cache")
+  gCtx.Header("Connection", "keep-alive")
+  gCtx.Header("X-Accel-Buffering", "no")
+  gCtx.Status(http.StatusOK)
+
+  conversation, err := c.ChatUsecase.StreamMessage(ctx, userID, request.Message, func(delta string) error {
+    if ctx.Err() != nil {
+      return ctx.Err()
+    }
+    gCtx.SSEvent("token", gin.H{"content": delta})
+    gCtx.Writer.Flush()
+    return nil
+  })
+  if ctx.Err() != nil {
+    return // client is gone or the stream timed out; the partial reply has been saved
+  }
+  if err != nil {
+    message := "failed to generate a reply"
+    if errors.Is(err, domain.ErrAIQuotaExceeded) {
+      message = "you have reached today's AI usage limit"
+    } else if errors.Is(err, domain.ErrAIRateLimited) || errors.Is(err, domain.ErrAIUnavailable) || errors.Is(err, domain.ErrAICircuitOpen) {
+      message = "the assistant is temporarily unavailable, please try again shortly"
+    }
+    gCtx.SSEvent("error", gin.H{"message": message})
+    gCtx.Writer.Flush()
+    return
+  }
+
+  gCtx.SSEvent("done", dto.ToChatResponse(conversation))
+  gCtx.Writer.Flush()
+}
+
 func (c *ChatController) GetConversationHistory(gCtx *gin.Context) {
-  userIDStr := gCtx.Query("user_id")
+  // history is always the caller's own
+  userIDStr := gCtx.GetString("userID")
   if userIDStr == "" {
-    gCtx.JSON(http.StatusBadRequest, gin.H{"message": "user_id is required"})
+    gCtx.JSON(http.StatusUnauthorized, gin.H{"message": "unauthorized"})
     return
   }
 
@@ -0,0 +1,177 @@
+package controllers
+
+import (
+	"errors"
+	"mime"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/tsigemariamzewdu/JobMate-backend/delivery/dto"
+	"github.com/tsigemariamzewdu/JobMate-backend/delivery/utils"
+	"github.com/tsigemariamzewdu/JobMate-backend/domain"
+	usecase "github.com/tsigemariamzewdu/JobMate-backend/domain/interfaces/usecases"
+	"github.com/tsigemariamzewdu/JobMate-backend/domain/models"
+)
+
+type CoverLetterController struct {
+	coverLetterUsecase usecase.ICoverLetterUsecase
+}
+
+func NewCoverLetterController(u usecase.ICoverLetterUsecase) *CoverLetterController {
+	return &CoverLetterController{coverLetterUsecase: u}
+}
+
+// POST /users/me/cover-letters
+func (c *CoverLetterController) GenerateCoverLetter(ctx *gin.Context) {
+	userID := ctx.GetString("userID")
+	if userID == "" {
+		ctx.JSON(http.StatusUnauthorized, utils.ErrorPayload("Unauthorized", nil))
+		return
+	}
+
+	var req dto.GenerateCoverLetterRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, utils.ErrorPayload("Invalid input", err.Error()))
+		return
+	}
+	if req.JobID == "" && req.Job == nil {
+		ctx.JSON(http.StatusBadRequest, utils.ErrorPayload("Either job_id or job must be provided", nil))
+		return
+	}
+
+	var job *models.Job
+	if req.Job != nil {
+		j := dto.FromJobDTO(*req.Job)
+		job = &j
+	}
+
+	letter, err := c.coverLetterUsecase.Generate(ctx, userID, req.JobID, job, models.Language(req.Language))
+	if err != nil {
+		writeCoverLetterError(ctx, err, "Failed to generate cover letter")
+		return
+	}
+
+	ctx.Header("Location", "/users/me/cover-letters/"+letter.ID)
+	ctx.JSON(http.StatusCreated, utils.SuccessPayload("Cover letter generated successfully", dto.ToCoverLetterDTO(letter)))
+}
+
+// GET /users/me/cover-letters
+func (c *CoverLetterController) ListCoverLetters(ctx *gin.Context) {
+	userID := ctx.GetString("userID")
+	if userID == "" {
+		ctx.JSON(http.StatusUnauthorized, utils.ErrorPayload("Unauthorized", nil))
+		return
+	}
+
+	letters, err := c.coverLetterUsecase.List(ctx, userID)
+	if err != nil {
+		writeCoverLetterError(ctx, err, "Failed to list cover letters")
+		return
+	}
+
+	out := make([]dto.CoverLetterDTO, 0, len(letters))
+	for i := range letters {
+		out = append(out, dto.ToCoverLetterDTO(&letters[i]))
+	}
+	ctx.JSON(http.StatusOK, utils.SuccessPayload("Cover letters retrieved successfully", out))
+}
+
+// GET /users/me/cover-letters/:id
+func (c *CoverLetterController) GetCoverLetter(ctx *gin.Context) {
+	userID := ctx.GetString("userID")
+	if userID == "" {
+		ctx.JSON(http.StatusUnauthorized, utils.ErrorPayload("Unauthorized", nil))
+		return
+	}
+
+	letter, err := c.coverLetterUsecase.Get(ctx, userID, ctx.Param("id"))
+	if err != nil {
+		writeCoverLetterError(ctx, err, "Failed to fetch cover letter")
+		return
+	}
+	ctx.JSON(http.StatusOK, utils.SuccessPayload("Cover letter retrieved successfully", dto.ToCoverLetterDTO(letter)))
+}
+
+// PATCH /users/me/cover-letters/:id
+func (c *CoverLetterController) EditCoverLetter(ctx *gin.Context) {
+	userID := ctx.GetString("userID")
+	if userID == "" {
+		ctx.JSON(http.StatusUnauthorized, utils.ErrorPayload("Unauthorized", nil))
+		return
+	}
+
+	var req dto.EditCoverLetterRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, utils.ErrorPayload("Invalid input", err.Error()))
+		return
+	}
+
+	letter, err := c.coverLetterUsecase.Edit(ctx, userID, ctx.Param("id"), req.Content)
+	if err != nil {
+		writeCoverLetterError(ctx, err, "Failed to update cover letter")
+		return
+	}
+	ctx.JSON(http.StatusOK, utils.SuccessPayload("Cover letter updated successfully", dto.ToCoverLetterDTO(letter)))
+}
+
+// DELETE /users/me/cover-letters/:id
+func (c *CoverLetterController) DeleteCoverLetter(ctx *gin.Context) {
+	userID := ctx.GetString("userID")
+	if userID == "" {
+		ctx.JSON(http.StatusUnauthorized, utils.ErrorPayload("Unauthorized", nil))
+		return
+	}
+
+	if err := c.coverLetterUsecase.Delete(ctx, userID, ctx.Param("id")); err != nil {
+		writeCoverLetterError(ctx, err, "Failed to delete cover letter")
+		return
+	}
+	ctx.JSON(http.StatusOK, utils.SuccessPayload("Cover letter deleted successfully", nil))
+}
+
+// GET /users/me/cover-letters/:id/export?format=docx
+func (c *CoverLetterController) ExportCoverLetter(ctx *gin.Context) {
+	userID := ctx.GetString("userID")
+	if userID == "" {
+		ctx.JSON(http.StatusUnauthorized, utils.ErrorPayload("Unauthorized", nil))
+		return
+	}
+
+	format := ctx.DefaultQuery("format", models.ExportFormatDocx)
+	letter, document, contentType, err := c.coverLetterUsecase.Export(ctx, userID, ctx.Param("id"), format)
+	if err != nil {
+		writeCoverLetterError(ctx, err, "Failed to export cover letter")
+		return
+	}
+
+	fileName := "cover-letter-" + letter.ID + "." + format
+	ctx.Header("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": fileName}))
+	ctx.Data(http.StatusOK, contentType, document)
+}
+
+func writeCoverLetterError(ctx *gin.Context, err error, fallback string) {
+	switch {
+	case errors.Is(err, domain.ErrInvalidCoverLetterID), errors.Is(err, domain.ErrInvalidJobID):
+		ctx.JSON(http.StatusBadRequest, utils.ErrorPayload("Invalid ID", nil))
+	case errors.Is(err, domain.ErrInvalidInput):
+		ctx.JSON(http.StatusBadRequest, utils.ErrorPayload("Job must include a title, language must be en or am and content must not be empty", nil))
+	case errors.Is(err, domain.ErrUnsupportedExportFormat):
+		ctx.JSON(http.StatusBadRequest, utils.ErrorPayload("Format must be txt or docx", nil))
+	case errors.Is(err, domain.ErrCoverLetterNotFound):
+		ctx.JSON(http.StatusNotFound, utils.ErrorPayload("Cover letter not found", nil))
+	case errors.Is(err, domain.ErrJobNotFound):
+		ctx.JSON(http.StatusNotFound, utils.ErrorPayload("Job not found", nil))
+	case errors.Is(err, domain.ErrCVNotFound):
+		ctx.JSON(http.StatusNotFound, utils.ErrorPayload("Upload a CV before generating a cover letter", nil))
+	case errors.Is(err, domain.ErrUserNotFound):
+		ctx.JSON(http.StatusNotFound, utils.ErrorPayload("User not found", nil))
+	case errors.Is(err, domain.ErrAIQuotaExceeded):
+		ctx.JSON(http.StatusTooManyRequests, utils.ErrorPayload("You have reached today's AI usage limit", nil))
+	case errors.Is(err, domain.ErrAIRateLimited), errors.Is(err, domain.ErrAIUnavailable), errors.Is(err, domain.ErrAICircuitOpen):
+		ctx.JSON(http.StatusServiceUnavailable, utils.ErrorPayload("Cover letter generation is temporarily unavailable, please try again shortly", nil))
+	case errors.Is(err, domain.ErrAIInvalidOutput):
+		ctx.JSON(http.StatusBadGateway, utils.ErrorPayload("The cover letter came back unusable, please try again", nil))
+	default:
+		ctx.JSON(http.StatusInternalServerError, utils.ErrorPayload(fallback, err.Error()))
+	}
+}
@@ -3,15 +3,18 @@ package controllers
 import (
 	"errors"
 	"io"
+	"mime"
 	"mime/multipart"
 	"net/http"
 	"path"
 	"strings"
 
 	"github.com/gin-gonic/gin"
+	"github.com/tsigemariamzewdu/JobMate-backend/delivery/dto"
 	"github.com/tsigemariamzewdu/JobMate-backend/delivery/utils"
 	"github.com/tsigemariamzewdu/JobMate-backend/domain"
 	usecase "github.com/tsigemariamzewdu/JobMate-backend/domain/interfaces/usecases"
+	"github.com/tsigemariamzewdu/JobMate-backend/domain/models"
 )
 
 const (
@@ -27,13 +30,18 @@ func NewCVController(u usecase.ICVUsecase) *CVController {
 }
 
 type CVUploadRequest struct {
-	UserID  string                `json:"userId" form:"userId" binding:"required"`
 	RawText string                `json:"rawText" form:"rawText"`
 	File    *multipart.FileHeader `form:"file"`
 }
 
 // POST /cv
 func (c *CVController) UploadCV(ctx *gin.Context) {
+	userID := ctx.GetString("userID")
+	if userID == "" {
+		ctx.JSON(http.StatusUnauthorized, utils.ErrorPayload("Unauthorized", nil))
+		return
+	}
+
 	// Limit request size
 	ctx.Request.Body = http.MaxBytesReader(ctx.Writer, ctx.Request.Body, maxUploadBytes)
 
@@ -65,38 +73,22 @@ func (c *CVController) UploadCV(ctx *gin.Context) {
 			return
 		}
 
-		// Sniff MIME type
-		file, err := req.File.Open()
-		if err != nil {
-			ctx.JSON(http.StatusBadRequest, utils.ErrorPayload("Could not open file", err.Error()))
-			return
-		}
-		defer file.Close()
-
-		head := make([]byte, 512)
-		n, _ := io.ReadFull(file, head)
-		mime := http.DetectContentType(head[:n])
-		ext := strings.ToLower(path.Ext(req.File.Filename))
-		allowed := map[string]bool{
-			"application/pdf": true,
-		}
-		if !allowed[mime] && ext != ".docx" {
-			ctx.JSON(http.StatusUnsupportedMediaType, utils.ErrorPayload(
-				"Only PDF or DOCX files are allowed",
-				map[string]any{"detected": mime},
-			))
-			return
-		}
-
+		// the type is sniffed from the content when the text is extracted
 		// Sanitize filename
 		req.File.Filename = path.Base(req.File.Filename)
 	}
 
-	createdCV, err := c.cvUsecase.Upload(ctx, req.UserID, req.RawText, req.File)
+	createdCV, err := c.cvUsecase.Upload(ctx, userID, req.RawText, req.File)
 	if err != nil {
 		switch {
 		case errors.Is(err, domain.ErrCVNotFound):
 			ctx.JSON(http.StatusNotFound, utils.ErrorPayload("CV not found", nil))
+		case errors.Is(err, domain.ErrUnsupportedFileType):
+			ctx.JSON(http.StatusUnsupportedMediaType, utils.ErrorPayload("Only PDF, DOCX, DOC, ODT, RTF, TXT, HTML, JPG or PNG files are allowed", nil))
+		case errors.Is(err, domain.ErrNoTextFound):
+			ctx.JSON(http.StatusUnprocessableEntity, utils.ErrorPayload("No readable text was found in the file. If it is a scan or photo, upload a sharper image", nil))
+		case errors.Is(err, domain.ErrOCRUnavailable):
+			ctx.JSON(http.StatusUnprocessableEntity, utils.ErrorPayload("Scanned documents and photos cannot be read right now. Upload a PDF or DOCX with selectable text, or paste the text instead", nil))
 		default:
 			ctx.JSON(http.StatusInternalServerError, utils.ErrorPayload("Failed to upload CV", err.Error()))
 		}
@@ -108,30 +100,316 @@ func (c *CVController) UploadCV(ctx *gin.Context) {
 	ctx.JSON(http.StatusCreated, utils.SuccessPayload("CV uploaded successfully", gin.H{
 		"cvId":      createdCV.ID,
 		"userId":    createdCV.UserID,
+		"version":   createdCV.Version,
+		"isActive":  createdCV.IsActive,
 		"fileName":  createdCV.FileName,
 		"createdAt": createdCV.CreatedAt,
 	}))
 }
 
+// GET /cv
+func (c *CVController) ListCVs(ctx *gin.Context) {
+	userID := ctx.GetString("userID")
+	if userID == "" {
+		ctx.JSON(http.StatusUnauthorized, utils.ErrorPayload("Unauthorized", nil))
+		return
+	}
+
+	cvs, err := c.cvUsecase.List(ctx, userID)
+	if err != nil {
+		writeCVError(ctx, err, "Failed to list CVs")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, utils.SuccessPayload("CVs retrieved", dto.ToCVDTOs(cvs)))
+}
+
+// GET /cv/:id
+func (c *CVController) GetCV(ctx *gin.Context) {
+	userID := ctx.GetString("userID")
+	if userID == "" {
+		ctx.JSON(http.StatusUnauthorized, utils.ErrorPayload("Unauthorized", nil))
+		return
+	}
+
+	cv, err := c.cvUsecase.Get(ctx, userID, ctx.Param("id"))
+	if err != nil {
+		writeCVError(ctx, err, "Failed to get CV")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, utils.SuccessPayload("CV retrieved", dto.ToCVDTO(cv, true)))
+}
+
+// PATCH /cv/:id/activate
+func (c *CVController) ActivateCV(ctx *gin.Context) {
+	userID := ctx.GetString("userID")
+	if userID == "" {
+		ctx.JSON(http.StatusUnauthorized, utils.ErrorPayload("Unauthorized", nil))
+		return
+	}
+
+	cv, err := c.cvUsecase.Activate(ctx, userID, ctx.Param("id"))
+	if err != nil {
+		writeCVError(ctx, err, "Failed to activate CV")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, utils.SuccessPayload("CV activated", dto.ToCVDTO(cv, false)))
+}
+
+// GET /cv/:id/file
+// Streams the originally uploaded document back to its owner.
+func (c *CVController) DownloadCV(ctx *gin.Context) {
+	userID := ctx.GetString("userID")
+	if userID == "" {
+		ctx.JSON(http.StatusUnauthorized, utils.ErrorPayload("Unauthorized", nil))
+		return
+	}
+
+	cv, content, err := c.cvUsecase.OpenFile(ctx, userID, ctx.Param("id"))
+	if err != nil {
+		writeCVError(ctx, err, "Failed to download CV")
+		return
+	}
+	defer content.Close()
+
+	etag := `"` + cv.File.Checksum + `"`
+	if cv.File.Checksum != "" && ctx.GetHeader("If-None-Match") == etag {
+		ctx.Status(http.StatusNotModified)
+		return
+	}
+	if cv.File.Checksum != "" {
+		ctx.Header("ETag", etag)
+	}
+	ctx.Header("Cache-Control", "private, no-cache")
+	ctx.Header("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": cv.FileName}))
+	contentType := cv.File.ContentType
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+	ctx.DataFromReader(http.StatusOK, cv.File.Size, contentType, content, nil)
+}
+
+// DELETE /cv/:id
+func (c *CVController) DeleteCV(ctx *gin.Context) {
+	userID := ctx.GetString("userID")
+	if userID == "" {
+		ctx.JSON(http.StatusUnauthorized, utils.ErrorPayload("Unauthorized", nil))
+		return
+	}
+
+	if err := c.cvUsecase.Delete(ctx, userID, ctx.Param("id")); err != nil {
+		writeCVError(ctx, err, "Failed to delete CV")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, utils.SuccessPayload("CV deleted", nil))
+}
+
+// AnalyzeCVRequest is the optional body of POST /cv/:id/analyze
+type AnalyzeCVRequest struct {
+	JobID string `json:"jobId"` // a catalog job to score keyword coverage against
+}
+
 // POST /cv/:id/analyze
+// Queues the analysis and returns at once; poll GET /cv/:id/analysis for the result.
 func (c *CVController) AnalyzeCV(ctx *gin.Context) {
+	userID := ctx.GetString("userID")
+	if userID == "" {
+		ctx.JSON(http.StatusUnauthorized, utils.ErrorPayload("Unauthorized", nil))
+		return
+	}
 	cvID := ctx.Param("id")
 
-	suggestions, err := c.cvUsecase.Analyze(ctx, cvID)
+	var req AnalyzeCVRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil && !errors.Is(err, io.EOF) {
+		ctx.JSON(http.StatusBadRequest, utils.ErrorPayload("Invalid input", err.Error()))
+		return
+	}
+
+	job, created, err := c.cvUsecase.RequestAnalysis(ctx, userID, cvID, strings.TrimSpace(req.JobID))
 	if err != nil {
-		switch {
-		case errors.Is(err, domain.ErrInvalidCVID):
-			ctx.JSON(http.StatusBadRequest, utils.ErrorPayload("Invalid CV ID", nil))
-		case errors.Is(err, domain.ErrCVNotFound):
-			ctx.JSON(http.StatusNotFound, utils.ErrorPayload("CV not found", nil))
-		default:
-			ctx.JSON(http.StatusInternalServerError, utils.ErrorPayload("Failed to analyze CV", err.Error()))
+		writeCVError(ctx, err, "Failed to queue CV analysis")
+		return
+	}
+
+	ctx.Header("Location", "/cv/"+cvID+"/analysis")
+	switch {
+	case job.Status == models.CVAnalysisSucceeded:
+		ctx.JSON(http.StatusOK, utils.SuccessPayload("CV already analyzed", dto.ToCVAnalysisDTO(job)))
+	case created:
+		ctx.JSON(http.StatusAccepted, utils.SuccessPayload("CV analysis queued", dto.ToCVAnalysisDTO(job)))
+	default:
+		ctx.JSON(http.StatusAccepted, utils.SuccessPayload("CV analysis already in progress", dto.ToCVAnalysisDTO(job)))
+	}
+}
+
+// GET /cv/:id/analysis
+func (c *CVController) GetAnalysis(ctx *gin.Context) {
+	userID := ctx.GetString("userID")
+	if userID == "" {
+		ctx.JSON(http.StatusUnauthorized, utils.ErrorPayload("Unauthorized", nil))
+		return
+	}
+
+	job, err := c.cvUsecase.GetAnalysis(ctx, userID, ctx.Param("id"))
+	if err != nil {
+		writeCVError(ctx, err, "Failed to get CV analysis")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, utils.SuccessPayload("CV analysis retrieved", dto.ToCVAnalysisDTO(job)))
+}
+
+// TailorCVRequest names a catalog job, or pastes a job description, to tailor the CV to
+type TailorCVRequest struct {
+	JobID          string `json:"jobId"`
+	JobDescription string `json:"jobDescription"`
+}
+
+// maxJobDescriptionChars bounds pasted postings, which go to the AI in full
+const maxJobDescriptionChars = 20000
+
+// POST /cv/:id/tailor
+func (c *CVController) TailorCV(ctx *gin.Context) {
+	userID := ctx.GetString("userID")
+	if userID == "" {
+		ctx.JSON(http.StatusUnauthorized, utils.ErrorPayload("Unauthorized", nil))
+		return
+	}
+
+	var req TailorCVRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, utils.ErrorPayload("Invalid input", err.Error()))
+		return
+	}
+	req.JobID, req.JobDescription = strings.TrimSpace(req.JobID), strings.TrimSpace(req.JobDescription)
+	if (req.JobID == "") == (req.JobDescription == "") {
+		ctx.JSON(http.StatusBadRequest, utils.ErrorPayload("Provide either jobId or jobDescription", nil))
+		return
+	}
+	if len([]rune(req.JobDescription)) > maxJobDescriptionChars {
+		ctx.JSON(http.StatusBadRequest, utils.ErrorPayload("Job description is too long", nil))
+		return
+	}
+
+	tailoring, err := c.cvUsecase.Tailor(ctx, userID, ctx.Param("id"), req.JobID, req.JobDescription)
+	if err != nil {
+		writeCVError(ctx, err, "Failed to tailor CV")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, utils.SuccessPayload("CV tailored to job", dto.ToCVTailoringDTO(tailoring)))
+}
+
+// GET /cv/:id/feedback
+func (c *CVController) ListFeedback(ctx *gin.Context) {
+	userID := ctx.GetString("userID")
+	if userID == "" {
+		ctx.JSON(http.StatusUnauthorized, utils.ErrorPayload("Unauthorized", nil))
+		return
+	}
+
+	feedback, err := c.cvUsecase.ListFeedback(ctx, userID, ctx.Param("id"))
+	if err != nil {
+		writeCVError(ctx, err, "Failed to get CV feedback")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, utils.SuccessPayload("CV feedback retrieved", dto.ToCVFeedbackDTOs(feedback)))
+}
+
+// GET /users/me/skill-gaps
+func (c *CVController) ListSkillGaps(ctx *gin.Context) {
+	userID := ctx.GetString("userID")
+	if userID == "" {
+		ctx.JSON(http.StatusUnauthorized, utils.ErrorPayload("Unauthorized", nil))
+		return
+	}
+
+	gaps, err := c.cvUsecase.ListSkillGaps(ctx, userID)
+	if err != nil {
+		writeCVError(ctx, err, "Failed to get skill gaps")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, utils.SuccessPayload("Skill gaps retrieved", dto.ToSkillGapDTOs(gaps)))
+}
+
+// GET /users/me/skill-gaps/diff?from=<analysisId>&to=<analysisId>
+// Without from and to, the two most recent analyses are compared.
+func (c *CVController) DiffSkillGaps(ctx *gin.Context) {
+	userID := ctx.GetString("userID")
+	if userID == "" {
+		ctx.JSON(http.StatusUnauthorized, utils.ErrorPayload("Unauthorized", nil))
+		return
+	}
+
+	from, to := ctx.Query("from"), ctx.Query("to")
+	if (from == "") != (to == "") {
+		ctx.JSON(http.StatusBadRequest, utils.ErrorPayload("from and to must be given together", nil))
+		return
+	}
+
+	diff, err := c.cvUsecase.DiffSkillGaps(ctx, userID, from, to)
+	if err != nil {
+		if errors.Is(err, domain.ErrCVAnalysisNotFound) && from == "" {
+			ctx.JSON(http.StatusNotFound, utils.ErrorPayload("At least two CV analyses are needed to compare skill gaps", nil))
+			return
 		}
+		writeCVError(ctx, err, "Failed to compare skill gaps")
 		return
 	}
 
-	ctx.JSON(http.StatusOK, utils.SuccessPayload("CV analyzed successfully", gin.H{
-		"cvId":        cvID,
-		"suggestions": suggestions,
-	}))
+	ctx.JSON(http.StatusOK, utils.SuccessPayload("Skill gap changes retrieved", dto.ToSkillGapDiffDTO(diff)))
+}
+
+// GET /users/me/profile-suggestions
+func (c *CVController) GetProfileSuggestions(ctx *gin.Context) {
+	userID := ctx.GetString("userID")
+	if userID == "" {
+		ctx.JSON(http.StatusUnauthorized, utils.ErrorPayload("Unauthorized", nil))
+		return
+	}
+
+	suggestion, err := c.cvUsecase.SuggestProfile(ctx, userID)
+	if err != nil {
+		if errors.Is(err, domain.ErrCVNotFound) {
+			ctx.JSON(http.StatusNotFound, utils.ErrorPayload("Upload a CV to get profile suggestions", nil))
+			return
+		}
+		writeCVError(ctx, err, "Failed to suggest profile values")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, utils.SuccessPayload("Profile suggestions retrieved", dto.ToProfileSuggestionDTO(suggestion)))
+}
+
+// writeCVError maps CV errors to responses, falling back to a 500 with fallback as the message
+func writeCVError(ctx *gin.Context, err error, fallback string) {
+	switch {
+	case errors.Is(err, domain.ErrInvalidCVID):
+		ctx.JSON(http.StatusBadRequest, utils.ErrorPayload("Invalid CV ID", nil))
+	case errors.Is(err, domain.ErrCVNotFound):
+		ctx.JSON(http.StatusNotFound, utils.ErrorPayload("CV not found", nil))
+	case errors.Is(err, domain.ErrCVFileNotFound):
+		ctx.JSON(http.StatusNotFound, utils.ErrorPayload("No file was uploaded for this CV", nil))
+	case errors.Is(err, domain.ErrCVAnalysisNotFound):
+		ctx.JSON(http.StatusNotFound, utils.ErrorPayload("CV has not been analyzed yet", nil))
+	case errors.Is(err, domain.ErrCVAnalysisInProgress):
+		ctx.JSON(http.StatusConflict, utils.ErrorPayload("CV analysis is still in progress, try another job once it finishes", nil))
+	case errors.Is(err, domain.ErrInvalidJobID):
+		ctx.JSON(http.StatusBadRequest, utils.ErrorPayload("Invalid job ID", nil))
+	case errors.Is(err, domain.ErrJobNotFound):
+		ctx.JSON(http.StatusNotFound, utils.ErrorPayload("Job not found", nil))
+	case errors.Is(err, domain.ErrAIQuotaExceeded):
+		ctx.JSON(http.StatusTooManyRequests, utils.ErrorPayload("You have reached today's AI usage limit", nil))
+	case errors.Is(err, domain.ErrAIRateLimited), errors.Is(err, domain.ErrAIUnavailable), errors.Is(err, domain.ErrAICircuitOpen):
+		ctx.JSON(http.StatusServiceUnavailable, utils.ErrorPayload("AI suggestions are temporarily unavailable, please try again shortly", nil))
+	case errors.Is(err, domain.ErrAIInvalidOutput):
+		ctx.JSON(http.StatusBadGateway, utils.ErrorPayload("AI suggestions came back unusable, please try again", nil))
+	default:
+		ctx.JSON(http.StatusInternalServerError, utils.ErrorPayload(fallback, err.Error()))
+	}
 }
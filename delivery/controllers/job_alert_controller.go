@@ -0,0 +1,140 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/tsigemariamzewdu/JobMate-backend/delivery/dto"
+	"github.com/tsigemariamzewdu/JobMate-backend/delivery/utils"
+	"github.com/tsigemariamzewdu/JobMate-backend/domain"
+	usecase "github.com/tsigemariamzewdu/JobMate-backend/domain/interfaces/usecases"
+	"github.com/tsigemariamzewdu/JobMate-backend/domain/models"
+)
+
+type JobAlertController struct {
+	alertUsecase usecase.IJobAlertUsecase
+}
+
+func NewJobAlertController(u usecase.IJobAlertUsecase) *JobAlertController {
+	return &JobAlertController{alertUsecase: u}
+}
+
+// POST /users/me/alerts
+func (c *JobAlertController) CreateAlert(ctx *gin.Context) {
+	userID := ctx.GetString("userID")
+	if userID == "" {
+		ctx.JSON(http.StatusUnauthorized, utils.ErrorPayload("Unauthorized", nil))
+		return
+	}
+
+	var req dto.CreateJobAlertRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, utils.ErrorPayload("Invalid input", err.Error()))
+		return
+	}
+	if req.LookingFor != "local" && req.LookingFor != "remote" && req.LookingFor != "freelance" {
+		ctx.JSON(http.StatusBadRequest, utils.ErrorPayload("looking_for must be one of local, remote, freelance", nil))
+		return
+	}
+	if req.Language != "am" {
+		req.Language = "en"
+	}
+
+	frequency := models.AlertFrequency(req.Frequency)
+	switch frequency {
+	case "":
+		frequency = models.AlertDaily
+	case models.AlertDaily, models.AlertWeekly:
+	default:
+		ctx.JSON(http.StatusBadRequest, utils.ErrorPayload("frequency must be daily or weekly", nil))
+		return
+	}
+
+	var channels []models.AlertChannel
+	for _, ch := range req.Channels {
+		channel := models.AlertChannel(ch)
+		if channel != models.AlertChannelEmail && channel != models.AlertChannelSMS {
+			ctx.JSON(http.StatusBadRequest, utils.ErrorPayload("channels may only contain email and sms", nil))
+			return
+		}
+		channels = append(channels, channel)
+	}
+
+	alert, err := c.alertUsecase.Subscribe(ctx, &models.JobAlert{
+		UserID:     userID,
+		Field:      req.Field,
+		LookingFor: req.LookingFor,
+		Skills:     req.Skills,
+		Language:   req.Language,
+		Frequency:  frequency,
+		Channels:   channels,
+	})
+	if err != nil {
+		if errors.Is(err, domain.ErrAlertNoContactMethod) {
+			ctx.JSON(http.StatusBadRequest, utils.ErrorPayload("Add an email or phone to your profile for the selected channels", nil))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, utils.ErrorPayload("Failed to create job alert", err.Error()))
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, utils.SuccessPayload("Job alert created successfully", dto.ToJobAlertDTO(alert)))
+}
+
+// GET /users/me/alerts
+func (c *JobAlertController) ListAlerts(ctx *gin.Context) {
+	userID := ctx.GetString("userID")
+	if userID == "" {
+		ctx.JSON(http.StatusUnauthorized, utils.ErrorPayload("Unauthorized", nil))
+		return
+	}
+
+	alerts, err := c.alertUsecase.List(ctx, userID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, utils.ErrorPayload("Failed to list job alerts", err.Error()))
+		return
+	}
+
+	out := make([]dto.JobAlertDTO, 0, len(alerts))
+	for i := range alerts {
+		out = append(out, dto.ToJobAlertDTO(&alerts[i]))
+	}
+	ctx.JSON(http.StatusOK, utils.SuccessPayload("Job alerts retrieved successfully", out))
+}
+
+// DELETE /users/me/alerts/:id
+func (c *JobAlertController) DeleteAlert(ctx *gin.Context) {
+	userID := ctx.GetString("userID")
+	if userID == "" {
+		ctx.JSON(http.StatusUnauthorized, utils.ErrorPayload("Unauthorized", nil))
+		return
+	}
+
+	if err := c.alertUsecase.Delete(ctx, userID, ctx.Param("id")); err != nil {
+		switch {
+		case errors.Is(err, domain.ErrInvalidAlertID):
+			ctx.JSON(http.StatusBadRequest, utils.ErrorPayload("Invalid alert ID", nil))
+		case errors.Is(err, domain.ErrAlertNotFound):
+			ctx.JSON(http.StatusNotFound, utils.ErrorPayload("Job alert not found", nil))
+		default:
+			ctx.JSON(http.StatusInternalServerError, utils.ErrorPayload("Failed to delete job alert", err.Error()))
+		}
+		return
+	}
+	ctx.JSON(http.StatusOK, utils.SuccessPayload("Job alert deleted successfully", nil))
+}
+
+// GET /alerts/unsubscribe?token=...
+// Public so the link in a digest works without logging in.
+func (c *JobAlertController) Unsubscribe(ctx *gin.Context) {
+	if err := c.alertUsecase.Unsubscribe(ctx, ctx.Query("token")); err != nil {
+		if errors.Is(err, domain.ErrInvalidUnsubscribe) {
+			ctx.JSON(http.StatusNotFound, utils.ErrorPayload("Invalid or expired unsubscribe link", nil))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, utils.ErrorPayload("Failed to unsubscribe", err.Error()))
+		return
+	}
+	ctx.JSON(http.StatusOK, utils.SuccessPayload("You have been unsubscribed from this job alert", nil))
+}
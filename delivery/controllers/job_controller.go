@@ -1,32 +1,42 @@
 package controllers
 
 import (
+	"errors"
 	"net/http"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/tsigemariamzewdu/JobMate-backend/delivery/dto"
+	"github.com/tsigemariamzewdu/JobMate-backend/domain"
+	repo "github.com/tsigemariamzewdu/JobMate-backend/domain/interfaces/repositories"
+	svc "github.com/tsigemariamzewdu/JobMate-backend/domain/interfaces/services"
 	"github.com/tsigemariamzewdu/JobMate-backend/domain/models"
-	"github.com/tsigemariamzewdu/JobMate-backend/infrastructure/ai"
-	"github.com/tsigemariamzewdu/JobMate-backend/repositories"
 	"github.com/tsigemariamzewdu/JobMate-backend/usecases"
 )
 
 type JobController struct {
 	JobUsecase  *usecases.JobUsecase
-	JobChatRepo *repositories.JobChatRepository
-	GroqClient  *ai.GroqClient
+	JobChatRepo repo.IJobChatRepository
+	AIClient    svc.IAIClient
 }
 
-func NewJobController(jobUsecase *usecases.JobUsecase, jobChatRepo *repositories.JobChatRepository, groqClient *ai.GroqClient) *JobController {
+func NewJobController(jobUsecase *usecases.JobUsecase, jobChatRepo repo.IJobChatRepository, aiClient svc.IAIClient) *JobController {
 	return &JobController{
 		JobUsecase:  jobUsecase,
 		JobChatRepo: jobChatRepo,
-		GroqClient:  groqClient,
+		AIClient:    aiClient,
 	}
 }
 
 func (jc *JobController) SuggestJobs(c *gin.Context) {
+	userID := c.GetString("userID")
+	if userID == "" {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
 	var req dto.JobSuggestionRequest
 	chatID := c.Query("chat_id")
 	if err := c.ShouldBindJSON(&req); err != nil {
@@ -34,7 +44,7 @@ func (jc *JobController) SuggestJobs(c *gin.Context) {
 		return
 	}
 	// input validation
-	if req.UserID == "" || req.Field == "" || req.LookingFor == "" || (req.LookingFor != "local" && req.LookingFor != "remote" && req.LookingFor != "freelance") {
+	if req.Field == "" || req.LookingFor == "" || (req.LookingFor != "local" && req.LookingFor != "remote" && req.LookingFor != "freelance") {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "Missing or invalid field(s) in request"})
 		return
 	}
@@ -48,18 +58,12 @@ func (jc *JobController) SuggestJobs(c *gin.Context) {
 		Field:      req.Field,
 		Skills:     req.Skills,
 		Experience: req.Experience,
+		Location:   req.Location,
 		Language:   req.Language,
 	}
 
-	// retrieve previous chat if chat_id is provided
+	// messages from this turn; with chat_id they are added to that chat's history
 	var chatMsgs []models.JobChatMessage
-	if chatID != "" {
-		prevChat, err := jc.JobChatRepo.GetJobChatByID(c.Request.Context(), chatID)
-		if err == nil && prevChat != nil {
-			chatMsgs = append(chatMsgs, prevChat.Messages...)
-		}
-	}
-	// append new chat history from request
 	for _, m := range req.ChatHistory {
 		chatMsgs = append(chatMsgs, models.JobChatMessage{
 			Role:      m.Role,
@@ -68,31 +72,174 @@ func (jc *JobController) SuggestJobs(c *gin.Context) {
 		})
 	}
 
-	jobs, aiResp, msg, newChatID, err := jc.JobUsecase.SuggestJobs(c.Request.Context(), req.UserID, domainReq, chatMsgs)
-	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": msg})
+	jobs, failures, aiResp, msg, newChatID, err := jc.JobUsecase.SuggestJobs(c.Request.Context(), userID, chatID, domainReq, chatMsgs)
+	if errors.Is(err, domain.ErrInvalidJobChatID) || errors.Is(err, domain.ErrJobChatNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Job chat not found"})
+		return
+	}
+
+	// convert source failures to DTOs
+	var failureDTOs []dto.JobSourceFailureDTO
+	for _, f := range failures {
+		failureDTOs = append(failureDTOs, dto.JobSourceFailureDTO{
+			Source: f.Source,
+			Error:  f.Error,
+		})
+	}
+
+	switch {
+	case errors.Is(err, domain.ErrAIQuotaExceeded):
+		c.JSON(http.StatusTooManyRequests, gin.H{"error": "You have reached today's AI usage limit", "chat_id": newChatID})
+		return
+	case errors.Is(err, domain.ErrAIRateLimited), errors.Is(err, domain.ErrAIUnavailable), errors.Is(err, domain.ErrAICircuitOpen):
+		c.JSON(http.StatusServiceUnavailable, gin.H{"error": "Job chat is temporarily unavailable, please try again shortly", "chat_id": newChatID})
+		return
+	case err != nil:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": msg, "source_errors": failureDTOs})
 		return
 	}
 
 	// convert domain jobs to DTOs
-	var jobDTOs []dto.JobDTO
+	var jobDTOs []dto.RankedJobDTO
 	for _, job := range jobs {
-		jobDTOs = append(jobDTOs, dto.JobDTO{
-			Title:        job.Title,
-			Company:      job.Company,
-			Location:     job.Location,
-			Requirements: job.Requirements,
-			Type:         job.Type,
-			Source:       job.Source,
-			Link:         job.Link,
-			Language:     job.Language,
-		})
+		jobDTOs = append(jobDTOs, dto.ToRankedJobDTO(job))
 	}
 
 	c.JSON(http.StatusOK, gin.H{
-		"jobs":       jobDTOs,
-		"ai_message": aiResp,
-		"message":    msg,
-		"chat_id":    newChatID,
+		"jobs":          jobDTOs,
+		"ai_message":    aiResp,
+		"message":       msg,
+		"chat_id":       newChatID,
+		"source_errors": failureDTOs,
 	})
 }
+
+// GET /jobs
+func (jc *JobController) SearchJobs(c *gin.Context) {
+	filter := models.JobFilter{
+		Keyword:  strings.TrimSpace(c.Query("keyword")),
+		Location: strings.TrimSpace(c.Query("location")),
+		Source:   strings.TrimSpace(c.Query("source")),
+		Cursor:   c.Query("cursor"),
+	}
+
+	if jobType := c.Query("type"); jobType != "" {
+		if jobType != "local" && jobType != "remote" && jobType != "freelance" {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "type must be one of local, remote, freelance"})
+			return
+		}
+		filter.Types = []string{jobType}
+	}
+
+	if since := c.Query("posted_since"); since != "" {
+		t, err := parseDateParam(since)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "posted_since must be a date (YYYY-MM-DD) or RFC3339 timestamp"})
+			return
+		}
+		filter.PostedSince = t
+	}
+
+	if limitStr := c.Query("limit"); limitStr != "" {
+		limit, err := strconv.Atoi(limitStr)
+		if err != nil || limit <= 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "limit must be a positive integer"})
+			return
+		}
+		filter.Limit = limit
+	}
+
+	jobs, nextCursor, err := jc.JobUsecase.SearchJobs(c.Request.Context(), filter)
+	if err != nil {
+		if errors.Is(err, domain.ErrInvalidCursor) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid cursor"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to search jobs"})
+		return
+	}
+
+	jobDTOs := make([]dto.JobDTO, 0, len(jobs))
+	for _, job := range jobs {
+		jobDTOs = append(jobDTOs, dto.ToJobDTO(job))
+	}
+
+	c.JSON(http.StatusOK, dto.JobSearchResponse{
+		Jobs:       jobDTOs,
+		NextCursor: nextCursor,
+	})
+}
+
+// GET /jobs/chats
+func (jc *JobController) ListJobChats(c *gin.Context) {
+	chats, err := jc.JobUsecase.ListChats(c.Request.Context(), c.GetString("userID"))
+	if err != nil {
+		if errors.Is(err, domain.ErrInvalidUserID) {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to list job chats"})
+		return
+	}
+
+	summaries := make([]dto.JobChatSummaryDTO, 0, len(chats))
+	for _, chat := range chats {
+		summaries = append(summaries, dto.ToJobChatSummaryDTO(chat))
+	}
+	c.JSON(http.StatusOK, gin.H{"chats": summaries})
+}
+
+// GET /jobs/chats/:id
+func (jc *JobController) GetJobChat(c *gin.Context) {
+	chat, err := jc.JobUsecase.GetChat(c.Request.Context(), c.GetString("userID"), c.Param("id"))
+	if err != nil {
+		writeJobChatError(c, err, "Failed to get job chat")
+		return
+	}
+	c.JSON(http.StatusOK, dto.ToJobChatDTO(chat))
+}
+
+// PATCH /jobs/chats/:id
+func (jc *JobController) RenameJobChat(c *gin.Context) {
+	var req dto.RenameJobChatRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "title is required"})
+		return
+	}
+	if err := jc.JobUsecase.RenameChat(c.Request.Context(), c.GetString("userID"), c.Param("id"), req.Title); err != nil {
+		writeJobChatError(c, err, "Failed to rename job chat")
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Job chat renamed"})
+}
+
+// DELETE /jobs/chats/:id
+func (jc *JobController) DeleteJobChat(c *gin.Context) {
+	if err := jc.JobUsecase.DeleteChat(c.Request.Context(), c.GetString("userID"), c.Param("id")); err != nil {
+		writeJobChatError(c, err, "Failed to delete job chat")
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Job chat deleted"})
+}
+
+// writeJobChatError maps job chat errors to responses; other users' chats look like missing ones
+func writeJobChatError(c *gin.Context, err error, fallback string) {
+	switch {
+	case errors.Is(err, domain.ErrInvalidUserID):
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+	case errors.Is(err, domain.ErrInvalidInput):
+		c.JSON(http.StatusBadRequest, gin.H{"error": "title is required"})
+	case errors.Is(err, domain.ErrInvalidJobChatID), errors.Is(err, domain.ErrJobChatNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "Job chat not found"})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": fallback})
+	}
+}
+
+// parseDateParam accepts either a plain date or a full RFC3339 timestamp
+func parseDateParam(value string) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+	return time.Parse("2006-01-02", value)
+}
@@ -0,0 +1,91 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/tsigemariamzewdu/JobMate-backend/delivery/dto"
+	"github.com/tsigemariamzewdu/JobMate-backend/delivery/utils"
+	"github.com/tsigemariamzewdu/JobMate-backend/domain"
+	usecase "github.com/tsigemariamzewdu/JobMate-backend/domain/interfaces/usecases"
+)
+
+type UsageController struct {
+	usageUsecase usecase.IAIUsageUsecase
+}
+
+func NewUsageController(u usecase.IAIUsageUsecase) *UsageController {
+	return &UsageController{usageUsecase: u}
+}
+
+// GET /users/me/usage?days=7
+func (c *UsageController) GetMyUsage(ctx *gin.Context) {
+	userID := ctx.GetString("userID")
+	if userID == "" {
+		ctx.JSON(http.StatusUnauthorized, utils.ErrorPayload("Unauthorized", nil))
+		return
+	}
+
+	days := 1
+	if daysStr := ctx.Query("days"); daysStr != "" {
+		d, err := strconv.Atoi(daysStr)
+		if err != nil || d <= 0 {
+			ctx.JSON(http.StatusBadRequest, utils.ErrorPayload("days must be a positive integer", nil))
+			return
+		}
+		days = d
+	}
+
+	report, err := c.usageUsecase.GetUserUsage(ctx, userID, days)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, utils.ErrorPayload("Failed to get AI usage", err.Error()))
+		return
+	}
+	ctx.JSON(http.StatusOK, utils.SuccessPayload("AI usage retrieved successfully", dto.ToAIUsageReportDTO(report)))
+}
+
+// GET /admin/usage?from=2024-01-01&to=2024-02-01
+// Defaults to the last 30 days.
+func (c *UsageController) GetUsageReport(ctx *gin.Context) {
+	to := time.Now()
+	from := to.AddDate(0, 0, -30)
+	if v := ctx.Query("from"); v != "" {
+		t, err := parseDateParam(v)
+		if err != nil {
+			ctx.JSON(http.StatusBadRequest, utils.ErrorPayload("from must be a date (YYYY-MM-DD) or RFC3339 timestamp", nil))
+			return
+		}
+		from = t
+	}
+	if v := ctx.Query("to"); v != "" {
+		t, err := parseDateParam(v)
+		if err != nil {
+			ctx.JSON(http.StatusBadRequest, utils.ErrorPayload("to must be a date (YYYY-MM-DD) or RFC3339 timestamp", nil))
+			return
+		}
+		to = t
+	}
+
+	totals, err := c.usageUsecase.GetReport(ctx, from, to)
+	if err != nil {
+		if errors.Is(err, domain.ErrInvalidInput) {
+			ctx.JSON(http.StatusBadRequest, utils.ErrorPayload("from must be before to", nil))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, utils.ErrorPayload("Failed to build usage report", err.Error()))
+		return
+	}
+
+	rows := make([]dto.AIUsageTotalDTO, 0, len(totals))
+	for _, t := range totals {
+		rows = append(rows, dto.ToAIUsageTotalDTO(t, true))
+	}
+	ctx.JSON(http.StatusOK, utils.SuccessPayload("AI usage report generated successfully", gin.H{
+		"from":  from,
+		"to":    to,
+		"usage": rows,
+	}))
+}
@@ -0,0 +1,89 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/tsigemariamzewdu/JobMate-backend/domain/models"
+)
+
+type SaveJobRequest struct {
+	JobID string  `json:"job_id"`
+	Job   *JobDTO `json:"job"`
+	Notes string  `json:"notes"`
+}
+
+type UpdateApplicationRequest struct {
+	Status *string    `json:"status"`
+	Note   string     `json:"note"`
+	Date   *time.Time `json:"date"`
+	Notes  *string    `json:"notes"`
+}
+
+type ApplicationStatusChangeDTO struct {
+	Status    string    `json:"status"`
+	Note      string    `json:"note,omitempty"`
+	ChangedAt time.Time `json:"changed_at"`
+}
+
+type ApplicationDTO struct {
+	ID        string                       `json:"id"`
+	JobID     string                       `json:"job_id,omitempty"`
+	Job       JobDTO                       `json:"job"`
+	Status    string                       `json:"status"`
+	Notes     string                       `json:"notes"`
+	AppliedAt *time.Time                   `json:"applied_at,omitempty"`
+	History   []ApplicationStatusChangeDTO `json:"history"`
+	CreatedAt time.Time                    `json:"created_at"`
+	UpdatedAt time.Time                    `json:"updated_at"`
+}
+
+// FromJobDTO converts a job posted by a client to the domain model
+func FromJobDTO(d JobDTO) models.Job {
+	job := models.Job{
+		Title:          d.Title,
+		Company:        d.Company,
+		Location:       d.Location,
+		Description:    d.Description,
+		Requirements:   d.Requirements,
+		Seniority:      d.Seniority,
+		EmploymentType: d.EmploymentType,
+		Type:           d.Type,
+		Source:         d.Source,
+		Link:           d.Link,
+		Language:       d.Language,
+	}
+	if d.Salary != nil {
+		job.Salary = &models.SalaryRange{
+			Min:      d.Salary.Min,
+			Max:      d.Salary.Max,
+			Currency: d.Salary.Currency,
+			Period:   d.Salary.Period,
+		}
+	}
+	if d.PostedAt != nil {
+		job.PostedAt = *d.PostedAt
+	}
+	return job
+}
+
+func ToApplicationDTO(a *models.JobApplication) ApplicationDTO {
+	history := make([]ApplicationStatusChangeDTO, 0, len(a.History))
+	for _, h := range a.History {
+		history = append(history, ApplicationStatusChangeDTO{
+			Status:    string(h.Status),
+			Note:      h.Note,
+			ChangedAt: h.ChangedAt,
+		})
+	}
+	return ApplicationDTO{
+		ID:        a.ID,
+		JobID:     a.JobID,
+		Job:       ToJobDTO(a.Job),
+		Status:    string(a.Status),
+		Notes:     a.Notes,
+		AppliedAt: a.AppliedAt,
+		History:   history,
+		CreatedAt: a.CreatedAt,
+		UpdatedAt: a.UpdatedAt,
+	}
+}
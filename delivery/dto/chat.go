@@ -7,7 +7,6 @@ import (
 )
 
 type ChatRequest struct {
-  UserID  string `json:"user_id" binding:"required"`
   Message string `json:"message" binding:"required"`
   IsFromUser bool `json:"is_from_user"`
 }
@@ -36,6 +35,25 @@ type GroqAPIRequest struct {
   Temperature float32            `json:"temperature"`
   MaxTokens   int                `json:"max_tokens"`
   Stream      bool               `json:"stream"`
+  StreamOptions *GroqStreamOptions `json:"stream_options,omitempty"`
+  ResponseFormat *GroqResponseFormat `json:"response_format,omitempty"`
+}
+
+// GroqResponseFormat switches on JSON mode, which guarantees the reply parses as JSON
+type GroqResponseFormat struct {
+  Type string `json:"type"` // "json_object"
+}
+
+// GroqStreamOptions asks for token usage in the final chunk of a stream
+type GroqStreamOptions struct {
+  IncludeUsage bool `json:"include_usage"`
+}
+
+// GroqUsage is the token accounting returned with a completion
+type GroqUsage struct {
+  CompletionTokens int `json:"completion_tokens"`
+  PromptTokens     int `json:"prompt_tokens"`
+  TotalTokens      int `json:"total_tokens"`
 }
 
 // GroqAPIResponse represents the response body from the Groq API
@@ -52,11 +70,26 @@ type GroqAPIResponse struct {
   ID      string `json:"id"`
   Model   string `json:"model"`
   Object  string `json:"object"`
-  Usage   struct {
-    CompletionTokens int `json:"completion_tokens"`
-    PromptTokens     int `json:"prompt_tokens"`
-    TotalTokens      int `json:"total_tokens"`
-  } `json:"usage"`
+  Usage   GroqUsage `json:"usage"`
+}
+
+// GroqStreamChunk is one "data:" event of a streamed Groq completion
+type GroqStreamChunk struct {
+  Choices []struct {
+    Delta struct {
+      Content string `json:"content"`
+      Role    string `json:"role"`
+    } `json:"delta"`
+    FinishReason *string `json:"finish_reason"`
+    Index        int     `json:"index"`
+  } `json:"choices"`
+  ID    string `json:"id"`
+  Model string `json:"model"`
+  // usage arrives with the last chunk: as "usage" from OpenAI-style APIs, under "x_groq" from Groq
+  Usage *GroqUsage `json:"usage,omitempty"`
+  XGroq *struct {
+    Usage *GroqUsage `json:"usage,omitempty"`
+  } `json:"x_groq,omitempty"`
 }
 
 func ToChatResponse(conv *models.UserConversation) *ChatResponse {
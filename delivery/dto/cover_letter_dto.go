@@ -0,0 +1,57 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/tsigemariamzewdu/JobMate-backend/domain/models"
+)
+
+type GenerateCoverLetterRequest struct {
+	JobID    string  `json:"job_id"`
+	Job      *JobDTO `json:"job"`
+	Language string  `json:"language" binding:"omitempty,oneof=en am"`
+}
+
+type EditCoverLetterRequest struct {
+	Content string `json:"content" binding:"required"`
+}
+
+type CoverLetterRevisionDTO struct {
+	Content   string    `json:"content"`
+	Source    string    `json:"source"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type CoverLetterDTO struct {
+	ID        string                   `json:"id"`
+	CVID      string                   `json:"cv_id"`
+	JobID     string                   `json:"job_id,omitempty"`
+	Job       JobDTO                   `json:"job"`
+	Language  string                   `json:"language"`
+	Content   string                   `json:"content"`
+	History   []CoverLetterRevisionDTO `json:"history"`
+	CreatedAt time.Time                `json:"created_at"`
+	UpdatedAt time.Time                `json:"updated_at"`
+}
+
+func ToCoverLetterDTO(l *models.CoverLetter) CoverLetterDTO {
+	history := make([]CoverLetterRevisionDTO, 0, len(l.History))
+	for _, h := range l.History {
+		history = append(history, CoverLetterRevisionDTO{
+			Content:   h.Content,
+			Source:    string(h.Source),
+			CreatedAt: h.CreatedAt,
+		})
+	}
+	return CoverLetterDTO{
+		ID:        l.ID,
+		CVID:      l.CVID,
+		JobID:     l.JobID,
+		Job:       ToJobDTO(l.Job),
+		Language:  string(l.Language),
+		Content:   l.Content,
+		History:   history,
+		CreatedAt: l.CreatedAt,
+		UpdatedAt: l.UpdatedAt,
+	}
+}
@@ -0,0 +1,339 @@
+package dto
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/tsigemariamzewdu/JobMate-backend/domain/models"
+)
+
+// CVDTO describes one CV version. The text is only included when a single CV is fetched.
+type CVDTO struct {
+	ID                  string               `json:"cvId"`
+	Version             int                  `json:"version"`
+	FileName            string               `json:"fileName"`
+	File                *CVFileDTO           `json:"file,omitempty"`
+	IsActive            bool                 `json:"isActive"`
+	Summary             string               `json:"summary"`
+	ExtractedSkills     []string             `json:"extractedSkills"`
+	ExtractedExperience []string             `json:"extractedExperience"`
+	ExtractedEducation  []string             `json:"extractedEducation"`
+	Experience          []ExperienceEntryDTO `json:"experience"`
+	Education           []EducationEntryDTO  `json:"education"`
+	OriginalText        string               `json:"originalText,omitempty"`
+	CreatedAt           time.Time            `json:"createdAt"`
+	UpdatedAt           time.Time            `json:"updatedAt"`
+}
+
+// ExperienceEntryDTO gives dates as "2019-03", or "2019" when the CV names no month
+type ExperienceEntryDTO struct {
+	Title        string `json:"title"`
+	Organization string `json:"organization"`
+	StartDate    string `json:"startDate,omitempty"`
+	EndDate      string `json:"endDate,omitempty"`
+	Current      bool   `json:"current"`
+	Description  string `json:"description"`
+}
+
+type EducationEntryDTO struct {
+	Institution    string `json:"institution"`
+	Degree         string `json:"degree"`
+	Level          string `json:"level,omitempty"`
+	FieldOfStudy   string `json:"fieldOfStudy"`
+	StartYear      int    `json:"startYear,omitempty"`
+	GraduationYear int    `json:"graduationYear,omitempty"`
+}
+
+// CVFileDTO describes the stored original of an uploaded CV
+type CVFileDTO struct {
+	ContentType string `json:"contentType"`
+	Size        int64  `json:"size"`
+	Checksum    string `json:"checksum"`
+	DownloadURL string `json:"downloadUrl"`
+}
+
+func ToCVDTO(cv *models.CV, withText bool) CVDTO {
+	d := CVDTO{
+		ID:                  cv.ID,
+		Version:             cv.Version,
+		FileName:            cv.FileName,
+		IsActive:            cv.IsActive,
+		Summary:             cv.Summary,
+		ExtractedSkills:     nonNil(cv.ExtractedSkills),
+		ExtractedExperience: nonNil(cv.ExtractedExperience),
+		ExtractedEducation:  nonNil(cv.ExtractedEducation),
+		Experience:          make([]ExperienceEntryDTO, 0, len(cv.Experience)),
+		Education:           make([]EducationEntryDTO, 0, len(cv.Education)),
+		CreatedAt:           cv.CreatedAt,
+		UpdatedAt:           cv.UpdatedAt,
+	}
+	for _, e := range cv.Experience {
+		d.Experience = append(d.Experience, ExperienceEntryDTO{
+			Title:        e.Title,
+			Organization: e.Organization,
+			StartDate:    formatYearMonth(e.Start),
+			EndDate:      formatYearMonth(e.End),
+			Current:      e.Current,
+			Description:  e.Description,
+		})
+	}
+	for _, e := range cv.Education {
+		d.Education = append(d.Education, EducationEntryDTO{
+			Institution:    e.Institution,
+			Degree:         e.Degree,
+			Level:          string(e.Level),
+			FieldOfStudy:   e.FieldOfStudy,
+			StartYear:      e.StartYear,
+			GraduationYear: e.GraduationYear,
+		})
+	}
+	if cv.File != nil {
+		d.File = &CVFileDTO{
+			ContentType: cv.File.ContentType,
+			Size:        cv.File.Size,
+			Checksum:    cv.File.Checksum,
+			DownloadURL: "/cv/" + cv.ID + "/file",
+		}
+	}
+	if withText {
+		d.OriginalText = cv.OriginalText
+	}
+	return d
+}
+
+func ToCVDTOs(cvs []models.CV) []CVDTO {
+	out := make([]CVDTO, 0, len(cvs))
+	for i := range cvs {
+		out = append(out, ToCVDTO(&cvs[i], false))
+	}
+	return out
+}
+
+func formatYearMonth(d models.YearMonth) string {
+	switch {
+	case d.Year == 0:
+		return ""
+	case d.Month == 0:
+		return fmt.Sprintf("%04d", d.Year)
+	default:
+		return fmt.Sprintf("%04d-%02d", d.Year, d.Month)
+	}
+}
+
+// nonNil keeps empty lists as [] rather than null in responses
+func nonNil(values []string) []string {
+	if values == nil {
+		return []string{}
+	}
+	return values
+}
+
+// CVAnalysisDTO uses camelCase keys like the rest of the /cv responses
+type CVAnalysisDTO struct {
+	ID          string                `json:"analysisId"`
+	CVID        string                `json:"cvId"`
+	Status      string                `json:"status"`
+	Error       string                `json:"error,omitempty"`
+	Suggestions *models.AISuggestions `json:"suggestions,omitempty"`
+	TargetJobID string                `json:"targetJobId,omitempty"`
+	Score       *CVScoreDTO           `json:"score,omitempty"`
+	CreatedAt   time.Time             `json:"createdAt"`
+	StartedAt   *time.Time            `json:"startedAt,omitempty"`
+	FinishedAt  *time.Time            `json:"finishedAt,omitempty"`
+}
+
+func ToCVAnalysisDTO(j *models.CVAnalysisJob) CVAnalysisDTO {
+	return CVAnalysisDTO{
+		ID:          j.ID,
+		CVID:        j.CVID,
+		Status:      string(j.Status),
+		Error:       j.Error,
+		Suggestions: j.Result,
+		TargetJobID: j.TargetJobID,
+		Score:       toCVScoreDTO(j.Score),
+		CreatedAt:   j.CreatedAt,
+		StartedAt:   j.StartedAt,
+		FinishedAt:  j.FinishedAt,
+	}
+}
+
+type CVFeedbackDTO struct {
+	ID                     string      `json:"feedbackId"`
+	CVID                   string      `json:"cvId"`
+	AnalysisID             string      `json:"analysisId,omitempty"`
+	Strengths              string      `json:"strengths"`
+	Weaknesses             string      `json:"weaknesses"`
+	ImprovementSuggestions string      `json:"improvementSuggestions"`
+	Score                  *CVScoreDTO `json:"score,omitempty"`
+	GeneratedAt            time.Time   `json:"generatedAt"`
+}
+
+// CVScoreDTO is the rubric score of a CV with the result of every check
+type CVScoreDTO struct {
+	Score       int          `json:"score"`
+	TargetJobID string       `json:"targetJobId,omitempty"`
+	Checks      []CVCheckDTO `json:"checks"`
+}
+
+type CVCheckDTO struct {
+	Check     string `json:"check"`
+	Passed    bool   `json:"passed"`
+	Skipped   bool   `json:"skipped"`
+	Points    int    `json:"points"`
+	MaxPoints int    `json:"maxPoints"`
+	Detail    string `json:"detail"`
+}
+
+func toCVScoreDTO(s *models.CVScore) *CVScoreDTO {
+	if s == nil {
+		return nil
+	}
+	d := &CVScoreDTO{Score: s.Score, TargetJobID: s.TargetJobID, Checks: make([]CVCheckDTO, 0, len(s.Checks))}
+	for _, c := range s.Checks {
+		d.Checks = append(d.Checks, CVCheckDTO{
+			Check:     string(c.Check),
+			Passed:    c.Passed,
+			Skipped:   c.Skipped,
+			Points:    c.Points,
+			MaxPoints: c.MaxPoints,
+			Detail:    c.Detail,
+		})
+	}
+	return d
+}
+
+func ToCVFeedbackDTOs(feedback []models.CVFeedback) []CVFeedbackDTO {
+	out := make([]CVFeedbackDTO, 0, len(feedback))
+	for _, f := range feedback {
+		out = append(out, CVFeedbackDTO{
+			ID:                     f.ID,
+			CVID:                   f.CVID,
+			AnalysisID:             f.AnalysisID,
+			Strengths:              f.Strengths,
+			Weaknesses:             f.Weaknesses,
+			ImprovementSuggestions: f.ImprovementSuggestions,
+			Score:                  toCVScoreDTO(f.Score),
+			GeneratedAt:            f.GeneratedAt,
+		})
+	}
+	return out
+}
+
+type SkillGapDTO struct {
+	ID                     string    `json:"id,omitempty"` // empty for gaps that were not stored
+	CVID                   string    `json:"cvId,omitempty"`
+	SkillName              string    `json:"skillName"`
+	CurrentLevel           int       `json:"currentLevel"`
+	RecommendedLevel       int       `json:"recommendedLevel"`
+	Importance             string    `json:"importance"`
+	ImprovementSuggestions string    `json:"improvementSuggestions"`
+	UpdatedAt              time.Time `json:"updatedAt"`
+}
+
+func ToSkillGapDTOs(gaps []models.SkillGap) []SkillGapDTO {
+	out := make([]SkillGapDTO, 0, len(gaps))
+	for _, g := range gaps {
+		out = append(out, SkillGapDTO{
+			ID:                     g.ID,
+			CVID:                   g.CVID,
+			SkillName:              g.SkillName,
+			CurrentLevel:           g.CurrentLevel,
+			RecommendedLevel:       g.RecommendedLevel,
+			Importance:             string(g.Importance),
+			ImprovementSuggestions: g.ImprovementSuggestions,
+			UpdatedAt:              g.UpdatedAt,
+		})
+	}
+	return out
+}
+
+type SkillGapDiffEntryDTO struct {
+	SkillName            string `json:"skillName"`
+	Change               string `json:"change"`
+	FromCurrentLevel     int    `json:"fromCurrentLevel,omitempty"`
+	ToCurrentLevel       int    `json:"toCurrentLevel,omitempty"`
+	FromRecommendedLevel int    `json:"fromRecommendedLevel,omitempty"`
+	ToRecommendedLevel   int    `json:"toRecommendedLevel,omitempty"`
+	FromImportance       string `json:"fromImportance,omitempty"`
+	ToImportance         string `json:"toImportance,omitempty"`
+}
+
+type SkillGapDiffDTO struct {
+	FromAnalysisID string                 `json:"fromAnalysisId"`
+	ToAnalysisID   string                 `json:"toAnalysisId"`
+	FromCVID       string                 `json:"fromCvId"`
+	ToCVID         string                 `json:"toCvId"`
+	FromAt         time.Time              `json:"fromAt"`
+	ToAt           time.Time              `json:"toAt"`
+	Changes        []SkillGapDiffEntryDTO `json:"changes"`
+}
+
+func ToSkillGapDiffDTO(d *models.SkillGapDiff) SkillGapDiffDTO {
+	changes := make([]SkillGapDiffEntryDTO, 0, len(d.Entries))
+	for _, e := range d.Entries {
+		changes = append(changes, SkillGapDiffEntryDTO{
+			SkillName:            e.SkillName,
+			Change:               string(e.Change),
+			FromCurrentLevel:     e.FromCurrentLevel,
+			ToCurrentLevel:       e.ToCurrentLevel,
+			FromRecommendedLevel: e.FromRecommendedLevel,
+			ToRecommendedLevel:   e.ToRecommendedLevel,
+			FromImportance:       string(e.FromImportance),
+			ToImportance:         string(e.ToImportance),
+		})
+	}
+	return SkillGapDiffDTO{
+		FromAnalysisID: d.FromAnalysisID,
+		ToAnalysisID:   d.ToAnalysisID,
+		FromCVID:       d.FromCVID,
+		ToCVID:         d.ToCVID,
+		FromAt:         d.FromAt,
+		ToAt:           d.ToAt,
+		Changes:        changes,
+	}
+}
+
+// CVTailoringDTO compares a CV with one job
+type CVTailoringDTO struct {
+	CVID                string                `json:"cvId"`
+	JobID               string                `json:"jobId,omitempty"`
+	JobTitle            string                `json:"jobTitle,omitempty"`
+	KeywordCoverage     int                   `json:"keywordCoverage"`
+	MatchedKeywords     []string              `json:"matchedKeywords"`
+	MissingKeywords     []string              `json:"missingKeywords"`
+	MissingRequirements []string              `json:"missingRequirements"`
+	BulletSuggestions   []BulletSuggestionDTO `json:"bulletSuggestions"`
+	TailoredSummary     string                `json:"tailoredSummary"`
+	SkillGaps           []SkillGapDTO         `json:"skillGaps"`
+	GeneratedAt         time.Time             `json:"generatedAt"`
+}
+
+type BulletSuggestionDTO struct {
+	Original  string `json:"original"`
+	Suggested string `json:"suggested"`
+	Reason    string `json:"reason"`
+}
+
+func ToCVTailoringDTO(t *models.CVTailoring) CVTailoringDTO {
+	d := CVTailoringDTO{
+		CVID:                t.CVID,
+		JobID:               t.JobID,
+		JobTitle:            t.JobTitle,
+		KeywordCoverage:     t.KeywordCoverage,
+		MatchedKeywords:     nonNil(t.MatchedKeywords),
+		MissingKeywords:     nonNil(t.MissingKeywords),
+		MissingRequirements: nonNil(t.MissingRequirements),
+		BulletSuggestions:   make([]BulletSuggestionDTO, 0, len(t.BulletSuggestions)),
+		TailoredSummary:     t.TailoredSummary,
+		SkillGaps:           ToSkillGapDTOs(t.SkillGaps),
+		GeneratedAt:         t.GeneratedAt,
+	}
+	for _, b := range t.BulletSuggestions {
+		d.BulletSuggestions = append(d.BulletSuggestions, BulletSuggestionDTO{
+			Original:  b.Original,
+			Suggested: b.Suggested,
+			Reason:    b.Reason,
+		})
+	}
+	return d
+}
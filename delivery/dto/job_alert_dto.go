@@ -0,0 +1,54 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/tsigemariamzewdu/JobMate-backend/domain/models"
+)
+
+type CreateJobAlertRequest struct {
+	Field      string   `json:"field" binding:"required"`
+	LookingFor string   `json:"looking_for" binding:"required"` // "local", "remote", "freelance"
+	Skills     []string `json:"skills"`
+	Language   string   `json:"language"`  // "en", "am"
+	Frequency  string   `json:"frequency"` // "daily", "weekly"
+	Channels   []string `json:"channels"`  // "email", "sms"; sent to the account's email and phone
+}
+
+type JobAlertDTO struct {
+	ID         string     `json:"id"`
+	Field      string     `json:"field"`
+	LookingFor string     `json:"looking_for"`
+	Skills     []string   `json:"skills"`
+	Language   string     `json:"language"`
+	Frequency  string     `json:"frequency"`
+	Channels   []string   `json:"channels"`
+	Active     bool       `json:"active"`
+	LastSentAt *time.Time `json:"last_sent_at,omitempty"`
+	NextRunAt  time.Time  `json:"next_run_at"`
+	CreatedAt  time.Time  `json:"created_at"`
+}
+
+func ToJobAlertDTO(a *models.JobAlert) JobAlertDTO {
+	channels := make([]string, 0, len(a.Channels))
+	for _, c := range a.Channels {
+		channels = append(channels, string(c))
+	}
+	skills := a.Skills
+	if skills == nil {
+		skills = []string{}
+	}
+	return JobAlertDTO{
+		ID:         a.ID,
+		Field:      a.Field,
+		LookingFor: a.LookingFor,
+		Skills:     skills,
+		Language:   a.Language,
+		Frequency:  string(a.Frequency),
+		Channels:   channels,
+		Active:     a.Active,
+		LastSentAt: a.LastSentAt,
+		NextRunAt:  a.NextRunAt,
+		CreatedAt:  a.CreatedAt,
+	}
+}
@@ -1,32 +1,178 @@
 package dto
 
+import (
+	"time"
+
+	"github.com/tsigemariamzewdu/JobMate-backend/domain/models"
+)
+
 type JobChatMessageDTO struct {
-	Role    string `json:"role"`
-	Message string `json:"message"`
+	Role      string     `json:"role"`
+	Message   string     `json:"message"`
+	Timestamp *time.Time `json:"timestamp,omitempty"`
+}
+
+// JobChatSummaryDTO is a job chat as shown in a list, without its messages and results
+type JobChatSummaryDTO struct {
+	ID           string    `json:"id"`
+	Title        string    `json:"title"`
+	LastMessage  string    `json:"last_message,omitempty"`
+	MessageCount int       `json:"message_count"`
+	CreatedAt    time.Time `json:"created_at"`
+	UpdatedAt    time.Time `json:"updated_at"`
+}
+
+type JobChatDTO struct {
+	ID          string              `json:"id"`
+	Title       string              `json:"title"`
+	Messages    []JobChatMessageDTO `json:"messages"`
+	SearchQuery map[string]any      `json:"search_query"`
+	Jobs        []JobDTO            `json:"jobs"`
+	CreatedAt   time.Time           `json:"created_at"`
+	UpdatedAt   time.Time           `json:"updated_at"`
+}
+
+type RenameJobChatRequest struct {
+	Title string `json:"title" binding:"required"`
+}
+
+func ToJobChatSummaryDTO(chat *models.JobChat) JobChatSummaryDTO {
+	summary := JobChatSummaryDTO{
+		ID:           chat.ID,
+		Title:        chat.Title,
+		MessageCount: len(chat.Messages),
+		CreatedAt:    chat.CreatedAt,
+		UpdatedAt:    chat.UpdatedAt,
+	}
+	if n := len(chat.Messages); n > 0 {
+		summary.LastMessage = chat.Messages[n-1].Message
+	}
+	return summary
+}
+
+func ToJobChatDTO(chat *models.JobChat) JobChatDTO {
+	messages := make([]JobChatMessageDTO, 0, len(chat.Messages))
+	for _, m := range chat.Messages {
+		ts := m.Timestamp
+		messages = append(messages, JobChatMessageDTO{Role: m.Role, Message: m.Message, Timestamp: &ts})
+	}
+	jobs := make([]JobDTO, 0, len(chat.JobResults))
+	for _, job := range chat.JobResults {
+		jobs = append(jobs, ToJobDTO(job))
+	}
+	return JobChatDTO{
+		ID:          chat.ID,
+		Title:       chat.Title,
+		Messages:    messages,
+		SearchQuery: chat.JobSearchQuery,
+		Jobs:        jobs,
+		CreatedAt:   chat.CreatedAt,
+		UpdatedAt:   chat.UpdatedAt,
+	}
 }
 
 type JobSuggestionRequest struct {
-	UserID      string              `json:"user_id"`
 	LookingFor  string              `json:"looking_for"` // "local", "remote", "freelance"
 	Field       string              `json:"field"`
 	Skills      []string            `json:"skills"`
 	Experience  string              `json:"experience"`
+	Location    string              `json:"location"`
 	Language    string              `json:"language"` // "en", "am"
 	ChatHistory []JobChatMessageDTO `json:"chat_history"`
 }
 
 type JobSuggestionResponse struct {
-	Jobs    []JobDTO `json:"jobs"`
-	Message string   `json:"message"`
+	Jobs         []JobDTO              `json:"jobs"`
+	Message      string                `json:"message"`
+	SourceErrors []JobSourceFailureDTO `json:"source_errors,omitempty"`
+}
+
+type JobSourceFailureDTO struct {
+	Source string `json:"source"`
+	Error  string `json:"error"`
 }
 
 type JobDTO struct {
-	Title        string   `json:"title"`
-	Company      string   `json:"company"`
-	Location     string   `json:"location"`
-	Requirements []string `json:"requirements"`
-	Type         string   `json:"type"`
-	Source       string   `json:"source"`
-	Link         string   `json:"link"`
-	Language     string   `json:"language"`
+	ID             string     `json:"id,omitempty"`
+	Title          string     `json:"title"`
+	Company        string     `json:"company"`
+	Location       string     `json:"location"`
+	Description    string     `json:"description,omitempty"`
+	Requirements   []string   `json:"requirements"`
+	Seniority      string     `json:"seniority,omitempty"`
+	EmploymentType string     `json:"employment_type,omitempty"`
+	Salary         *SalaryDTO `json:"salary,omitempty"`
+	Type           string     `json:"type"`
+	Source         string     `json:"source"`
+	Link           string     `json:"link"`
+	Language       string     `json:"language"`
+	PostedAt       *time.Time `json:"posted_at,omitempty"`
+}
+
+// RankedJobDTO is a job with how well it matches the requester
+type RankedJobDTO struct {
+	JobDTO
+	MatchScore    int      `json:"match_score"`
+	MatchedSkills []string `json:"matched_skills"`
+	MissingSkills []string `json:"missing_skills"`
+}
+
+type JobSearchResponse struct {
+	Jobs       []JobDTO `json:"jobs"`
+	NextCursor string   `json:"next_cursor,omitempty"`
+}
+
+type SalaryDTO struct {
+	Min      float64 `json:"min"`
+	Max      float64 `json:"max"`
+	Currency string  `json:"currency"`
+	Period   string  `json:"period,omitempty"`
+}
+
+func ToSalaryDTO(s *models.SalaryRange) *SalaryDTO {
+	if s == nil {
+		return nil
+	}
+	return &SalaryDTO{Min: s.Min, Max: s.Max, Currency: s.Currency, Period: s.Period}
+}
+
+func ToJobDTO(job models.Job) JobDTO {
+	dto := JobDTO{
+		ID:             job.ID,
+		Title:          job.Title,
+		Company:        job.Company,
+		Location:       job.Location,
+		Description:    job.Description,
+		Requirements:   job.Requirements,
+		Seniority:      job.Seniority,
+		EmploymentType: job.EmploymentType,
+		Salary:         ToSalaryDTO(job.Salary),
+		Type:           job.Type,
+		Source:         job.Source,
+		Link:           job.Link,
+		Language:       job.Language,
+	}
+	if !job.PostedAt.IsZero() {
+		postedAt := job.PostedAt
+		dto.PostedAt = &postedAt
+	}
+	return dto
+}
+
+// ToRankedJobDTO converts a scored job to its transport form
+func ToRankedJobDTO(r models.RankedJob) RankedJobDTO {
+	matched := r.MatchedSkills
+	if matched == nil {
+		matched = []string{}
+	}
+	missing := r.MissingSkills
+	if missing == nil {
+		missing = []string{}
+	}
+	return RankedJobDTO{
+		JobDTO:        ToJobDTO(r.Job),
+		MatchScore:    r.MatchScore,
+		MatchedSkills: matched,
+		MissingSkills: missing,
+	}
 }
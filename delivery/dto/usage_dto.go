@@ -0,0 +1,58 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/tsigemariamzewdu/JobMate-backend/domain/models"
+)
+
+type AIUsageTotalDTO struct {
+	UserID           string `json:"user_id,omitempty"`
+	Feature          string `json:"feature"`
+	Requests         int    `json:"requests"`
+	PromptTokens     int    `json:"prompt_tokens"`
+	CompletionTokens int    `json:"completion_tokens"`
+	TotalTokens      int    `json:"total_tokens"`
+}
+
+type AIUsageReportDTO struct {
+	From           time.Time         `json:"from"`
+	To             time.Time         `json:"to"`
+	ByFeature      []AIUsageTotalDTO `json:"by_feature"`
+	DailyQuota     int               `json:"daily_quota"` // 0 means unlimited
+	UsedToday      int               `json:"used_today"`
+	RemainingToday *int              `json:"remaining_today,omitempty"`
+}
+
+// ToAIUsageTotalDTO converts a usage total; withUser keeps the user ID for admin reports
+func ToAIUsageTotalDTO(t models.AIUsageTotal, withUser bool) AIUsageTotalDTO {
+	out := AIUsageTotalDTO{
+		Feature:          t.Feature,
+		Requests:         t.Requests,
+		PromptTokens:     t.PromptTokens,
+		CompletionTokens: t.CompletionTokens,
+		TotalTokens:      t.TotalTokens,
+	}
+	if withUser {
+		out.UserID = t.UserID
+	}
+	return out
+}
+
+func ToAIUsageReportDTO(r *models.AIUsageReport) AIUsageReportDTO {
+	out := AIUsageReportDTO{
+		From:       r.From,
+		To:         r.To,
+		ByFeature:  make([]AIUsageTotalDTO, 0, len(r.ByFeature)),
+		DailyQuota: r.DailyQuota,
+		UsedToday:  r.UsedToday,
+	}
+	for _, t := range r.ByFeature {
+		out.ByFeature = append(out.ByFeature, ToAIUsageTotalDTO(t, false))
+	}
+	if r.DailyQuota > 0 {
+		remaining := r.RemainingToday
+		out.RemainingToday = &remaining
+	}
+	return out
+}
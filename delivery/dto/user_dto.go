@@ -98,4 +98,22 @@ func ToUserProfileView(u *models.User) *UserProfileView {
 		CareerGoals:       u.CareerGoals,
 		ProfilePicture:    u.ProfilePicture,
 	}
-}
\ No newline at end of file
+}
+
+// ProfileSuggestionDTO uses the keys of UpdateUserProfile, so accepted values can be sent
+// back to POST /users/me as they are
+type ProfileSuggestionDTO struct {
+	CVID            string                 `json:"cv_id"`
+	YearsExperience *int                   `json:"years_experience,omitempty"`
+	EducationLevel  *models.EducationLevel `json:"education_level,omitempty"`
+	FieldOfStudy    *string                `json:"field_of_study,omitempty"`
+}
+
+func ToProfileSuggestionDTO(s *models.ProfileSuggestion) ProfileSuggestionDTO {
+	return ProfileSuggestionDTO{
+		CVID:            s.CVID,
+		YearsExperience: s.YearsExperience,
+		EducationLevel:  s.EducationLevel,
+		FieldOfStudy:    s.FieldOfStudy,
+	}
+}
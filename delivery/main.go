@@ -10,12 +10,18 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/tsigemariamzewdu/JobMate-backend/delivery/controllers"
 	"github.com/tsigemariamzewdu/JobMate-backend/delivery/routes"
-	groqpkg "github.com/tsigemariamzewdu/JobMate-backend/infrastructure/ai"
+	svc "github.com/tsigemariamzewdu/JobMate-backend/domain/interfaces/services"
+	aiinfra "github.com/tsigemariamzewdu/JobMate-backend/infrastructure/ai"
 	"github.com/tsigemariamzewdu/JobMate-backend/infrastructure/ai_service"
 	authinfra "github.com/tsigemariamzewdu/JobMate-backend/infrastructure/auth"
+	"github.com/tsigemariamzewdu/JobMate-backend/infrastructure/blobstore"
 	config "github.com/tsigemariamzewdu/JobMate-backend/infrastructure/config"
+	cvparser "github.com/tsigemariamzewdu/JobMate-backend/infrastructure/cv_parser"
+	docexport "github.com/tsigemariamzewdu/JobMate-backend/infrastructure/document_export"
 	emailinfra "github.com/tsigemariamzewdu/JobMate-backend/infrastructure/email"
 	"github.com/tsigemariamzewdu/JobMate-backend/infrastructure/job_service"
+	"github.com/tsigemariamzewdu/JobMate-backend/infrastructure/ocr"
+	"github.com/tsigemariamzewdu/JobMate-backend/infrastructure/scheduler"
 
 	mongoclient "github.com/tsigemariamzewdu/JobMate-backend/infrastructure/db/mongo"
 	// utils "github.com/tsigemariamzewdu/JobMate-backend/infrastructure/util"
@@ -46,8 +52,14 @@ func main() {
 	authRepo := repositories.NewAuthRepository(db)
 	userRepo := repositories.NewUserRepository(db)
 	cvRepo := repositories.NewCVRepository(db)
+	if err := cvRepo.EnsureIndexes(context.Background()); err != nil {
+		log.Printf("Failed to create CV indexes: %v", err)
+	}
 	feedbackRepo := repositories.NewFeedbackRepository(db)
 	skillGapRepo := repositories.NewSkillGapRepository(db)
+	if err := skillGapRepo.EnsureIndexes(context.Background()); err != nil {
+		log.Printf("Failed to create skill gap indexes: %v", err)
+	}
 	// use the name conversationRepo because feature branch used it
 	conversationRepo := repositories.NewConversationRepository(db)
 
@@ -70,16 +82,45 @@ func main() {
 	passwordService := authinfra.NewPasswordService()
 	authMiddleware := authinfra.NewAuthMiddleware(jwtService)
 	oauthService, err := authinfra.NewOAuth2Service(providersConfigs)
-	aiService := ai_service.NewGeminiAISuggestionService("gemini-1.5-flash", cfg.AIApiKey) // to be loaded from config later
 
-	textExtractor := file_parser.NewFileTextExtractor()
+	// scanned and photographed CVs are read with tesseract when it is installed
+	var ocrEngine svc.OCREngine
+	if !cfg.OCRDisabled {
+		ocrTimeout := time.Duration(cfg.OCRTimeoutSeconds) * time.Second
+		if ocrTimeout <= 0 {
+			ocrTimeout = time.Minute
+		}
+		tesseract := ocr.NewTesseractEngine(cfg.OCRLanguages, ocrTimeout)
+		if err := tesseract.Check(context.Background()); err != nil {
+			log.Printf("OCR may not work, scanned CVs could be rejected: %v", err)
+		}
+		ocrEngine = tesseract
+	}
+	textExtractor := file_parser.NewFileTextExtractor(ocrEngine)
 
 	if err != nil {
 		log.Fatalf("Failed to initialize OAuth2 service: %v", err)
 	}
 
-	// Initialize AI client (avoid alias/variable collision)
-	groqClient := groqpkg.NewGroqClient(cfg)
+	// AI clients: providers are tried in the configured order. Chat defaults to Groq and
+	// CV analysis to Gemini, as before provider selection existed. Every call is metered
+	// per user and feature against the daily token quota.
+	aiUsageRepo := repositories.NewAIUsageRepository(db)
+	if err := aiUsageRepo.EnsureIndexes(context.Background()); err != nil {
+		log.Printf("Failed to create AI usage indexes: %v", err)
+	}
+	chatAIClient, err := aiinfra.NewClient(cfg, aiinfra.ParseProviders(cfg.AIProvider, aiinfra.ProviderGroq))
+	if err != nil {
+		log.Fatalf("Failed to initialize AI client: %v", err)
+	}
+	aiClient := aiinfra.NewMeteredClient(chatAIClient, aiUsageRepo, cfg.AIDailyTokenQuota)
+	// CV analysis stays on Gemini unless CV_AI_PROVIDER says otherwise, whatever chat uses
+	cvAIClient, err := aiinfra.NewClient(cfg, aiinfra.ParseProviders(cfg.CVAIProvider, aiinfra.ProviderGemini))
+	if err != nil {
+		log.Fatalf("Failed to initialize CV analysis AI client: %v", err)
+	}
+	aiService := ai_service.NewAISuggestionService(aiinfra.NewMeteredClient(cvAIClient, aiUsageRepo, cfg.AIDailyTokenQuota))
+	usageController := controllers.NewUsageController(usecases.NewAIUsageUsecase(aiUsageRepo, cfg.AIDailyTokenQuota, time.Second*10))
 
 	// Initialize use cases
 	// Feature branch expected emailService as an extra arg for NewOTPUsecase
@@ -88,15 +129,74 @@ func main() {
 	authUsecase := usecases.NewAuthUsecase(authRepo, passwordService, jwtService, cfg.BaseURL, otpRepo, time.Second*10,emailService)
 	userUsecase := usecases.NewUserUsecase(userRepo, time.Second*10)
 
-	cvUsecase := usecases.NewCVUsecase(cvRepo, feedbackRepo, skillGapRepo, aiService, textExtractor, time.Second*15)
-	chatUsecase := usecases.NewChatUsecase(conversationRepo, groqClient, cfg)
+	// CV analysis runs in the background on a bounded pool of workers
+	cvAnalysisRepo := repositories.NewCVAnalysisJobRepository(db)
+	if err := cvAnalysisRepo.EnsureIndexes(context.Background()); err != nil {
+		log.Printf("Failed to create CV analysis indexes: %v", err)
+	}
+	cvAnalysisTimeout := time.Duration(cfg.CVAnalysisTimeoutSeconds) * time.Second
+	if cvAnalysisTimeout <= 0 {
+		cvAnalysisTimeout = 2 * time.Minute
+	}
+	cvAnalysisPool := scheduler.NewPool("cv analysis", cfg.CVAnalysisWorkers, 30*time.Second)
+	blobStore, err := blobstore.New(cfg.BlobStore, cfg.BlobLocalDir, db)
+	if err != nil {
+		log.Fatalf("Failed to initialize file storage: %v", err)
+	}
+	jobCatalogRepo := repositories.NewJobCatalogRepository(db)
+	if err := jobCatalogRepo.EnsureIndexes(context.Background()); err != nil {
+		log.Printf("Failed to create job catalog indexes: %v", err)
+	}
+	cvUsecase := usecases.NewCVUsecase(cvRepo, feedbackRepo, skillGapRepo, cvAnalysisRepo, jobCatalogRepo, aiService, textExtractor, cvparser.NewCVParser(), cvparser.NewCVScorer(), blobStore, cvAnalysisPool, time.Second*15, cvAnalysisTimeout)
+	chatUsecase := usecases.NewChatUsecase(conversationRepo, aiClient, cfg)
 
 	// Job Matching Feature
-	jobRepo := job_service.NewJobService(cfg.JobDataApiKey)
+	jobSources := job_service.NewSourceRegistry(time.Duration(cfg.JobSourceTimeoutSeconds)*time.Second, cfg.JobSources)
+	jobSources.Register(job_service.NewJobDataAPISource(cfg.JobDataApiKey), 0)
+	jobSources.Register(job_service.NewUpworkSource(), 0)
+	// AI-assisted requirement extraction is opt-in to keep ingestion cheap
+	var requirementsAI svc.IAIClient
+	if cfg.JobRequirementsUseAI {
+		requirementsAI = aiClient
+	}
+	requirementExtractor := job_service.NewRequirementExtractor(requirementsAI)
+	jobTTL := time.Duration(cfg.JobTTLHours) * time.Hour
+	// the live fallback runs while a user waits, so it only uses the rules
+	jobRepo := job_service.NewJobService(jobSources, jobCatalogRepo, job_service.NewRequirementExtractor(nil), jobTTL)
+
+	// Background ingestion keeps the job catalog fresh
+	ingestionCtx, stopIngestion := context.WithCancel(context.Background())
+	defer stopIngestion()
+	jobIngestion := job_service.NewIngestionWorker(jobSources, jobCatalogRepo, requirementExtractor, time.Duration(cfg.JobIngestionIntervalMinutes)*time.Minute, jobTTL, cfg.JobIngestionFields)
+	jobIngestion.Start(ingestionCtx)
 	jobChatRepo := repositories.NewJobChatRepository(db)
-	// usecase expects job service and jobChatRepo + groq client
-	jobUsecase := usecases.NewJobUsecase(jobRepo, jobChatRepo, groqClient)
-	jobController := controllers.NewJobController(jobUsecase, jobChatRepo, groqClient)
+	// usecase expects job service and jobChatRepo + AI client
+	jobUsecase := usecases.NewJobUsecase(jobRepo, jobChatRepo, aiClient, cvRepo, job_service.NewJobRanker(), jobCatalogRepo, cfg.DefaultPageSize, cfg.MaxPageSize)
+	jobController := controllers.NewJobController(jobUsecase, jobChatRepo, aiClient)
+
+	// Saved jobs and application tracking
+	applicationRepo := repositories.NewJobApplicationRepository(db)
+	if err := applicationRepo.EnsureIndexes(context.Background()); err != nil {
+		log.Printf("Failed to create job application indexes: %v", err)
+	}
+	applicationUsecase := usecases.NewJobApplicationUsecase(applicationRepo, jobCatalogRepo, time.Second*10)
+	applicationController := controllers.NewApplicationController(applicationUsecase)
+
+	// Cover letters drafted from the active CV, the profile and a job
+	coverLetterRepo := repositories.NewCoverLetterRepository(db)
+	coverLetterUsecase := usecases.NewCoverLetterUsecase(coverLetterRepo, cvRepo, userRepo, jobCatalogRepo, aiClient, docexport.NewDocumentExporter(), time.Second*10, cvAnalysisTimeout)
+	coverLetterController := controllers.NewCoverLetterController(coverLetterUsecase)
+
+	// Job alerts: digests are checked on a schedule and sent when due
+	jobAlertRepo := repositories.NewJobAlertRepository(db)
+	jobAlertUsecase := usecases.NewJobAlertUsecase(jobAlertRepo, jobRepo, job_service.NewJobRanker(), authRepo, emailService, otpSender, cfg.BaseURL, time.Second*30)
+	jobAlertController := controllers.NewJobAlertController(jobAlertUsecase)
+	alertInterval := time.Duration(cfg.JobAlertCheckIntervalMinutes) * time.Minute
+	if alertInterval <= 0 {
+		alertInterval = 15 * time.Minute
+	}
+	scheduler.Every(ingestionCtx, "job alerts", alertInterval, jobAlertUsecase.SendDueDigests)
+	cvAnalysisPool.Start(ingestionCtx, cvUsecase.ProcessNextAnalysis)
 
 	// Initialize controllers
 	otpController := controllers.NewOtpController(otpUsecase)
@@ -107,7 +207,7 @@ func main() {
 	chatController := controllers.NewChatController(chatUsecase)
 
 	// Setup router (add more controllers as you add features)
-	router := routes.SetupRouter(authMiddleware, userController, authController, otpController, oauthController, cvController, chatController, jobController)
+	router := routes.SetupRouter(authMiddleware, userController, authController, otpController, oauthController, cvController, chatController, jobController, applicationController, coverLetterController, jobAlertController, usageController, cfg.AdminUserIDs)
 
 	// Security: Add CORS and secure headers middleware
 	router.Use(func(c *gin.Context) {
@@ -120,7 +220,7 @@ func main() {
 	})
 	router.Use(func(c *gin.Context) {
 		c.Writer.Header().Set("Access-Control-Allow-Origin", "*")
-		c.Writer.Header().Set("Access-Control-Allow-Methods", "GET, POST, PUT, DELETE, OPTIONS")
+		c.Writer.Header().Set("Access-Control-Allow-Methods", "GET, POST, PUT, PATCH, DELETE, OPTIONS")
 		c.Writer.Header().Set("Access-Control-Allow-Headers", "Origin, Content-Type, Accept, Authorization")
 		if c.Request.Method == "OPTIONS" {
 			c.AbortWithStatus(204)
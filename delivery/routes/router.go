@@ -14,12 +14,22 @@ func SetupRouter(authMiddleware *auth.AuthMiddleware,
 	cvController *controllers.CVController,
 	chatController *controllers.ChatController,
 	jobController *controllers.JobController,
+	applicationController *controllers.ApplicationController,
+	coverLetterController *controllers.CoverLetterController,
+	jobAlertController *controllers.JobAlertController,
+	usageController *controllers.UsageController,
+	adminUserIDs []string,
 ) *gin.Engine {
 
 	router := gin.Default()
 
 	// register user + auth routes
 	registerUserRoutes(router, authMiddleware, uc, authController)
+	registerApplicationRoutes(router, authMiddleware, applicationController)
+	registerCoverLetterRoutes(router, authMiddleware, coverLetterController)
+	registerJobAlertRoutes(router, authMiddleware, jobAlertController)
+	registerUsageRoutes(router, authMiddleware, usageController, adminUserIDs)
+	registerSkillGapRoutes(router, authMiddleware, cvController)
 
 	// add OTP route
 	otpRoutes := router.Group("/auth")
@@ -37,17 +47,28 @@ func SetupRouter(authMiddleware *auth.AuthMiddleware,
 	chatRoutes := router.Group("/chat", authMiddleware.Middleware())
 	{
 		chatRoutes.POST("", chatController.SendMessage)
+		chatRoutes.POST("/stream", chatController.StreamMessage)
 		chatRoutes.GET("/history", chatController.GetConversationHistory)
 	}
 
 	//cv routes
-	cvGroup := router.Group("/cv")
+	cvGroup := router.Group("/cv", authMiddleware.Middleware())
 	NewCVRouter(*cvController, *cvGroup)
 
 	// Job suggestion route
 	jobRoutes := router.Group("/jobs")
 	{
-		jobRoutes.POST("/suggest", jobController.SuggestJobs)
+		jobRoutes.GET("", jobController.SearchJobs)
+		jobRoutes.POST("/suggest", authMiddleware.Middleware(), jobController.SuggestJobs)
+	}
+
+	// Job search conversations of the logged-in user
+	jobChatRoutes := router.Group("/jobs/chats", authMiddleware.Middleware())
+	{
+		jobChatRoutes.GET("", jobController.ListJobChats)
+		jobChatRoutes.GET("/:id", jobController.GetJobChat)
+		jobChatRoutes.PATCH("/:id", jobController.RenameJobChat)
+		jobChatRoutes.DELETE("/:id", jobController.DeleteJobChat)
 	}
 
 	return router
@@ -64,6 +85,59 @@ func registerUserRoutes(router *gin.Engine, authMiddleware *auth.AuthMiddleware,
 	
 }
 
+func registerApplicationRoutes(router *gin.Engine, authMiddleware *auth.AuthMiddleware, ac *controllers.ApplicationController) {
+	applicationRoutes := router.Group("/users/me/applications", authMiddleware.Middleware())
+	{
+		applicationRoutes.GET("", ac.ListApplications)
+		applicationRoutes.POST("", ac.SaveJob)
+		applicationRoutes.GET("/:id", ac.GetApplication)
+		applicationRoutes.PATCH("/:id", ac.UpdateApplication)
+		applicationRoutes.DELETE("/:id", ac.DeleteApplication)
+	}
+}
+
+func registerCoverLetterRoutes(router *gin.Engine, authMiddleware *auth.AuthMiddleware, cc *controllers.CoverLetterController) {
+	coverLetterRoutes := router.Group("/users/me/cover-letters", authMiddleware.Middleware())
+	{
+		coverLetterRoutes.GET("", cc.ListCoverLetters)
+		coverLetterRoutes.POST("", cc.GenerateCoverLetter)
+		coverLetterRoutes.GET("/:id", cc.GetCoverLetter)
+		coverLetterRoutes.PATCH("/:id", cc.EditCoverLetter)
+		coverLetterRoutes.DELETE("/:id", cc.DeleteCoverLetter)
+		coverLetterRoutes.GET("/:id/export", cc.ExportCoverLetter)
+	}
+}
+
+func registerJobAlertRoutes(router *gin.Engine, authMiddleware *auth.AuthMiddleware, jc *controllers.JobAlertController) {
+	alertRoutes := router.Group("/users/me/alerts", authMiddleware.Middleware())
+	{
+		alertRoutes.GET("", jc.ListAlerts)
+		alertRoutes.POST("", jc.CreateAlert)
+		alertRoutes.DELETE("/:id", jc.DeleteAlert)
+	}
+
+	// unsubscribe links in digests carry their own token
+	router.GET("/alerts/unsubscribe", jc.Unsubscribe)
+}
+
+func registerUsageRoutes(router *gin.Engine, authMiddleware *auth.AuthMiddleware, uc *controllers.UsageController, adminUserIDs []string) {
+	router.GET("/users/me/usage", authMiddleware.Middleware(), uc.GetMyUsage)
+
+	adminRoutes := router.Group("/admin", authMiddleware.Middleware(), authMiddleware.AdminOnly(adminUserIDs))
+	{
+		adminRoutes.GET("/usage", uc.GetUsageReport)
+	}
+}
+
+func registerSkillGapRoutes(router *gin.Engine, authMiddleware *auth.AuthMiddleware, cc *controllers.CVController) {
+	skillGapRoutes := router.Group("/users/me/skill-gaps", authMiddleware.Middleware())
+	{
+		skillGapRoutes.GET("", cc.ListSkillGaps)
+		skillGapRoutes.GET("/diff", cc.DiffSkillGaps)
+	}
+	router.GET("/users/me/profile-suggestions", authMiddleware.Middleware(), cc.GetProfileSuggestions)
+}
+
 func NewAuthRouter(authController controllers.AuthController, authMiddleware *auth.AuthMiddleware, group gin.RouterGroup) {
 
 	group.POST("/register", authController.Register)
@@ -74,7 +148,17 @@ func NewAuthRouter(authController controllers.AuthController, authMiddleware *au
 
 func NewCVRouter(cvController controllers.CVController, group gin.RouterGroup) {
 	group.POST("/", cvController.UploadCV)
+	group.GET("", cvController.ListCVs)
+	group.GET("/:id", cvController.GetCV)
+	group.GET("/:id/file", cvController.DownloadCV)
+	group.PATCH("/:id/activate", cvController.ActivateCV)
+	group.DELETE("/:id", cvController.DeleteCV)
+	group.POST("/:id/analyze", cvController.AnalyzeCV)
+	// misspelled path kept for existing clients
 	group.POST("/:id/analye", cvController.AnalyzeCV)
+	group.GET("/:id/analysis", cvController.GetAnalysis)
+	group.GET("/:id/feedback", cvController.ListFeedback)
+	group.POST("/:id/tailor", cvController.TailorCV)
 }
 
 func RegisterOAuthRoutes(
@@ -0,0 +1,22 @@
+package domain
+
+import "context"
+
+type aiCallerKey struct{}
+
+// AICaller identifies who an AI call is made for, so usage can be metered and quotas applied
+type AICaller struct {
+	UserID  string
+	Feature string
+}
+
+// WithAICaller tags ctx with the user and feature an AI call is made for
+func WithAICaller(ctx context.Context, userID, feature string) context.Context {
+	return context.WithValue(ctx, aiCallerKey{}, AICaller{UserID: userID, Feature: feature})
+}
+
+// AICallerFrom returns the caller set by WithAICaller
+func AICallerFrom(ctx context.Context) (AICaller, bool) {
+	caller, ok := ctx.Value(aiCallerKey{}).(AICaller)
+	return caller, ok
+}
@@ -0,0 +1,62 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Kinds of AI provider failure. AIError wraps one of these so callers can use errors.Is.
+var (
+	ErrAIRateLimited = errors.New("AI provider rate limit reached")
+	ErrAIUnavailable = errors.New("AI provider unavailable")
+	ErrAIRejected    = errors.New("AI provider rejected the request")
+	ErrAICircuitOpen = errors.New("AI provider temporarily disabled after repeated failures")
+
+	// ErrAIQuotaExceeded is returned before calling any provider once a user has used up today's tokens
+	ErrAIQuotaExceeded = errors.New("daily AI usage quota exceeded")
+
+	// ErrAIInvalidOutput means the reply still did not match the expected structure after repair attempts
+	ErrAIInvalidOutput = errors.New("AI reply did not match the expected format")
+)
+
+// AIError describes a failed call to an AI provider
+type AIError struct {
+	Provider   string
+	Kind       error         // one of the ErrAI* values above
+	StatusCode int           // HTTP status, 0 when the request never got a response
+	RetryAfter time.Duration // server-requested wait, 0 when not given
+	Message    string
+}
+
+func (e *AIError) Error() string {
+	msg := fmt.Sprintf("%s: %v", e.Provider, e.Kind)
+	if e.StatusCode != 0 {
+		msg += fmt.Sprintf(" (status %d)", e.StatusCode)
+	}
+	if e.Message != "" {
+		msg += ": " + e.Message
+	}
+	return msg
+}
+
+func (e *AIError) Unwrap() error {
+	return e.Kind
+}
+
+// Retryable reports whether the same request may succeed if tried again later
+func (e *AIError) Retryable() bool {
+	return e.Kind == ErrAIRateLimited || e.Kind == ErrAIUnavailable
+}
+
+// AIErrorKindForStatus maps an HTTP status from a provider to an error kind
+func AIErrorKindForStatus(status int) error {
+	switch {
+	case status == 429:
+		return ErrAIRateLimited
+	case status >= 500 || status == 408:
+		return ErrAIUnavailable
+	default:
+		return ErrAIRejected
+	}
+}
@@ -53,6 +53,43 @@ var (
 	ErrCVNotFound  = errors.New("cv not found")
 	ErrInvalidCVID = errors.New("invalid cv id")
 
+	ErrCVAnalysisNotFound = errors.New("cv analysis not found")
+	ErrCVAnalysisInProgress = errors.New("cv analysis already in progress")
+	ErrCVFileNotFound     = errors.New("cv has no stored file")
+	ErrUnsupportedFileType = errors.New("unsupported file type")
+	ErrNoTextFound         = errors.New("no readable text found in file")
+	ErrOCRUnavailable      = errors.New("text recognition is unavailable")
+
+	// file storage errors
+	ErrBlobNotFound   = errors.New("file not found")
+	ErrInvalidBlobKey = errors.New("invalid file key")
+
+	// job related errors
+	ErrInvalidCursor = errors.New("invalid pagination cursor")
+	ErrJobNotFound   = errors.New("job not found")
+	ErrInvalidJobID  = errors.New("invalid job id")
+
+	// application related errors
+	ErrApplicationNotFound      = errors.New("application not found")
+	ErrInvalidApplicationID     = errors.New("invalid application id")
+	ErrInvalidApplicationStatus = errors.New("invalid application status")
+	ErrJobAlreadySaved          = errors.New("job already saved")
+
+	// cover letter errors
+	ErrCoverLetterNotFound     = errors.New("cover letter not found")
+	ErrInvalidCoverLetterID    = errors.New("invalid cover letter id")
+	ErrUnsupportedExportFormat = errors.New("unsupported export format")
+
+	// job chat related errors
+	ErrJobChatNotFound  = errors.New("job chat not found")
+	ErrInvalidJobChatID = errors.New("invalid job chat id")
+
+	// job alert related errors
+	ErrAlertNotFound        = errors.New("job alert not found")
+	ErrInvalidAlertID       = errors.New("invalid job alert id")
+	ErrInvalidUnsubscribe   = errors.New("invalid unsubscribe token")
+	ErrAlertNoContactMethod = errors.New("no email or phone available for the selected channels")
+
 	//otp realted errors
 	ErrMissingOTP=errors.New("otp not found")
 	ErrOTPExpired=errors.New("otp is expired")
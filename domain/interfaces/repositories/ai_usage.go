@@ -0,0 +1,21 @@
+package interfaces
+
+import (
+	"context"
+	"time"
+
+	"github.com/tsigemariamzewdu/JobMate-backend/domain/models"
+)
+
+type IAIUsageRepository interface {
+	Record(ctx context.Context, record *models.AIUsageRecord) error
+
+	// TotalTokensSince sums the user's tokens across all features from since until now.
+	TotalTokensSince(ctx context.Context, userID string, since time.Time) (int, error)
+
+	// Totals sums usage in [from, to) per user and feature. An empty userID covers all users.
+	Totals(ctx context.Context, userID string, from, to time.Time) ([]models.AIUsageTotal, error)
+
+	// EnsureIndexes creates the indexes usage queries rely on.
+	EnsureIndexes(ctx context.Context) error
+}
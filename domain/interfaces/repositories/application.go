@@ -0,0 +1,29 @@
+package interfaces
+
+import (
+	"context"
+
+	"github.com/tsigemariamzewdu/JobMate-backend/domain/models"
+)
+
+type IJobApplicationRepository interface {
+	// Create stores a new application, returning ErrJobAlreadySaved if the user already saved
+	// a job with the same link.
+	Create(ctx context.Context, app *models.JobApplication) (string, error)
+
+	// GetByID returns the application only if it belongs to userID.
+	GetByID(ctx context.Context, userID, id string) (*models.JobApplication, error)
+
+	// ListByUser returns the user's applications, most recently updated first. An empty status lists all.
+	ListByUser(ctx context.Context, userID string, status models.ApplicationStatus) ([]models.JobApplication, error)
+
+	// ExistsForJob reports whether the user already saved a job with this link.
+	ExistsForJob(ctx context.Context, userID, jobLink string) (bool, error)
+
+	Update(ctx context.Context, app *models.JobApplication) error
+
+	Delete(ctx context.Context, userID, id string) error
+
+	// EnsureIndexes creates the indexes applications rely on, including one saved job per link.
+	EnsureIndexes(ctx context.Context) error
+}
@@ -0,0 +1,22 @@
+package interfaces
+
+import (
+	"context"
+
+	"github.com/tsigemariamzewdu/JobMate-backend/domain/models"
+)
+
+type ICoverLetterRepository interface {
+	Create(ctx context.Context, letter *models.CoverLetter) (string, error)
+
+	// GetByID returns the letter only if it belongs to userID.
+	GetByID(ctx context.Context, userID, id string) (*models.CoverLetter, error)
+
+	// ListByUser returns the user's letters, most recently updated first.
+	ListByUser(ctx context.Context, userID string) ([]models.CoverLetter, error)
+
+	// Update saves the letter's content and history.
+	Update(ctx context.Context, letter *models.CoverLetter) error
+
+	Delete(ctx context.Context, userID, id string) error
+}
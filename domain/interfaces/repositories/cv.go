@@ -7,10 +7,25 @@ import (
 )
 
 type CVRepository interface {
-	
+	// Create stores cv as the user's next version and returns its ID. It does not change which CV is active.
 	Create(ctx context.Context, cv *models.CV) (string, error)
 
+	// GetByID returns a CV that has not been deleted
 	GetByID(ctx context.Context, id string) (*models.CV, error)
 
+	// ListByUserID returns the user's CVs that have not been deleted, newest version first
+	ListByUserID(ctx context.Context, userID string) ([]models.CV, error)
+
+	// SetActive makes cvID the user's only active CV. When activations race, the latest one wins.
+	SetActive(ctx context.Context, userID, cvID string) error
+
+	// SoftDelete hides the CV and deactivates it, keeping the document for history
+	SoftDelete(ctx context.Context, userID, cvID string) error
+
+	EnsureIndexes(ctx context.Context) error
+
 	Update(ctx context.Context, cv *models.CV) error
+
+	// GetLatestAnalyzedByUserID returns the user's most recently analyzed CV, preferring the active one.
+	GetLatestAnalyzedByUserID(ctx context.Context, userID string) (*models.CV, error)
 }
\ No newline at end of file
@@ -0,0 +1,35 @@
+package interfaces
+
+import (
+	"context"
+	"time"
+
+	"github.com/tsigemariamzewdu/JobMate-backend/domain/models"
+)
+
+type CVAnalysisJobRepository interface {
+	// CreateOrGetActive queues job unless the CV already has a queued or running analysis, in
+	// which case that one is returned. created reports whether job was queued.
+	CreateOrGetActive(ctx context.Context, job *models.CVAnalysisJob) (existing *models.CVAnalysisJob, created bool, err error)
+
+	// ClaimNext marks the oldest queued job as running and returns it. Jobs left running since
+	// before staleBefore (e.g. by a crashed instance) are claimed again.
+	// domain.ErrCVAnalysisNotFound means there is nothing to do.
+	ClaimNext(ctx context.Context, staleBefore time.Time) (*models.CVAnalysisJob, error)
+
+	// Complete stores the result and, like Fail, lets the CV be analyzed again
+	Complete(ctx context.Context, id string, result *models.AISuggestions, score *models.CVScore) error
+
+	// Fail marks the job failed, which lets the CV be analyzed again
+	Fail(ctx context.Context, id string, reason string) error
+
+	GetByID(ctx context.Context, id string) (*models.CVAnalysisJob, error)
+
+	// ListSucceededByUserID returns the user's successful analyses across all CVs, newest first
+	ListSucceededByUserID(ctx context.Context, userID string, limit int64) ([]models.CVAnalysisJob, error)
+
+	// GetLatestByCVID returns the most recent analysis of a CV
+	GetLatestByCVID(ctx context.Context, cvID string) (*models.CVAnalysisJob, error)
+
+	EnsureIndexes(ctx context.Context) error
+}
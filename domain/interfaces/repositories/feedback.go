@@ -8,4 +8,7 @@ import (
 
 type FeedbackRepository interface {
 	Create(ctx context.Context, f *models.CVFeedback) (string, error)
+
+	// ListByCVID returns the feedback generated for a CV, newest first
+	ListByCVID(ctx context.Context, cvID string) ([]models.CVFeedback, error)
 }
@@ -1,7 +1,38 @@
 package interfaces
 
-import "github.com/tsigemariamzewdu/JobMate-backend/domain/models"
+import (
+	"context"
+	"time"
+
+	"github.com/tsigemariamzewdu/JobMate-backend/domain/models"
+)
 
 type IJobRepository interface {
-	GetCuratedJobs(field, lookingFor, experience string, skills []string, language string) ([]models.Job, string, error)
+	// GetCuratedJobs returns the merged jobs of every enabled source along with the sources that failed.
+	GetCuratedJobs(ctx context.Context, field, lookingFor, experience string, skills []string, language string) ([]models.Job, []models.JobSourceFailure, string, error)
+}
+
+// IJobCatalogRepository persists ingested job postings
+type IJobCatalogRepository interface {
+	// UpsertMany inserts or refreshes jobs, deduplicating them by (company, title, link) fingerprint.
+	// Every upserted job is marked as seen now and kept until now+ttl.
+	UpsertMany(ctx context.Context, jobs []models.Job, ttl time.Duration) (int, error)
+
+	// LoadExtracted copies the cleaned description, requirements, seniority, employment type and
+	// salary already stored for jobs in the catalog onto them. known[i] reports whether jobs[i]
+	// was found with extracted requirements and so needs no new extraction.
+	LoadExtracted(ctx context.Context, jobs []models.Job) (known []bool, err error)
+
+	// Find returns non-expired jobs matching the filter, newest first, and the cursor of the next page
+	// (empty when there are no more results).
+	Find(ctx context.Context, filter models.JobFilter) ([]models.Job, string, error)
+
+	// GetByID returns a catalog job, expired or not.
+	GetByID(ctx context.Context, id string) (*models.Job, error)
+
+	// DeleteExpired removes postings whose expiry has passed.
+	DeleteExpired(ctx context.Context) (int64, error)
+
+	// EnsureIndexes creates the indexes the catalog relies on.
+	EnsureIndexes(ctx context.Context) error
 }
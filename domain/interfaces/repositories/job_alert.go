@@ -0,0 +1,34 @@
+package interfaces
+
+import (
+	"context"
+	"time"
+
+	"github.com/tsigemariamzewdu/JobMate-backend/domain/models"
+)
+
+type IJobAlertRepository interface {
+	Create(ctx context.Context, alert *models.JobAlert) (string, error)
+
+	ListByUser(ctx context.Context, userID string) ([]models.JobAlert, error)
+
+	// Delete removes the alert only if it belongs to userID.
+	Delete(ctx context.Context, userID, id string) error
+
+	// DeactivateByToken stops the alert owning the unsubscribe token.
+	DeactivateByToken(ctx context.Context, token string) error
+
+	// ClaimDue takes one active alert whose next run is at or before now and moves that run to
+	// lockedUntil, so no other instance sends it meanwhile. It returns ErrAlertNotFound once
+	// nothing is due.
+	ClaimDue(ctx context.Context, now, lockedUntil time.Time) (*models.JobAlert, error)
+
+	// MarkRun records a digest run and schedules the next one. sentAt is nil when nothing was sent.
+	MarkRun(ctx context.Context, id string, sentAt *time.Time, nextRunAt time.Time) error
+
+	// FilterUnsent returns the job keys that were not yet sent for the alert.
+	FilterUnsent(ctx context.Context, alertID string, jobKeys []string) ([]string, error)
+
+	// MarkSent remembers that these jobs were sent for the alert.
+	MarkSent(ctx context.Context, alertID string, jobKeys []string) error
+}
@@ -0,0 +1,26 @@
+package interfaces
+
+import (
+	"context"
+
+	"github.com/tsigemariamzewdu/JobMate-backend/domain/models"
+)
+
+// IJobChatRepository stores job search conversations. Reads and writes are scoped to the owning user.
+type IJobChatRepository interface {
+	CreateJobChat(ctx context.Context, chat *models.JobChat) (string, error)
+
+	// AppendTurn adds messages to an existing chat and replaces its query and job results.
+	AppendTurn(ctx context.Context, userID, chatID string, messages []models.JobChatMessage, query map[string]any, jobResults []models.Job) error
+
+	AppendMessage(ctx context.Context, chatID string, message models.JobChatMessage) error
+
+	GetJobChatByID(ctx context.Context, userID, chatID string) (*models.JobChat, error)
+
+	// GetJobChatsByUserID lists the user's chats, most recently active first, without job results.
+	GetJobChatsByUserID(ctx context.Context, userID string) ([]*models.JobChat, error)
+
+	RenameJobChat(ctx context.Context, userID, chatID, title string) error
+
+	DeleteJobChat(ctx context.Context, userID, chatID string) error
+}
@@ -7,5 +7,13 @@ import (
 )
 
 type SkillGapRepository interface {
-	CreateMany(ctx context.Context, gaps []*models.SkillGap) error
+	// ReplaceForUser makes gaps the user's current skill gaps. Each gap is stored under its
+	// (user, skill) key, updating the existing gap for a skill rather than adding another, and
+	// gaps for skills not in gaps are removed. Skill names are compared after normalization.
+	ReplaceForUser(ctx context.Context, userID string, gaps []*models.SkillGap) error
+
+	// ListByUserID returns the user's gaps, most important first
+	ListByUserID(ctx context.Context, userID string) ([]models.SkillGap, error)
+
+	EnsureIndexes(ctx context.Context) error
 }
@@ -8,4 +8,12 @@ import (
 // IAIClient defines the contract for interacting with an AI chat completion service
 type IAIClient interface {
 	GetChatCompletion(ctx context.Context, messages []models.AIMessage) (string, error)
+
+	// StreamChatCompletion calls onDelta with each piece of the reply as it arrives and returns
+	// everything received so far. Returning an error from onDelta stops the stream.
+	StreamChatCompletion(ctx context.Context, messages []models.AIMessage, onDelta func(delta string) error) (string, error)
+
+	// GetJSONCompletion asks for a reply that is a single JSON value following schema, using the
+	// provider's structured output mode where it has one. Callers should still validate the reply.
+	GetJSONCompletion(ctx context.Context, messages []models.AIMessage, schema *models.JSONSchema) (string, error)
 }
@@ -8,4 +8,8 @@ import (
 
 type AISuggestionService interface {
 	Analyze(ctx context.Context, cvText string) (*models.AISuggestions, error)
+
+	// Tailor suggests how to fit a CV to job. missingKeywords are the job's keywords the CV
+	// does not mention, found without AI, so suggestions can address them.
+	Tailor(ctx context.Context, cvText string, job *models.Job, missingKeywords []string) (*models.AITailoring, error)
 }
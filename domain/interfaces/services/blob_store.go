@@ -0,0 +1,21 @@
+package interfaces
+
+import (
+	"context"
+	"io"
+
+	"github.com/tsigemariamzewdu/JobMate-backend/domain/models"
+)
+
+// BlobStore keeps files under caller-chosen keys such as "cvs/<user>/<id>/cv.pdf"
+type BlobStore interface {
+	// Put stores the content under key, replacing any previous content, and returns its
+	// size and checksum
+	Put(ctx context.Context, key string, content io.Reader, contentType string) (*models.BlobInfo, error)
+
+	// Get opens the content stored under key. The caller closes the reader.
+	// domain.ErrBlobNotFound is returned for unknown keys.
+	Get(ctx context.Context, key string) (io.ReadCloser, *models.BlobInfo, error)
+
+	Delete(ctx context.Context, key string) error
+}
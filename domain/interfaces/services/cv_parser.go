@@ -1,8 +1,28 @@
 package interfaces
 
 
-import "mime/multipart"
+import (
+	"context"
+	"mime/multipart"
+
+	"github.com/tsigemariamzewdu/JobMate-backend/domain/models"
+)
 
 type TextExtractor interface {
-	Extract(fileHeader *multipart.FileHeader) (string, error)
+	// Extract returns the text of an uploaded document and the content type it was sniffed as.
+	// domain.ErrUnsupportedFileType is returned for formats it cannot read and
+	// domain.ErrNoTextFound when a readable format held no text, even after OCR.
+	Extract(ctx context.Context, fileHeader *multipart.FileHeader) (text string, contentType string, err error)
+}
+
+// CVParser reads sections, skills, experience and education out of CV text with rules,
+// so it works when the AI does not
+type CVParser interface {
+	Parse(text string) *models.ParsedCV
+}
+
+// CVScorer grades CV text with a fixed rubric. target may be nil, in which case keyword
+// coverage is skipped.
+type CVScorer interface {
+	Score(text string, parsed *models.ParsedCV, target *models.Job) *models.CVScore
 }
@@ -0,0 +1,9 @@
+package interfaces
+
+import "github.com/tsigemariamzewdu/JobMate-backend/domain/models"
+
+// DocumentExporter renders plain text, paragraphs separated by blank lines, as a downloadable
+// document. domain.ErrUnsupportedExportFormat is returned for unknown formats.
+type DocumentExporter interface {
+	Export(format string, text string, language models.Language) (document []byte, contentType string, err error)
+}
@@ -0,0 +1,8 @@
+package interfaces
+
+import "github.com/tsigemariamzewdu/JobMate-backend/domain/models"
+
+// IJobRanker scores jobs against a candidate and orders them best match first
+type IJobRanker interface {
+	Rank(jobs []models.Job, profile models.CandidateProfile) []models.RankedJob
+}
@@ -0,0 +1,13 @@
+package interfaces
+
+import (
+	"context"
+
+	"github.com/tsigemariamzewdu/JobMate-backend/domain/models"
+)
+
+// IJobRequirementExtractor derives structured requirements from a job's title and description
+type IJobRequirementExtractor interface {
+	// Extract fills Requirements, Seniority, EmploymentType and Salary on the job where they can be determined.
+	Extract(ctx context.Context, job *models.Job) error
+}
@@ -0,0 +1,19 @@
+package interfaces
+
+import (
+	"context"
+
+	"github.com/tsigemariamzewdu/JobMate-backend/domain/models"
+)
+
+// JobSource is a single upstream job board (JobDataAPI, Upwork, local Ethiopian boards, ...)
+type JobSource interface {
+	// Name identifies the source in job results and in failure reports.
+	Name() string
+
+	// Supports reports whether the source serves the given job type ("local", "remote", "freelance").
+	Supports(lookingFor string) bool
+
+	// FetchJobs returns the postings matching the request.
+	FetchJobs(ctx context.Context, req models.JobSuggestionRequest) ([]models.Job, error)
+}
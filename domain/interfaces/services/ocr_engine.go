@@ -0,0 +1,10 @@
+package interfaces
+
+import "context"
+
+// OCREngine recognizes the text of scanned documents and photos
+type OCREngine interface {
+	// Recognize reads a JPEG or PNG image, or every page of an image-only PDF.
+	// domain.ErrOCRUnavailable is returned when the engine cannot run on this host.
+	Recognize(ctx context.Context, data []byte, contentType string) (string, error)
+}
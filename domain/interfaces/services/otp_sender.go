@@ -1,5 +1,12 @@
 package interfaces
+
+// ISMSSender sends arbitrary text messages
+type ISMSSender interface {
+	SendSMS(phone string, message string) error
+}
+
 // IOTPSender defines SMS sending (service interface)
 type IOTPSender interface {
+	ISMSSender
 	SendOTP(phone string, code string) error
 }
@@ -0,0 +1,6 @@
+package interfaces
+
+// WorkNotifier wakes background workers when new work has been queued
+type WorkNotifier interface {
+	Notify()
+}
@@ -0,0 +1,17 @@
+package interfaces
+
+import (
+	"context"
+	"time"
+
+	"github.com/tsigemariamzewdu/JobMate-backend/domain/models"
+)
+
+type IAIUsageUsecase interface {
+	// GetUserUsage returns the user's usage per feature over the last days days (today included)
+	// and where they stand against today's quota.
+	GetUserUsage(ctx context.Context, userID string, days int) (*models.AIUsageReport, error)
+
+	// GetReport sums usage of all users per user and feature in [from, to).
+	GetReport(ctx context.Context, from, to time.Time) ([]models.AIUsageTotal, error)
+}
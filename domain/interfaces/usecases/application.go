@@ -0,0 +1,21 @@
+package interfaces
+
+import (
+	"context"
+
+	"github.com/tsigemariamzewdu/JobMate-backend/domain/models"
+)
+
+type IJobApplicationUsecase interface {
+	// SaveJob bookmarks a catalog job (by jobID) or a job given inline.
+	SaveJob(ctx context.Context, userID, jobID string, job *models.Job, notes string) (*models.JobApplication, error)
+
+	// Update validates the whole change before writing it, so either all of it is saved or none.
+	Update(ctx context.Context, userID, id string, update models.ApplicationUpdate) (*models.JobApplication, error)
+
+	Get(ctx context.Context, userID, id string) (*models.JobApplication, error)
+
+	List(ctx context.Context, userID string, status models.ApplicationStatus) ([]models.JobApplication, error)
+
+	Delete(ctx context.Context, userID, id string) error
+}
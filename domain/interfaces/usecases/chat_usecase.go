@@ -8,5 +8,7 @@ import (
 
 type IChatUsecase interface {
 	SendMessage(ctx context.Context, userID string, message string) (*models.UserConversation, error)
+	// StreamMessage relays the reply through onDelta as it is generated and saves it when the stream ends.
+	StreamMessage(ctx context.Context, userID string, message string, onDelta func(delta string) error) (*models.UserConversation, error)
 	GetConversationHistory(ctx context.Context, userID string, limit int64) ([]models.UserConversation, error)
 }
@@ -0,0 +1,27 @@
+package interfaces
+
+import (
+	"context"
+
+	"github.com/tsigemariamzewdu/JobMate-backend/domain/models"
+)
+
+type ICoverLetterUsecase interface {
+	// Generate writes a letter for a catalog job (by jobID) or a job given inline, from the
+	// user's active CV and profile, and stores it as a new draft. An empty language uses the
+	// user's preferred language.
+	Generate(ctx context.Context, userID, jobID string, job *models.Job, language models.Language) (*models.CoverLetter, error)
+
+	// Edit replaces the letter's text, keeping the previous version in its history.
+	Edit(ctx context.Context, userID, id, content string) (*models.CoverLetter, error)
+
+	Get(ctx context.Context, userID, id string) (*models.CoverLetter, error)
+
+	List(ctx context.Context, userID string) ([]models.CoverLetter, error)
+
+	Delete(ctx context.Context, userID, id string) error
+
+	// Export renders the letter in format (models.ExportFormatText or models.ExportFormatDocx)
+	// and returns the document with its content type.
+	Export(ctx context.Context, userID, id, format string) (letter *models.CoverLetter, document []byte, contentType string, err error)
+}
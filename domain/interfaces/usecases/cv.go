@@ -2,13 +2,61 @@ package interfaces
 
 import (
 	"context"
+	"io"
 	"mime/multipart"
 
 	"github.com/tsigemariamzewdu/JobMate-backend/domain/models"
 )
 
 type ICVUsecase interface {
+	// Upload stores a new version of the user's CV and makes it the active one. An uploaded
+	// file is kept so it can be downloaded again.
 	Upload(ctx context.Context, userID string, rawText string, file *multipart.FileHeader) (*models.CV, error)
 
-	Analyze(ctx context.Context, cvID string) (*models.AISuggestions, error)
+	// List returns the user's CV versions, newest first, without their text.
+	List(ctx context.Context, userID string) ([]models.CV, error)
+
+	// Get, Activate and Delete act on a CV owned by userID. Other users' CVs are reported as not found.
+	Get(ctx context.Context, userID, cvID string) (*models.CV, error)
+
+	// Activate makes the CV the user's only active one.
+	Activate(ctx context.Context, userID, cvID string) (*models.CV, error)
+
+	// OpenFile opens the original upload of a CV owned by userID. The caller closes the reader.
+	// domain.ErrCVFileNotFound is returned for CVs that were pasted as text.
+	OpenFile(ctx context.Context, userID, cvID string) (*models.CV, io.ReadCloser, error)
+
+	// Delete soft deletes the CV, activating the newest remaining one if it was active.
+	Delete(ctx context.Context, userID, cvID string) error
+
+	// RequestAnalysis queues the AI analysis of a CV owned by userID. Other users' CVs are reported
+	// as not found. If the CV already has a queued or running analysis that job is returned and
+	// created is false. targetJobID optionally names a catalog job whose keywords the CV is scored
+	// against; an analysis still in progress for another target gives domain.ErrCVAnalysisInProgress.
+	RequestAnalysis(ctx context.Context, userID, cvID, targetJobID string) (job *models.CVAnalysisJob, created bool, err error)
+
+	// GetAnalysis returns the latest analysis of a CV owned by userID.
+	GetAnalysis(ctx context.Context, userID, cvID string) (*models.CVAnalysisJob, error)
+
+	// ListFeedback returns the feedback of every analysis of a CV owned by userID, newest first.
+	ListFeedback(ctx context.Context, userID, cvID string) ([]models.CVFeedback, error)
+
+	// ListSkillGaps returns the user's current skill gaps, one per skill.
+	ListSkillGaps(ctx context.Context, userID string) ([]models.SkillGap, error)
+
+	// DiffSkillGaps compares the skill gaps found by two of the user's analyses. When both IDs
+	// are empty the two most recent analyses are compared.
+	DiffSkillGaps(ctx context.Context, userID, fromAnalysisID, toAnalysisID string) (*models.SkillGapDiff, error)
+
+	// Tailor compares a CV owned by userID with a job: the catalog job jobID, or jobDescription when
+	// jobID is empty. It returns keyword overlap, missing requirements, rewritten bullets, a summary
+	// for the job and skill gaps weighted for it. The user's stored skill gaps are left unchanged.
+	Tailor(ctx context.Context, userID, cvID, jobID, jobDescription string) (*models.CVTailoring, error)
+
+	// SuggestProfile works out years of experience, education level and field of study from the
+	// user's active CV, or their newest one when none is active.
+	SuggestProfile(ctx context.Context, userID string) (*models.ProfileSuggestion, error)
+
+	// ProcessNextAnalysis runs one queued analysis, reporting false when none is waiting.
+	ProcessNextAnalysis(ctx context.Context) (bool, error)
 }
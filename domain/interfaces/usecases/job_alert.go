@@ -0,0 +1,22 @@
+package interfaces
+
+import (
+	"context"
+
+	"github.com/tsigemariamzewdu/JobMate-backend/domain/models"
+)
+
+type IJobAlertUsecase interface {
+	// Subscribe saves a search the user wants digests for. Missing contact details are taken from the profile.
+	Subscribe(ctx context.Context, alert *models.JobAlert) (*models.JobAlert, error)
+
+	List(ctx context.Context, userID string) ([]models.JobAlert, error)
+
+	Delete(ctx context.Context, userID, id string) error
+
+	// Unsubscribe stops the alert identified by the token sent in every digest.
+	Unsubscribe(ctx context.Context, token string) error
+
+	// SendDueDigests sends a digest of unsent matching jobs for every alert that is due.
+	SendDueDigests(ctx context.Context) error
+}
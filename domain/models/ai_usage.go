@@ -0,0 +1,55 @@
+package models
+
+import "time"
+
+// Features that consume AI tokens, used to break usage down
+const (
+	AIFeatureChat            = "chat"
+	AIFeatureCVAnalysis      = "cv_analysis"
+	AIFeatureCVTailoring     = "cv_tailoring"
+	AIFeatureCoverLetter     = "cover_letter"
+	AIFeatureJobChat         = "job_chat"
+	AIFeatureJobRequirements = "job_requirements"
+)
+
+// AIUsage is the token count a provider reported for one call
+type AIUsage struct {
+	Provider         string
+	Model            string
+	PromptTokens     int
+	CompletionTokens int
+}
+
+// AIUsageRecord is one metered AI call. UserID is empty for background work.
+type AIUsageRecord struct {
+	ID               string
+	UserID           string
+	Feature          string
+	Provider         string
+	Model            string
+	PromptTokens     int
+	CompletionTokens int
+	TotalTokens      int
+	Estimated        bool // the provider gave no counts, so they were estimated from text length
+	CreatedAt        time.Time
+}
+
+// AIUsageTotal sums usage for a user and feature over a period
+type AIUsageTotal struct {
+	UserID           string
+	Feature          string
+	Requests         int
+	PromptTokens     int
+	CompletionTokens int
+	TotalTokens      int
+}
+
+// AIUsageReport is a user's usage over a period together with today's quota position
+type AIUsageReport struct {
+	From           time.Time
+	To             time.Time
+	ByFeature      []AIUsageTotal
+	DailyQuota     int // 0 means unlimited
+	UsedToday      int
+	RemainingToday int
+}
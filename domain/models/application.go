@@ -0,0 +1,51 @@
+package models
+
+import "time"
+
+type ApplicationStatus string
+
+const (
+	ApplicationSaved        ApplicationStatus = "saved"
+	ApplicationApplied      ApplicationStatus = "applied"
+	ApplicationInterviewing ApplicationStatus = "interviewing"
+	ApplicationOffer        ApplicationStatus = "offer"
+	ApplicationRejected     ApplicationStatus = "rejected"
+)
+
+// IsValid reports whether s is one of the known application statuses
+func (s ApplicationStatus) IsValid() bool {
+	switch s {
+	case ApplicationSaved, ApplicationApplied, ApplicationInterviewing, ApplicationOffer, ApplicationRejected:
+		return true
+	}
+	return false
+}
+
+// ApplicationStatusChange is one step in an application's history
+type ApplicationStatusChange struct {
+	Status    ApplicationStatus
+	Note      string
+	ChangedAt time.Time
+}
+
+// ApplicationUpdate is a change to an application; nil fields are left as they are
+type ApplicationUpdate struct {
+	Status     *ApplicationStatus
+	StatusNote string    // recorded in the history with the new status
+	Date       time.Time // when the status changed; zero means now
+	Notes      *string
+}
+
+// JobApplication is a job a user bookmarked and tracks through the hiring process
+type JobApplication struct {
+	ID        string
+	UserID    string
+	JobID     string // catalog job ID, empty for jobs saved from elsewhere
+	Job       Job
+	Status    ApplicationStatus
+	Notes     string
+	AppliedAt *time.Time
+	History   []ApplicationStatusChange
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
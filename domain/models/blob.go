@@ -0,0 +1,12 @@
+package models
+
+import "time"
+
+// BlobInfo describes a stored file
+type BlobInfo struct {
+	Key         string
+	ContentType string
+	Size        int64
+	Checksum    string // hex SHA-256 of the content
+	CreatedAt   time.Time
+}
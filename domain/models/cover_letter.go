@@ -0,0 +1,38 @@
+package models
+
+import "time"
+
+type CoverLetterSource string
+
+const (
+	CoverLetterGenerated CoverLetterSource = "generated"
+	CoverLetterEdited    CoverLetterSource = "edited"
+)
+
+// CoverLetterRevision is one saved version of a letter's text
+type CoverLetterRevision struct {
+	Content   string
+	Source    CoverLetterSource
+	CreatedAt time.Time
+}
+
+// CoverLetter is a user's draft letter for one job. History holds every version, oldest
+// first; the last one is Content.
+type CoverLetter struct {
+	ID        string
+	UserID    string
+	CVID      string // the CV the letter was written from
+	JobID     string // catalog job ID, empty for jobs given inline
+	Job       Job
+	Language  Language
+	Content   string
+	History   []CoverLetterRevision
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// Formats a document can be exported in
+const (
+	ExportFormatText = "txt"
+	ExportFormatDocx = "docx"
+)
@@ -20,16 +20,21 @@ const (
 type CV struct {
 	ID                  string
 	UserID              string
+	Version             int // 1 for the user's first CV, counting deleted ones
 	FileName            string
+	File                *BlobInfo // the uploaded original; nil for pasted text
 	OriginalText        string
 	ExtractedSkills     []string
 	ExtractedExperience []string
 	ExtractedEducation  []string
+	Experience          []ExperienceEntry
+	Education           []EducationEntry
 	Summary             string
 	Language            Language
 	IsActive            bool
 	CreatedAt           time.Time
 	UpdatedAt           time.Time
+	DeletedAt           *time.Time
 }
 
 type CVFeedback struct {
@@ -37,15 +42,19 @@ type CVFeedback struct {
 	SessionID              string
 	UserID                 string
 	CVID                   string
+	AnalysisID             string // the analysis that produced this feedback
 	Strengths              string
 	Weaknesses             string
 	ImprovementSuggestions string
+	Score                  *CVScore // nil for feedback from before scoring
 	GeneratedAt            time.Time
 }
 
+// SkillGap is the latest known gap for one skill of a user; re-analysis updates it in place
 type SkillGap struct {
 	ID                     string
 	UserID                 string
+	CVID                   string // the CV whose analysis last reported the gap
 	SkillName              string
 	CurrentLevel           int // 1-5 scale
 	RecommendedLevel       int // 1-5 scale
@@ -60,6 +69,8 @@ type AISuggestions struct {
 		ExtractedSkills     []string
 		ExtractedExperience []string
 		ExtractedEducation  []string
+		Experience          []ExperienceEntry
+		Education           []EducationEntry
 		Summary             string
 	}
 	CVFeedback struct {
@@ -75,3 +86,46 @@ type AISuggestions struct {
 		ImprovementSuggestions string
 	}
 }
+
+// ProfileSuggestion holds profile values worked out from a CV. A nil field means the CV
+// does not say enough to suggest it.
+type ProfileSuggestion struct {
+	CVID            string
+	YearsExperience *int
+	EducationLevel  *EducationLevel
+	FieldOfStudy    *string
+}
+
+type SkillGapChange string
+
+const (
+	SkillGapAdded     SkillGapChange = "added"
+	SkillGapResolved  SkillGapChange = "resolved"
+	SkillGapImproved  SkillGapChange = "improved"
+	SkillGapRegressed SkillGapChange = "regressed"
+	SkillGapUnchanged SkillGapChange = "unchanged"
+)
+
+// SkillGapDiffEntry compares one skill across two analyses. Levels are 0 on the side
+// where the skill was not reported.
+type SkillGapDiffEntry struct {
+	SkillName            string
+	Change               SkillGapChange
+	FromCurrentLevel     int
+	ToCurrentLevel       int
+	FromRecommendedLevel int
+	ToRecommendedLevel   int
+	FromImportance       Importance
+	ToImportance         Importance
+}
+
+// SkillGapDiff shows how skill gaps changed from one analysis to a later one
+type SkillGapDiff struct {
+	FromAnalysisID string
+	ToAnalysisID   string
+	FromCVID       string
+	ToCVID         string
+	FromAt         time.Time
+	ToAt           time.Time
+	Entries        []SkillGapDiffEntry
+}
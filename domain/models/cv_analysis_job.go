@@ -0,0 +1,29 @@
+package models
+
+import "time"
+
+type CVAnalysisStatus string
+
+const (
+	CVAnalysisQueued    CVAnalysisStatus = "queued"
+	CVAnalysisRunning   CVAnalysisStatus = "running"
+	CVAnalysisSucceeded CVAnalysisStatus = "succeeded"
+	CVAnalysisFailed    CVAnalysisStatus = "failed"
+)
+
+// CVAnalysisJob tracks one background AI analysis of a CV
+type CVAnalysisJob struct {
+	ID          string
+	UserID      string
+	CVID        string
+	Status      CVAnalysisStatus
+	Attempts    int
+	Error       string // user-facing reason when Status is failed
+	Result      *AISuggestions
+	TargetJobID string   // the catalog job the CV is scored against, if any
+	Score       *CVScore // set once the analysis succeeded
+	CreatedAt   time.Time
+	StartedAt   *time.Time
+	FinishedAt  *time.Time
+	UpdatedAt   time.Time
+}
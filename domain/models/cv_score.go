@@ -0,0 +1,32 @@
+package models
+
+// CVCheck names one check of the CV scoring rubric
+type CVCheck string
+
+const (
+	CVCheckSections               CVCheck = "sections"
+	CVCheckContactInfo            CVCheck = "contact_info"
+	CVCheckLength                 CVCheck = "length"
+	CVCheckQuantifiedAchievements CVCheck = "quantified_achievements"
+	CVCheckActionVerbs            CVCheck = "action_verbs"
+	CVCheckDateConsistency        CVCheck = "date_consistency"
+	CVCheckKeywordCoverage        CVCheck = "keyword_coverage"
+)
+
+// CVCheckResult is the outcome of one check. A skipped check, such as keyword coverage
+// without a target job, does not count toward the score.
+type CVCheckResult struct {
+	Check     CVCheck
+	Passed    bool
+	Skipped   bool
+	Points    int
+	MaxPoints int
+	Detail    string // what was found, e.g. "Missing sections: summary"
+}
+
+// CVScore grades a CV the way applicant tracking systems screen it
+type CVScore struct {
+	Score       int    // 0-100, over the checks that were not skipped
+	TargetJobID string // the catalog job keywords were matched against, if any
+	Checks      []CVCheckResult
+}
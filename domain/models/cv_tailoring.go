@@ -0,0 +1,33 @@
+package models
+
+import "time"
+
+// BulletSuggestion rewrites one line of a CV to speak to a particular job
+type BulletSuggestion struct {
+	Original  string // as written in the CV
+	Suggested string
+	Reason    string
+}
+
+// AITailoring is what the AI suggests to fit a CV to one job
+type AITailoring struct {
+	MissingRequirements []string // requirements of the job the CV does not show
+	BulletSuggestions   []BulletSuggestion
+	TailoredSummary     string
+	SkillGaps           []SkillGap // Importance is for this job rather than the user's goals
+}
+
+// CVTailoring compares a CV with one job, from the catalog or pasted by the user
+type CVTailoring struct {
+	CVID                string
+	JobID               string // empty for a pasted job description
+	JobTitle            string
+	MatchedKeywords     []string
+	MissingKeywords     []string
+	KeywordCoverage     int // percentage of the job's keywords the CV mentions
+	MissingRequirements []string
+	BulletSuggestions   []BulletSuggestion
+	TailoredSummary     string
+	SkillGaps           []SkillGap
+	GeneratedAt         time.Time
+}
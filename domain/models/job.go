@@ -1,12 +1,70 @@
 package models
 
+import "time"
+
 type Job struct {
-	Title        string
-	Company      string
-	Location     string
-	Requirements []string
-	Type         string      // "local", "remote", "freelance"
-	Source       string      
-	Link         string
-	Language     string     
+	ID             string
+	Title          string
+	Company        string
+	Location       string
+	Description    string
+	Requirements   []string
+	Seniority      string // "entry", "junior", "mid", "senior"
+	EmploymentType string // "full_time", "part_time", "contract", "internship", "temporary"
+	Salary         *SalaryRange
+	Type           string      // "local", "remote", "freelance"
+	Source         string      
+	Link           string
+	Language       string     
+	PostedAt     time.Time
+	LastSeenAt   time.Time
+	ExpiresAt    time.Time
+}
+
+// SalaryRange is the pay advertised in a posting
+type SalaryRange struct {
+	Min      float64
+	Max      float64
+	Currency string // "ETB", "USD", ...
+	Period   string // "month", "year", "hour"
+}
+
+// Seniority levels
+const (
+	SeniorityEntry  = "entry"
+	SeniorityJunior = "junior"
+	SeniorityMid    = "mid"
+	SenioritySenior = "senior"
+)
+
+// JobFilter narrows a job catalog query
+type JobFilter struct {
+	Keyword     string
+	Types       []string
+	Location    string
+	Source      string
+	PostedSince time.Time
+	Cursor      string // opaque position returned by the previous page
+	Limit       int
+}
+
+// JobSourceFailure records a job source that could not be queried during a search
+type JobSourceFailure struct {
+	Source string
+	Error  string
+}
+
+// CandidateProfile is what a job seeker brings to a job match
+type CandidateProfile struct {
+	Skills     []string
+	Experience string // free text such as "2 years" or "junior"
+	Location   string
+}
+
+// RankedJob is a job scored against a candidate profile
+type RankedJob struct {
+	Job           Job
+	MatchScore    int // 0-100
+	MatchedSkills []string
+	MissingSkills []string
 }
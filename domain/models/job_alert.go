@@ -0,0 +1,45 @@
+package models
+
+import "time"
+
+type AlertFrequency string
+
+const (
+	AlertDaily  AlertFrequency = "daily"
+	AlertWeekly AlertFrequency = "weekly"
+)
+
+// Interval is the time between two digests
+func (f AlertFrequency) Interval() time.Duration {
+	if f == AlertWeekly {
+		return 7 * 24 * time.Hour
+	}
+	return 24 * time.Hour
+}
+
+type AlertChannel string
+
+const (
+	AlertChannelEmail AlertChannel = "email"
+	AlertChannelSMS   AlertChannel = "sms"
+)
+
+// JobAlert is a saved search the user receives periodic digests for
+type JobAlert struct {
+	ID               string
+	UserID           string
+	Field            string
+	LookingFor       string
+	Skills           []string
+	Language         string
+	Frequency        AlertFrequency
+	Channels         []AlertChannel
+	Email            string
+	Phone            string
+	UnsubscribeToken string
+	Active           bool
+	LastSentAt       *time.Time
+	NextRunAt        time.Time
+	CreatedAt        time.Time
+	UpdatedAt        time.Time
+}
@@ -13,6 +13,7 @@ type JobChatMessage struct {
 type JobChat struct {
 	ID             string           `bson:"_id,omitempty" json:"id"`
 	UserID         string           `bson:"user_id" json:"user_id"`
+	Title          string           `bson:"title" json:"title"`
 	Messages       []JobChatMessage `bson:"messages" json:"messages"`
 	JobSearchQuery map[string]any   `bson:"job_search_query" json:"job_search_query"`
 	JobResults     []Job            `bson:"job_results" json:"job_results"`
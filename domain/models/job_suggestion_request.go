@@ -5,5 +5,6 @@ type JobSuggestionRequest struct {
 	Field      string
 	Skills     []string
 	Experience string
+	Location   string
 	Language   string
 }
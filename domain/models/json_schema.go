@@ -0,0 +1,14 @@
+package models
+
+// JSONSchema describes the JSON an AI reply must follow. It covers the subset of JSON Schema
+// that the providers' structured output modes accept.
+type JSONSchema struct {
+	Type        string // "object", "array", "string", "integer", "number" or "boolean"
+	Description string
+	Properties  map[string]*JSONSchema
+	Required    []string
+	Items       *JSONSchema
+	Enum        []string
+	Minimum     *float64
+	Maximum     *float64
+}
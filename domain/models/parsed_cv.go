@@ -0,0 +1,52 @@
+package models
+
+// CVSection names a part of a CV recognized from its heading
+type CVSection string
+
+const (
+	CVSectionContact        CVSection = "contact" // also the text before the first heading
+	CVSectionSummary        CVSection = "summary"
+	CVSectionExperience     CVSection = "experience"
+	CVSectionEducation      CVSection = "education"
+	CVSectionSkills         CVSection = "skills"
+	CVSectionLanguages      CVSection = "languages"
+	CVSectionCertifications CVSection = "certifications"
+	CVSectionProjects       CVSection = "projects"
+	CVSectionReferences     CVSection = "references"
+	CVSectionOther          CVSection = "other" // awards, hobbies and other recognized headings
+)
+
+// YearMonth is a date as CVs give it; Month is 0 when only the year is known
+type YearMonth struct {
+	Year  int
+	Month int
+}
+
+// ExperienceEntry is one position. End is zero while Current is set.
+type ExperienceEntry struct {
+	Title        string
+	Organization string
+	Start        YearMonth
+	End          YearMonth
+	Current      bool
+	Description  string
+}
+
+// EducationEntry is one qualification. StartYear is 0 when only the graduation year is given.
+type EducationEntry struct {
+	Institution    string
+	Degree         string // as written, e.g. "BSc" or "የመጀመሪያ ዲግሪ"
+	Level          EducationLevel
+	FieldOfStudy   string
+	StartYear      int
+	GraduationYear int
+}
+
+// ParsedCV is what rule-based parsing recovers from CV text, without AI
+type ParsedCV struct {
+	Sections   map[CVSection]string
+	Summary    string
+	Skills     []string
+	Experience []ExperienceEntry
+	Education  []EducationEntry
+}
@@ -24,6 +24,26 @@ const (
 	EducationOther      EducationLevel = "other"
 )
 
+// Rank orders levels from lowest to highest; unknown values rank 0
+func (l EducationLevel) Rank() int {
+	switch l {
+	case EducationOther:
+		return 1
+	case EducationHighSchool:
+		return 2
+	case EducationDiploma:
+		return 3
+	case EducationBachelor:
+		return 4
+	case EducationMaster:
+		return 5
+	case EducationPhD:
+		return 6
+	default:
+		return 0
+	}
+}
+
 type User struct {
 	UserID            string
 	// OauthID           int
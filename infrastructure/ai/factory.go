@@ -0,0 +1,112 @@
+package ai
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	svc "github.com/tsigemariamzewdu/JobMate-backend/domain/interfaces/services"
+	config "github.com/tsigemariamzewdu/JobMate-backend/infrastructure/config"
+)
+
+const (
+	ProviderGroq   = "groq"
+	ProviderGemini = "gemini"
+	ProviderOpenAI = "openai"
+	ProviderStub   = "stub"
+)
+
+// ParseProviders reads a comma-separated, ordered provider list such as "groq,gemini,stub".
+// An empty list falls back to def.
+func ParseProviders(list, def string) []string {
+	var providers []string
+	for _, p := range strings.Split(list, ",") {
+		if p = strings.ToLower(strings.TrimSpace(p)); p != "" {
+			providers = append(providers, p)
+		}
+	}
+	if len(providers) == 0 {
+		return []string{def}
+	}
+	return providers
+}
+
+// NewProvider builds the client for a single named provider from config
+func NewProvider(cfg *config.Config, name string) (svc.IAIClient, error) {
+	switch name {
+	case ProviderGroq:
+		return NewGroqClient(cfg), nil
+	case ProviderGemini:
+		apiKey := cfg.GeminiApiKey
+		if apiKey == "" {
+			apiKey = cfg.AIApiKey
+		}
+		return NewGeminiClient(apiKey, cfg.GeminiModelName, cfg.AITemperature), nil
+	case ProviderOpenAI:
+		baseURL := cfg.OpenAIApiBaseUrl
+		if baseURL == "" {
+			baseURL = "https://api.openai.com/v1"
+		}
+		return NewOpenAICompatibleClient(cfg.OpenAIApiKey, cfg.OpenAIModelName, baseURL, cfg.AITemperature), nil
+	case ProviderStub:
+		return NewStubClient(), nil
+	default:
+		return nil, fmt.Errorf("unknown AI provider %q", name)
+	}
+}
+
+// retryPolicy reads the retry settings, using defaults for unset values. A negative
+// AI_MAX_RETRIES turns retries off.
+func retryPolicy(cfg *config.Config) RetryPolicy {
+	policy := RetryPolicy{
+		MaxRetries: cfg.AIMaxRetries,
+		BaseDelay:  time.Duration(cfg.AIRetryBaseDelayMs) * time.Millisecond,
+		MaxDelay:   10 * time.Second,
+	}
+	if policy.MaxRetries == 0 {
+		policy.MaxRetries = 2
+	}
+	if policy.MaxRetries < 0 {
+		policy.MaxRetries = 0
+	}
+	if policy.BaseDelay <= 0 {
+		policy.BaseDelay = 500 * time.Millisecond
+	}
+	return policy
+}
+
+func newBreaker(cfg *config.Config) *CircuitBreaker {
+	threshold := cfg.AIBreakerThreshold
+	if threshold <= 0 {
+		threshold = 5
+	}
+	cooldown := time.Duration(cfg.AIBreakerCooldownSeconds) * time.Second
+	if cooldown <= 0 {
+		cooldown = 30 * time.Second
+	}
+	return NewCircuitBreaker(threshold, cooldown)
+}
+
+// NewClient builds a client over the given providers. Each remote provider retries on its own
+// and has its own circuit breaker; more than one provider gives a FailoverClient that tries
+// them in the listed order.
+func NewClient(cfg *config.Config, providers []string) (svc.IAIClient, error) {
+	var chain []Provider
+	for _, name := range providers {
+		client, err := NewProvider(cfg, name)
+		if err != nil {
+			return nil, err
+		}
+		if name != ProviderStub {
+			client = NewResilientClient(name, client, retryPolicy(cfg), newBreaker(cfg))
+		}
+		chain = append(chain, Provider{Name: name, Client: client})
+	}
+	if len(chain) == 0 {
+		return nil, fmt.Errorf("no AI providers configured")
+	}
+	if len(chain) == 1 {
+		return chain[0].Client, nil
+	}
+	return NewFailoverClient(chain...), nil
+}
@@ -0,0 +1,79 @@
+package ai
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+
+	svc "github.com/tsigemariamzewdu/JobMate-backend/domain/interfaces/services"
+	"github.com/tsigemariamzewdu/JobMate-backend/domain/models"
+)
+
+// Provider is an AI client together with the name used in config and logs
+type Provider struct {
+	Name   string
+	Client svc.IAIClient
+}
+
+// FailoverClient tries its providers in order and returns the first successful reply
+type FailoverClient struct {
+	Providers []Provider
+}
+
+var _ svc.IAIClient = (*FailoverClient)(nil)
+
+func NewFailoverClient(providers ...Provider) *FailoverClient {
+	return &FailoverClient{Providers: providers}
+}
+
+func (fc *FailoverClient) GetChatCompletion(ctx context.Context, messages []models.AIMessage) (string, error) {
+	var errs []error
+	for _, p := range fc.Providers {
+		reply, err := p.Client.GetChatCompletion(ctx, messages)
+		if err == nil {
+			return reply, nil
+		}
+		if ctx.Err() != nil {
+			return "", ctx.Err()
+		}
+		log.Printf("AI provider %s failed, trying next: %v", p.Name, err)
+		errs = append(errs, fmt.Errorf("%s: %w", p.Name, err))
+	}
+	return "", fmt.Errorf("all AI providers failed: %w", errors.Join(errs...))
+}
+
+func (fc *FailoverClient) GetJSONCompletion(ctx context.Context, messages []models.AIMessage, schema *models.JSONSchema) (string, error) {
+	var errs []error
+	for _, p := range fc.Providers {
+		reply, err := p.Client.GetJSONCompletion(ctx, messages, schema)
+		if err == nil {
+			return reply, nil
+		}
+		if ctx.Err() != nil {
+			return "", ctx.Err()
+		}
+		log.Printf("AI provider %s failed, trying next: %v", p.Name, err)
+		errs = append(errs, fmt.Errorf("%s: %w", p.Name, err))
+	}
+	return "", fmt.Errorf("all AI providers failed: %w", errors.Join(errs...))
+}
+
+// StreamChatCompletion only fails over while nothing has been sent to onDelta;
+// once a provider has started answering, switching would splice two different replies.
+func (fc *FailoverClient) StreamChatCompletion(ctx context.Context, messages []models.AIMessage, onDelta func(delta string) error) (string, error) {
+	var errs []error
+	for _, p := range fc.Providers {
+		started := false
+		reply, err := p.Client.StreamChatCompletion(ctx, messages, func(delta string) error {
+			started = true
+			return onDelta(delta)
+		})
+		if err == nil || started || ctx.Err() != nil {
+			return reply, err
+		}
+		log.Printf("AI provider %s failed, trying next: %v", p.Name, err)
+		errs = append(errs, fmt.Errorf("%s: %w", p.Name, err))
+	}
+	return "", fmt.Errorf("all AI providers failed: %w", errors.Join(errs...))
+}
@@ -0,0 +1,181 @@
+package ai
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+
+	"github.com/tsigemariamzewdu/JobMate-backend/domain"
+	svc "github.com/tsigemariamzewdu/JobMate-backend/domain/interfaces/services"
+	"github.com/tsigemariamzewdu/JobMate-backend/domain/models"
+	"google.golang.org/genai"
+)
+
+// GeminiClient talks to Google's Gemini API. The underlying genai client is created on first
+// use and shared by all later calls.
+type GeminiClient struct {
+	APIKey      string
+	Model       string
+	Temperature float32
+
+	mu     sync.Mutex
+	client *genai.Client
+}
+
+var _ svc.IAIClient = (*GeminiClient)(nil)
+
+func NewGeminiClient(apiKey, model string, temperature float32) *GeminiClient {
+	if model == "" {
+		model = "gemini-1.5-flash"
+	}
+	return &GeminiClient{APIKey: apiKey, Model: model, Temperature: temperature}
+}
+
+func (gc *GeminiClient) genaiClient(ctx context.Context) (*genai.Client, error) {
+	gc.mu.Lock()
+	defer gc.mu.Unlock()
+	if gc.client != nil {
+		return gc.client, nil
+	}
+	client, err := genai.NewClient(ctx, &genai.ClientConfig{
+		APIKey:  gc.APIKey,
+		Backend: genai.BackendGeminiAPI,
+	})
+	if err != nil {
+		return nil, &domain.AIError{Provider: ProviderGemini, Kind: domain.ErrAIRejected, Message: err.Error()}
+	}
+	gc.client = client
+	return client, nil
+}
+
+// request converts chat messages to Gemini contents; system messages become the system instruction
+func (gc *GeminiClient) request(messages []models.AIMessage) ([]*genai.Content, *genai.GenerateContentConfig) {
+	var system []string
+	var contents []*genai.Content
+	for _, m := range messages {
+		switch m.Role {
+		case "system":
+			system = append(system, m.Content)
+		case "assistant":
+			contents = append(contents, genai.NewContentFromText(m.Content, genai.RoleModel))
+		default:
+			contents = append(contents, genai.NewContentFromText(m.Content, genai.RoleUser))
+		}
+	}
+
+	config := &genai.GenerateContentConfig{}
+	if gc.Temperature > 0 {
+		config.Temperature = genai.Ptr(gc.Temperature)
+	}
+	if len(system) > 0 {
+		config.SystemInstruction = genai.NewContentFromText(strings.Join(system, "\n\n"), genai.RoleUser)
+	}
+	return contents, config
+}
+
+func (gc *GeminiClient) GetChatCompletion(ctx context.Context, messages []models.AIMessage) (string, error) {
+	contents, config := gc.request(messages)
+	return gc.generate(ctx, contents, config)
+}
+
+// GetJSONCompletion constrains generation to schema with Gemini's response schema support
+func (gc *GeminiClient) GetJSONCompletion(ctx context.Context, messages []models.AIMessage, schema *models.JSONSchema) (string, error) {
+	contents, config := gc.request(messages)
+	config.ResponseMIMEType = "application/json"
+	config.ResponseSchema = toGenaiSchema(schema)
+	return gc.generate(ctx, contents, config)
+}
+
+func (gc *GeminiClient) generate(ctx context.Context, contents []*genai.Content, config *genai.GenerateContentConfig) (string, error) {
+	client, err := gc.genaiClient(ctx)
+	if err != nil {
+		return "", err
+	}
+	result, err := client.Models.GenerateContent(ctx, gc.Model, contents, config)
+	if err != nil {
+		return "", gc.classify(ctx, err)
+	}
+	gc.reportUsage(ctx, result)
+	return result.Text(), nil
+}
+
+func (gc *GeminiClient) StreamChatCompletion(ctx context.Context, messages []models.AIMessage, onDelta func(delta string) error) (string, error) {
+	client, err := gc.genaiClient(ctx)
+	if err != nil {
+		return "", err
+	}
+	contents, config := gc.request(messages)
+
+	var assembled strings.Builder
+	var last *genai.GenerateContentResponse
+	defer func() { gc.reportUsage(ctx, last) }()
+	for chunk, err := range client.Models.GenerateContentStream(ctx, gc.Model, contents, config) {
+		if err != nil {
+			return assembled.String(), gc.classify(ctx, err)
+		}
+		if chunk.UsageMetadata != nil {
+			last = chunk // the final chunk carries the totals for the whole stream
+		}
+		delta := chunk.Text()
+		if delta == "" {
+			continue
+		}
+		assembled.WriteString(delta)
+		if err := onDelta(delta); err != nil {
+			return assembled.String(), err
+		}
+	}
+	return assembled.String(), nil
+}
+
+func (gc *GeminiClient) reportUsage(ctx context.Context, resp *genai.GenerateContentResponse) {
+	if resp == nil || resp.UsageMetadata == nil {
+		return
+	}
+	reportUsage(ctx, models.AIUsage{
+		Provider:         ProviderGemini,
+		Model:            gc.Model,
+		PromptTokens:     int(resp.UsageMetadata.PromptTokenCount),
+		CompletionTokens: int(resp.UsageMetadata.CandidatesTokenCount),
+	})
+}
+
+func toGenaiSchema(s *models.JSONSchema) *genai.Schema {
+	if s == nil {
+		return nil
+	}
+	out := &genai.Schema{
+		Type:        genai.Type(strings.ToUpper(s.Type)),
+		Description: s.Description,
+		Required:    s.Required,
+		Items:       toGenaiSchema(s.Items),
+		Enum:        s.Enum,
+		Minimum:     s.Minimum,
+		Maximum:     s.Maximum,
+	}
+	if len(s.Properties) > 0 {
+		out.Properties = make(map[string]*genai.Schema, len(s.Properties))
+		for name, prop := range s.Properties {
+			out.Properties[name] = toGenaiSchema(prop)
+		}
+	}
+	return out
+}
+
+// classify turns genai failures into domain AI errors
+func (gc *GeminiClient) classify(ctx context.Context, err error) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+	var apiErr genai.APIError
+	if errors.As(err, &apiErr) {
+		return &domain.AIError{
+			Provider:   ProviderGemini,
+			Kind:       domain.AIErrorKindForStatus(apiErr.Code),
+			StatusCode: apiErr.Code,
+			Message:    apiErr.Message,
+		}
+	}
+	return &domain.AIError{Provider: ProviderGemini, Kind: domain.ErrAIUnavailable, Message: err.Error()}
+}
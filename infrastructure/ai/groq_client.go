@@ -1,44 +1,187 @@
 package ai
 
 import (
+	"bufio"
 	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
+	"io"
 	"net/http"
+	"strconv"
+	"strings"
 	"time"
 
 	dto "github.com/tsigemariamzewdu/JobMate-backend/delivery/dto"
+	"github.com/tsigemariamzewdu/JobMate-backend/domain"
 	svc "github.com/tsigemariamzewdu/JobMate-backend/domain/interfaces/services"
 	"github.com/tsigemariamzewdu/JobMate-backend/domain/models"
 	config "github.com/tsigemariamzewdu/JobMate-backend/infrastructure/config"
 )
 
 type GroqClient struct {
-	APIKey     string
-	Model      string
-	BaseURL    string
-	Temperature float32 
-	HTTPClient *http.Client
-}
+	Provider    string // name used in errors, "groq" unless pointed elsewhere
+	APIKey      string
+	Model       string
+	BaseURL     string
+	Temperature float32
+	HTTPClient  *http.Client
 
+	// StreamHTTPClient has no overall timeout, which would cut long streams off mid-reply;
+	// only the request context bounds a stream
+	StreamHTTPClient *http.Client
+}
 
 var _ svc.IAIClient = (*GroqClient)(nil)
 
 // NewGroqClient creates a new GroqClient instance
 func NewGroqClient(cfg *config.Config) *GroqClient {
+	client := NewOpenAICompatibleClient(cfg.AIApiKey, cfg.AIModelName, cfg.AIApiBaseUrl, cfg.AITemperature)
+	client.Provider = ProviderGroq
+	return client
+}
+
+// NewOpenAICompatibleClient points the same client at any endpoint that speaks the OpenAI
+// chat completions API, which is what Groq implements
+func NewOpenAICompatibleClient(apiKey, model, baseURL string, temperature float32) *GroqClient {
+	transport := http.DefaultTransport
 	return &GroqClient{
-		APIKey:     cfg.AIApiKey,
-		Model:      cfg.AIModelName,
-		BaseURL:    cfg.AIApiBaseUrl, 
-		Temperature: cfg.AITemperature, 
-		HTTPClient: &http.Client{Timeout: 30 * time.Second},
+		Provider:         ProviderOpenAI,
+		APIKey:           apiKey,
+		Model:            model,
+		BaseURL:          strings.TrimRight(baseURL, "/"),
+		Temperature:      temperature,
+		HTTPClient:       &http.Client{Timeout: 30 * time.Second, Transport: transport},
+		StreamHTTPClient: &http.Client{Transport: transport},
 	}
 }
 
 // GetChatCompletion sends a request to the Groq API and returns the AI's response
 func (gc *GroqClient) GetChatCompletion(ctx context.Context, domainMessages []models.AIMessage) (string, error) {
-	
+	req, err := gc.newRequest(ctx, dto.GroqAPIRequest{Messages: toAIMessageDTOs(domainMessages)})
+	if err != nil {
+		return "", err
+	}
+	return gc.complete(ctx, req)
+}
+
+// GetJSONCompletion uses JSON mode. The schema itself is not sent, since not every
+// OpenAI-compatible endpoint supports json_schema; prompts carry the expected shape.
+func (gc *GroqClient) GetJSONCompletion(ctx context.Context, domainMessages []models.AIMessage, schema *models.JSONSchema) (string, error) {
+	req, err := gc.newRequest(ctx, dto.GroqAPIRequest{
+		Messages:       toAIMessageDTOs(domainMessages),
+		ResponseFormat: &dto.GroqResponseFormat{Type: "json_object"},
+	})
+	if err != nil {
+		return "", err
+	}
+	return gc.complete(ctx, req)
+}
+
+func (gc *GroqClient) complete(ctx context.Context, req *http.Request) (string, error) {
+	resp, err := gc.HTTPClient.Do(req)
+	if err != nil {
+		return "", gc.transportError(ctx, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", gc.errorFromResponse(resp)
+	}
+
+	var groqResponse dto.GroqAPIResponse
+	if err := json.NewDecoder(resp.Body).Decode(&groqResponse); err != nil {
+		return "", fmt.Errorf("failed to decode Groq API response: %w", err)
+	}
+
+	if groqResponse.Usage.TotalTokens > 0 {
+		reportUsage(ctx, models.AIUsage{
+			Provider:         gc.Provider,
+			Model:            groqResponse.Model,
+			PromptTokens:     groqResponse.Usage.PromptTokens,
+			CompletionTokens: groqResponse.Usage.CompletionTokens,
+		})
+	}
+
+	if len(groqResponse.Choices) > 0 {
+		return groqResponse.Choices[0].Message.Content, nil
+	}
+
+	return "", &domain.AIError{Provider: gc.Provider, Kind: domain.ErrAIUnavailable, Message: "no choices returned"}
+}
+
+// StreamChatCompletion requests a streamed completion and relays each content delta to onDelta.
+// The returned text is what was assembled before the stream ended, failed or was cancelled.
+func (gc *GroqClient) StreamChatCompletion(ctx context.Context, domainMessages []models.AIMessage, onDelta func(delta string) error) (string, error) {
+	req, err := gc.newRequest(ctx, dto.GroqAPIRequest{
+		Messages:      toAIMessageDTOs(domainMessages),
+		Stream:        true,
+		StreamOptions: &dto.GroqStreamOptions{IncludeUsage: true},
+	})
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Accept", "text/event-stream")
+
+	resp, err := gc.StreamHTTPClient.Do(req)
+	if err != nil {
+		return "", gc.transportError(ctx, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", gc.errorFromResponse(resp)
+	}
+
+	var assembled strings.Builder
+	scanner := bufio.NewScanner(resp.Body)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if !strings.HasPrefix(line, "data:") {
+			continue // blank separators, comments and other SSE fields
+		}
+		data := strings.TrimSpace(strings.TrimPrefix(line, "data:"))
+		if data == "[DONE]" {
+			return assembled.String(), nil
+		}
+
+		var chunk dto.GroqStreamChunk
+		if err := json.Unmarshal([]byte(data), &chunk); err != nil {
+			return assembled.String(), fmt.Errorf("failed to decode Groq stream chunk: %w", err)
+		}
+		usage := chunk.Usage
+		if usage == nil && chunk.XGroq != nil {
+			usage = chunk.XGroq.Usage
+		}
+		if usage != nil {
+			reportUsage(ctx, models.AIUsage{
+				Provider:         gc.Provider,
+				Model:            chunk.Model,
+				PromptTokens:     usage.PromptTokens,
+				CompletionTokens: usage.CompletionTokens,
+			})
+		}
+		if len(chunk.Choices) == 0 || chunk.Choices[0].Delta.Content == "" {
+			continue
+		}
+
+		delta := chunk.Choices[0].Delta.Content
+		assembled.WriteString(delta)
+		if err := onDelta(delta); err != nil {
+			return assembled.String(), err
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return assembled.String(), gc.transportError(ctx, err)
+	}
+	if ctx.Err() != nil {
+		return assembled.String(), ctx.Err()
+	}
+	return assembled.String(), nil
+}
+
+func toAIMessageDTOs(domainMessages []models.AIMessage) []dto.AIMessageDTO {
 	var dtoMessages []dto.AIMessageDTO
 	for _, msg := range domainMessages {
 		dtoMessages = append(dtoMessages, dto.AIMessageDTO{
@@ -46,55 +189,73 @@ func (gc *GroqClient) GetChatCompletion(ctx context.Context, domainMessages []mo
 			Content: msg.Content,
 		})
 	}
+	return dtoMessages
+}
 
-	requestBody := dto.GroqAPIRequest{
-		Messages:    dtoMessages, 
-		Model:       gc.Model,
-		Temperature: gc.Temperature,
-		MaxTokens:   1000,
-		Stream:      false,
-	}
+// newRequest fills in the client's model settings and builds the HTTP request
+func (gc *GroqClient) newRequest(ctx context.Context, requestBody dto.GroqAPIRequest) (*http.Request, error) {
+	requestBody.Model = gc.Model
+	requestBody.Temperature = gc.Temperature
+	requestBody.MaxTokens = 1000
 
 	jsonBody, err := json.Marshal(requestBody)
 	if err != nil {
-		return "", fmt.Errorf("failed to marshal Groq API request: %w", err)
+		return nil, fmt.Errorf("failed to marshal Groq API request: %w", err)
 	}
 
 	req, err := http.NewRequestWithContext(ctx, "POST", fmt.Sprintf("%s/chat/completions", gc.BaseURL), bytes.NewBuffer(jsonBody))
 	if err != nil {
-		return "", fmt.Errorf("failed to create Groq API request: %w", err)
+		return nil, fmt.Errorf("failed to create Groq API request: %w", err)
 	}
 
 	req.Header.Set("Content-Type", "application/json")
 	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", gc.APIKey))
+	return req, nil
+}
 
-	resp, err := gc.HTTPClient.Do(req)
-	if err != nil {
-		return "", fmt.Errorf("failed to send request to Groq API: %w", err)
+// transportError reports a failure to reach the provider. Cancellation by the caller is
+// returned as is so it is never mistaken for an outage.
+func (gc *GroqClient) transportError(ctx context.Context, err error) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
 	}
-	defer resp.Body.Close()
+	return &domain.AIError{Provider: gc.Provider, Kind: domain.ErrAIUnavailable, Message: err.Error()}
+}
 
-	if resp.StatusCode != http.StatusOK {
-		var errorResponse struct {
-			Error struct {
-				Message string `json:"message"`
-				Type    string `json:"type"`
-			} `json:"error"`
-		}
-		if decodeErr := json.NewDecoder(resp.Body).Decode(&errorResponse); decodeErr == nil {
-			return "", fmt.Errorf("groq API returned error status %d: %s (Type: %s)", resp.StatusCode, errorResponse.Error.Message, errorResponse.Error.Type)
-		}
-		return "", fmt.Errorf("groq API returned error status: %s", resp.Status)
+func (gc *GroqClient) errorFromResponse(resp *http.Response) error {
+	aiErr := &domain.AIError{
+		Provider:   gc.Provider,
+		Kind:       domain.AIErrorKindForStatus(resp.StatusCode),
+		StatusCode: resp.StatusCode,
+		RetryAfter: parseRetryAfter(resp.Header.Get("Retry-After")),
+		Message:    resp.Status,
 	}
 
-	var groqResponse dto.GroqAPIResponse
-	if err := json.NewDecoder(resp.Body).Decode(&groqResponse); err != nil {
-		return "", fmt.Errorf("failed to decode Groq API response: %w", err)
+	var errorResponse struct {
+		Error struct {
+			Message string `json:"message"`
+			Type    string `json:"type"`
+		} `json:"error"`
 	}
-
-	if len(groqResponse.Choices) > 0 {
-		return groqResponse.Choices[0].Message.Content, nil
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, 64*1024))
+	if json.Unmarshal(body, &errorResponse) == nil && errorResponse.Error.Message != "" {
+		aiErr.Message = fmt.Sprintf("%s (Type: %s)", errorResponse.Error.Message, errorResponse.Error.Type)
 	}
+	return aiErr
+}
 
-	return "", fmt.Errorf("groq API returned no choices")
-}
\ No newline at end of file
+// parseRetryAfter reads a Retry-After header given either in seconds or as an HTTP date
+func parseRetryAfter(value string) time.Duration {
+	if value == "" {
+		return 0
+	}
+	if secs, err := strconv.Atoi(strings.TrimSpace(value)); err == nil && secs > 0 {
+		return time.Duration(secs) * time.Second
+	}
+	if t, err := http.ParseTime(value); err == nil {
+		if d := time.Until(t); d > 0 {
+			return d
+		}
+	}
+	return 0
+}
@@ -0,0 +1,153 @@
+package ai
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/tsigemariamzewdu/JobMate-backend/domain"
+	repo "github.com/tsigemariamzewdu/JobMate-backend/domain/interfaces/repositories"
+	svc "github.com/tsigemariamzewdu/JobMate-backend/domain/interfaces/services"
+	"github.com/tsigemariamzewdu/JobMate-backend/domain/models"
+)
+
+type usageCollectorKey struct{}
+
+// usageCollector gathers the token counts providers report during one metered call
+type usageCollector struct {
+	mu     sync.Mutex
+	usages []models.AIUsage
+}
+
+func withUsageCollector(ctx context.Context) (context.Context, *usageCollector) {
+	c := &usageCollector{}
+	return context.WithValue(ctx, usageCollectorKey{}, c), c
+}
+
+// reportUsage is called by providers with the counts from their response; outside a metered
+// call it does nothing
+func reportUsage(ctx context.Context, usage models.AIUsage) {
+	c, ok := ctx.Value(usageCollectorKey{}).(*usageCollector)
+	if !ok {
+		return
+	}
+	c.mu.Lock()
+	c.usages = append(c.usages, usage)
+	c.mu.Unlock()
+}
+
+// MeteredClient records the tokens of every call against the caller set with domain.WithAICaller
+// and refuses calls from users who have used up their daily quota
+type MeteredClient struct {
+	Client          svc.IAIClient
+	Usage           repo.IAIUsageRepository
+	DailyTokenQuota int // 0 means unlimited
+}
+
+var _ svc.IAIClient = (*MeteredClient)(nil)
+
+func NewMeteredClient(client svc.IAIClient, usage repo.IAIUsageRepository, dailyTokenQuota int) *MeteredClient {
+	return &MeteredClient{Client: client, Usage: usage, DailyTokenQuota: dailyTokenQuota}
+}
+
+func (m *MeteredClient) GetChatCompletion(ctx context.Context, messages []models.AIMessage) (string, error) {
+	caller, _ := domain.AICallerFrom(ctx)
+	if err := m.checkQuota(ctx, caller); err != nil {
+		return "", err
+	}
+	callCtx, collected := withUsageCollector(ctx)
+	reply, err := m.Client.GetChatCompletion(callCtx, messages)
+	m.record(ctx, caller, messages, reply, err, collected)
+	return reply, err
+}
+
+func (m *MeteredClient) GetJSONCompletion(ctx context.Context, messages []models.AIMessage, schema *models.JSONSchema) (string, error) {
+	caller, _ := domain.AICallerFrom(ctx)
+	if err := m.checkQuota(ctx, caller); err != nil {
+		return "", err
+	}
+	callCtx, collected := withUsageCollector(ctx)
+	reply, err := m.Client.GetJSONCompletion(callCtx, messages, schema)
+	m.record(ctx, caller, messages, reply, err, collected)
+	return reply, err
+}
+
+func (m *MeteredClient) StreamChatCompletion(ctx context.Context, messages []models.AIMessage, onDelta func(delta string) error) (string, error) {
+	caller, _ := domain.AICallerFrom(ctx)
+	if err := m.checkQuota(ctx, caller); err != nil {
+		return "", err
+	}
+	callCtx, collected := withUsageCollector(ctx)
+	reply, err := m.Client.StreamChatCompletion(callCtx, messages, onDelta)
+	m.record(ctx, caller, messages, reply, err, collected)
+	return reply, err
+}
+
+// StartOfDay is when the current quota day began (midnight UTC)
+func StartOfDay(now time.Time) time.Time {
+	y, mo, d := now.UTC().Date()
+	return time.Date(y, mo, d, 0, 0, 0, 0, time.UTC)
+}
+
+func (m *MeteredClient) checkQuota(ctx context.Context, caller domain.AICaller) error {
+	if m.DailyTokenQuota <= 0 || caller.UserID == "" {
+		return nil
+	}
+	used, err := m.Usage.TotalTokensSince(ctx, caller.UserID, StartOfDay(time.Now()))
+	if err != nil {
+		// metering problems should not take the assistant down
+		log.Printf("failed to check AI quota for user %s: %v", caller.UserID, err)
+		return nil
+	}
+	if used >= m.DailyTokenQuota {
+		return domain.ErrAIQuotaExceeded
+	}
+	return nil
+}
+
+func (m *MeteredClient) record(ctx context.Context, caller domain.AICaller, messages []models.AIMessage, reply string, callErr error, collected *usageCollector) {
+	collected.mu.Lock()
+	usages := collected.usages
+	collected.mu.Unlock()
+
+	rec := &models.AIUsageRecord{
+		UserID:    caller.UserID,
+		Feature:   caller.Feature,
+		CreatedAt: time.Now(),
+	}
+	if len(usages) > 0 {
+		for _, u := range usages {
+			rec.PromptTokens += u.PromptTokens
+			rec.CompletionTokens += u.CompletionTokens
+		}
+		last := usages[len(usages)-1]
+		rec.Provider, rec.Model = last.Provider, last.Model
+	} else {
+		if callErr != nil && reply == "" {
+			return // nothing was generated and nothing reported, so nothing to bill
+		}
+		rec.PromptTokens, rec.CompletionTokens = estimateTokens(messages, reply)
+		rec.Estimated = true
+	}
+	rec.TotalTokens = rec.PromptTokens + rec.CompletionTokens
+	if rec.Feature == "" {
+		rec.Feature = "other"
+	}
+
+	// the caller's context may already be cancelled when a stream is cut off
+	saveCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), 5*time.Second)
+	defer cancel()
+	if err := m.Usage.Record(saveCtx, rec); err != nil {
+		log.Printf("failed to record AI usage for user %s: %v", caller.UserID, err)
+	}
+}
+
+// estimateTokens approximates token counts at roughly four characters per token
+func estimateTokens(messages []models.AIMessage, reply string) (prompt, completion int) {
+	chars := 0
+	for _, m := range messages {
+		chars += len(m.Content)
+	}
+	return (chars + 3) / 4, (len(reply) + 3) / 4
+}
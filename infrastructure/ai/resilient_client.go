@@ -0,0 +1,130 @@
+package ai
+
+import (
+	"context"
+	"errors"
+	"math/rand/v2"
+	"time"
+
+	"github.com/tsigemariamzewdu/JobMate-backend/domain"
+	svc "github.com/tsigemariamzewdu/JobMate-backend/domain/interfaces/services"
+	"github.com/tsigemariamzewdu/JobMate-backend/domain/models"
+)
+
+// RetryPolicy controls how often and how long to wait before repeating a failed AI call
+type RetryPolicy struct {
+	MaxRetries int
+	BaseDelay  time.Duration
+	MaxDelay   time.Duration
+}
+
+// delay is the wait before retry number attempt (0-based). A Retry-After from the provider
+// wins up to MaxDelay; otherwise the backoff doubles per attempt with jitter so clients don't
+// retry in step.
+func (p RetryPolicy) delay(attempt int, retryAfter time.Duration) time.Duration {
+	if retryAfter > 0 {
+		return min(retryAfter, p.MaxDelay)
+	}
+	backoff := p.BaseDelay << attempt
+	if backoff <= 0 || backoff > p.MaxDelay {
+		backoff = p.MaxDelay
+	}
+	half := backoff / 2
+	return half + rand.N(half+1)
+}
+
+// ResilientClient retries rate-limited and unavailable calls to one provider and trips a
+// circuit breaker when the provider keeps failing, so failover can move on without waiting.
+type ResilientClient struct {
+	Name    string
+	Client  svc.IAIClient
+	Policy  RetryPolicy
+	Breaker *CircuitBreaker
+}
+
+var _ svc.IAIClient = (*ResilientClient)(nil)
+
+func NewResilientClient(name string, client svc.IAIClient, policy RetryPolicy, breaker *CircuitBreaker) *ResilientClient {
+	return &ResilientClient{Name: name, Client: client, Policy: policy, Breaker: breaker}
+}
+
+func (rc *ResilientClient) GetChatCompletion(ctx context.Context, messages []models.AIMessage) (string, error) {
+	var reply string
+	err := rc.do(ctx, func() (bool, error) {
+		var err error
+		reply, err = rc.Client.GetChatCompletion(ctx, messages)
+		return true, err
+	})
+	return reply, err
+}
+
+func (rc *ResilientClient) GetJSONCompletion(ctx context.Context, messages []models.AIMessage, schema *models.JSONSchema) (string, error) {
+	var reply string
+	err := rc.do(ctx, func() (bool, error) {
+		var err error
+		reply, err = rc.Client.GetJSONCompletion(ctx, messages, schema)
+		return true, err
+	})
+	return reply, err
+}
+
+// StreamChatCompletion retries only while nothing has been sent to onDelta
+func (rc *ResilientClient) StreamChatCompletion(ctx context.Context, messages []models.AIMessage, onDelta func(delta string) error) (string, error) {
+	var reply string
+	err := rc.do(ctx, func() (bool, error) {
+		started := false
+		var err error
+		reply, err = rc.Client.StreamChatCompletion(ctx, messages, func(delta string) error {
+			started = true
+			return onDelta(delta)
+		})
+		return !started, err
+	})
+	return reply, err
+}
+
+// do runs call until it succeeds, fails permanently or runs out of retries.
+// call reports whether it is still safe to repeat.
+func (rc *ResilientClient) do(ctx context.Context, call func() (repeatable bool, err error)) error {
+	for attempt := 0; ; attempt++ {
+		if !rc.Breaker.Allow() {
+			return &domain.AIError{Provider: rc.Name, Kind: domain.ErrAICircuitOpen}
+		}
+
+		repeatable, err := call()
+		if err == nil {
+			rc.Breaker.Success()
+			return nil
+		}
+		if ctx.Err() != nil {
+			return err
+		}
+
+		var aiErr *domain.AIError
+		if !errors.As(err, &aiErr) || !aiErr.Retryable() {
+			// the provider answered, it just didn't like this request
+			rc.Breaker.Success()
+			return err
+		}
+		rc.Breaker.Failure()
+
+		if !repeatable || attempt >= rc.Policy.MaxRetries {
+			return err
+		}
+		if aiErr.RetryAfter > rc.Policy.MaxDelay {
+			return err // the provider wants a longer pause than callers should sit through; fail over
+		}
+		wait := rc.Policy.delay(attempt, aiErr.RetryAfter)
+		if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) < wait {
+			return err // let the caller fail over instead of sleeping past its deadline
+		}
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return err
+		case <-timer.C:
+		}
+	}
+}
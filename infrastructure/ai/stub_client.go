@@ -0,0 +1,95 @@
+package ai
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+
+	svc "github.com/tsigemariamzewdu/JobMate-backend/domain/interfaces/services"
+	"github.com/tsigemariamzewdu/JobMate-backend/domain/models"
+)
+
+// StubClient answers without any network call. Replies depend only on the input,
+// which makes it useful for local development and as a last-resort provider.
+type StubClient struct{}
+
+var _ svc.IAIClient = StubClient{}
+
+func NewStubClient() StubClient {
+	return StubClient{}
+}
+
+func (StubClient) GetChatCompletion(ctx context.Context, messages []models.AIMessage) (string, error) {
+	if err := ctx.Err(); err != nil {
+		return "", err
+	}
+	return stubReply(messages), nil
+}
+
+func (s StubClient) StreamChatCompletion(ctx context.Context, messages []models.AIMessage, onDelta func(delta string) error) (string, error) {
+	var assembled strings.Builder
+	for _, word := range strings.SplitAfter(stubReply(messages), " ") {
+		if err := ctx.Err(); err != nil {
+			return assembled.String(), err
+		}
+		assembled.WriteString(word)
+		if err := onDelta(word); err != nil {
+			return assembled.String(), err
+		}
+	}
+	return assembled.String(), nil
+}
+
+// GetJSONCompletion returns the smallest value the schema accepts: required keys only, empty
+// arrays and the lowest allowed scalars
+func (StubClient) GetJSONCompletion(ctx context.Context, messages []models.AIMessage, schema *models.JSONSchema) (string, error) {
+	if err := ctx.Err(); err != nil {
+		return "", err
+	}
+	if schema == nil {
+		return "{}", nil
+	}
+	reply, err := json.Marshal(stubValue(schema))
+	if err != nil {
+		return "", err
+	}
+	return string(reply), nil
+}
+
+func stubValue(schema *models.JSONSchema) any {
+	switch schema.Type {
+	case "object":
+		obj := make(map[string]any, len(schema.Required))
+		for _, key := range schema.Required {
+			if prop := schema.Properties[key]; prop != nil {
+				obj[key] = stubValue(prop)
+			} else {
+				obj[key] = ""
+			}
+		}
+		return obj
+	case "array":
+		return []any{}
+	case "integer", "number":
+		if schema.Minimum != nil {
+			return *schema.Minimum
+		}
+		return 0
+	case "boolean":
+		return false
+	default:
+		if len(schema.Enum) > 0 {
+			return schema.Enum[0]
+		}
+		return ""
+	}
+}
+
+func stubReply(messages []models.AIMessage) string {
+	for i := len(messages) - 1; i >= 0; i-- {
+		if messages[i].Role == "user" {
+			return "AI is not configured. You said: " + strings.TrimSpace(messages[i].Content)
+		}
+	}
+	return "AI is not configured."
+}
@@ -2,14 +2,14 @@ package ai_service
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
+	"log"
 	"strings"
 
+	"github.com/tsigemariamzewdu/JobMate-backend/domain"
 	svc "github.com/tsigemariamzewdu/JobMate-backend/domain/interfaces/services"
 
 	model "github.com/tsigemariamzewdu/JobMate-backend/domain/models"
-	"google.golang.org/genai"
 )
 
 type aiResponse struct {
@@ -17,45 +17,52 @@ type aiResponse struct {
 		ExtractedSkills     []string `json:"extracted_skills"`
 		ExtractedExperience []string `json:"extracted_experience"`
 		ExtractedEducation  []string `json:"extracted_education"`
-		Summary             string   `json:"summary"`
+		ExperienceEntries   []struct {
+			Title        string `json:"title"`
+			Organization string `json:"organization"`
+			StartDate    string `json:"start_date"`
+			EndDate      string `json:"end_date"`
+			Description  string `json:"description"`
+		} `json:"experience_entries"`
+		EducationEntries []struct {
+			Institution    string `json:"institution"`
+			Degree         string `json:"degree"`
+			Level          string `json:"level"`
+			FieldOfStudy   string `json:"field_of_study"`
+			GraduationYear int    `json:"graduation_year"`
+		} `json:"education_entries"`
+		Summary string `json:"summary"`
 	} `json:"cvs"`
 	CVFeedback struct {
 		Strengths              string `json:"strengths"`
 		Weaknesses             string `json:"weaknesses"`
 		ImprovementSuggestions string `json:"improvement_suggestions"`
 	} `json:"cv_feedback"`
-	SkillGaps []struct {
-		SkillName              string `json:"skill_name"`
-		CurrentLevel           int    `json:"current_level"`
-		RecommendedLevel       int    `json:"recommended_level"`
-		Importance             string `json:"importance"`
-		ImprovementSuggestions string `json:"improvement_suggestions"`
-	} `json:"skill_gaps"`
+	SkillGaps []aiSkillGap `json:"skill_gaps"`
 }
 
-type GeminiAISuggestionService struct {
-	model  string
-	apiKey string
+type aiSkillGap struct {
+	SkillName              string `json:"skill_name"`
+	CurrentLevel           int    `json:"current_level"`
+	RecommendedLevel       int    `json:"recommended_level"`
+	Importance             string `json:"importance"`
+	ImprovementSuggestions string `json:"improvement_suggestions"`
 }
 
-func NewGeminiAISuggestionService(model, apiKey string) svc.AISuggestionService {
-	if model == "" {
-		model = "gemini-1.5-flash"
-	}
-	return &GeminiAISuggestionService{
-		model:  model,
-		apiKey: apiKey,
-	}
+// maxRepairAttempts is how many times an invalid reply is sent back for correction
+const maxRepairAttempts = 2
+
+// AISuggestionService analyzes CVs with whichever AI client it is given, so the provider
+// (and any failover between providers) is chosen by configuration
+type AISuggestionService struct {
+	client svc.IAIClient
 }
 
-func (s *GeminiAISuggestionService) Analyze(ctx context.Context, cvText string) (*model.AISuggestions, error) {
-	client, err := genai.NewClient(ctx, &genai.ClientConfig{
-		APIKey: s.apiKey,
-	})
-	if err != nil {
-		return nil, fmt.Errorf("failed to create Gemini client: %w", err)
-	}
+func NewAISuggestionService(client svc.IAIClient) svc.AISuggestionService {
+	return &AISuggestionService{client: client}
+}
 
+func (s *AISuggestionService) Analyze(ctx context.Context, cvText string) (*model.AISuggestions, error) {
 	prompt := fmt.Sprintf(`You are a career coach AI. Analyze the following CV text and return **only JSON**, strictly matching this structure. Use empty arrays or empty strings if there is no data:
 
 {
@@ -63,6 +70,24 @@ func (s *GeminiAISuggestionService) Analyze(ctx context.Context, cvText string)
     "extracted_skills": ["skill1", "skill2"],
     "extracted_experience": ["experience1"],
     "extracted_education": ["education1"],
+    "experience_entries": [
+      {
+        "title": "Job title",
+        "organization": "Employer",
+        "start_date": "2019-03",
+        "end_date": "present",
+        "description": "What the candidate did"
+      }
+    ],
+    "education_entries": [
+      {
+        "institution": "School or university",
+        "degree": "BSc",
+        "level": "bachelor",
+        "field_of_study": "Computer Science",
+        "graduation_year": 2019
+      }
+    ],
     "summary": "Concise professional summary"
   },
   "cv_feedback": {
@@ -81,31 +106,64 @@ func (s *GeminiAISuggestionService) Analyze(ctx context.Context, cvText string)
   ]
 }
 
+current_level and recommended_level are whole numbers from 1 (beginner) to 5 (expert).
+importance is exactly one of: critical, important, nice_to_have.
+Dates are YYYY-MM, or YYYY when the CV gives no month; end_date is "present" for a current role.
+Years in the Ethiopian calendar (marked E.C. or ዓ.ም) are converted to Gregorian years.
+level is exactly one of: high_school, diploma, bachelor, master, phd, other. graduation_year is 0 when not given.
+
 CV Text:
 %s
 `, cvText)
 
-	result, err := client.Models.GenerateContent(ctx, s.model, genai.Text(prompt), nil)
+	var aiResp aiResponse
+	err := s.completeJSON(ctx, "CV analysis", prompt, cvAnalysisSchema, func(reply string) []string {
+		var problems []string
+		aiResp, problems = parseAIResponse(reply)
+		return problems
+	})
 	if err != nil {
-		return nil, fmt.Errorf("AI generation failed: %w", err)
+		return nil, err
 	}
-	resp := strings.TrimSpace(result.Text())
-	resp = strings.TrimPrefix(resp, "```json")
-	resp = strings.TrimPrefix(resp, "```")
-	resp = strings.TrimSuffix(resp, "```")
-	resp = strings.TrimSpace(resp)
+	return toSuggestions(aiResp), nil
+}
 
-	var aiResp aiResponse
-	if err := json.Unmarshal([]byte(resp), &aiResp); err != nil {
-		return nil, fmt.Errorf("failed to parse AI response: %w\nAI output: %s", err, resp)
+// completeJSON sends prompt and hands the reply to parse, which decodes it and returns what is
+// wrong with it. Invalid replies are sent back for correction up to maxRepairAttempts times.
+func (s *AISuggestionService) completeJSON(ctx context.Context, task, prompt string, schema *model.JSONSchema, parse func(reply string) []string) error {
+	messages := []model.AIMessage{{Role: "user", Content: prompt}}
+	var problems []string
+	for attempt := 0; attempt <= maxRepairAttempts; attempt++ {
+		result, err := s.client.GetJSONCompletion(ctx, messages, schema)
+		if err != nil {
+			return fmt.Errorf("AI generation failed: %w", err)
+		}
+
+		if problems = parse(result); len(problems) == 0 {
+			return nil
+		}
+
+		// show the model its own reply and what is wrong with it, and ask again
+		log.Printf("%s reply failed validation (attempt %d): %s", task, attempt+1, strings.Join(problems, "; "))
+		messages = append(messages,
+			model.AIMessage{Role: "assistant", Content: result},
+			model.AIMessage{Role: "user", Content: "Your reply did not match the required format:\n- " + strings.Join(problems, "\n- ") +
+				"\nReply again with the corrected JSON only, keeping everything else the same."},
+		)
 	}
+	return fmt.Errorf("%w: %s", domain.ErrAIInvalidOutput, strings.Join(problems, "; "))
+}
 
+// toSuggestions maps a validated AI response to domain.AISuggestions
+func toSuggestions(aiResp aiResponse) *model.AISuggestions {
 	// Map AI response to domain.AISuggestions
 	suggestions := &model.AISuggestions{
 		CVs: struct {
 			ExtractedSkills     []string
 			ExtractedExperience []string
 			ExtractedEducation  []string
+			Experience          []model.ExperienceEntry
+			Education           []model.EducationEntry
 			Summary             string
 		}{
 			ExtractedSkills:     aiResp.CVs.ExtractedSkills,
@@ -124,16 +182,31 @@ CV Text:
 		},
 	}
 
-	type skillGapType = struct {
-		SkillName              string `json:"skill_name"`
-		CurrentLevel           int    `json:"current_level"`
-		RecommendedLevel       int    `json:"recommended_level"`
-		Importance             string `json:"importance"`
-		ImprovementSuggestions string `json:"improvement_suggestions"`
+	// dates and levels were checked by parseAIResponse
+	for _, e := range aiResp.CVs.ExperienceEntries {
+		start, _, _ := parseAIDate(e.StartDate)
+		end, current, _ := parseAIDate(e.EndDate)
+		suggestions.CVs.Experience = append(suggestions.CVs.Experience, model.ExperienceEntry{
+			Title:        e.Title,
+			Organization: e.Organization,
+			Start:        start,
+			End:          end,
+			Current:      current,
+			Description:  e.Description,
+		})
+	}
+	for _, e := range aiResp.CVs.EducationEntries {
+		suggestions.CVs.Education = append(suggestions.CVs.Education, model.EducationEntry{
+			Institution:    e.Institution,
+			Degree:         e.Degree,
+			Level:          model.EducationLevel(e.Level),
+			FieldOfStudy:   e.FieldOfStudy,
+			GraduationYear: e.GraduationYear,
+		})
 	}
 
 	if aiResp.SkillGaps == nil {
-		aiResp.SkillGaps = make([]skillGapType, 0)
+		aiResp.SkillGaps = make([]aiSkillGap, 0)
 	} else {
 		for _, g := range aiResp.SkillGaps {
 			suggestions.SkillGaps = append(suggestions.SkillGaps, struct {
@@ -152,5 +225,5 @@ CV Text:
 		}
 	}
 
-	return suggestions, nil
+	return suggestions
 }
@@ -0,0 +1,255 @@
+package ai_service
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	model "github.com/tsigemariamzewdu/JobMate-backend/domain/models"
+)
+
+const (
+	minSkillLevel = 1
+	maxSkillLevel = 5
+)
+
+var importanceValues = []string{
+	string(model.ImportanceCritical),
+	string(model.ImportanceImportant),
+	string(model.ImportanceNiceToHave),
+}
+
+var educationLevels = []string{
+	string(model.EducationHighSchool),
+	string(model.EducationDiploma),
+	string(model.EducationBachelor),
+	string(model.EducationMaster),
+	string(model.EducationPhD),
+	string(model.EducationOther),
+}
+
+func float(v float64) *float64 { return &v }
+
+func stringArray() *model.JSONSchema {
+	return &model.JSONSchema{Type: "array", Items: &model.JSONSchema{Type: "string"}}
+}
+
+// cvAnalysisSchema is the shape of aiResponse, given to providers that support structured output
+var cvAnalysisSchema = &model.JSONSchema{
+	Type:     "object",
+	Required: []string{"cvs", "cv_feedback", "skill_gaps"},
+	Properties: map[string]*model.JSONSchema{
+		"cvs": {
+			Type:     "object",
+			Required: []string{"extracted_skills", "extracted_experience", "extracted_education", "experience_entries", "education_entries", "summary"},
+			Properties: map[string]*model.JSONSchema{
+				"extracted_skills":     stringArray(),
+				"extracted_experience": stringArray(),
+				"extracted_education":  stringArray(),
+				"experience_entries": {
+					Type: "array",
+					Items: &model.JSONSchema{
+						Type:     "object",
+						Required: []string{"title", "organization", "start_date", "end_date", "description"},
+						Properties: map[string]*model.JSONSchema{
+							"title":        {Type: "string"},
+							"organization": {Type: "string"},
+							"start_date":   {Type: "string"},
+							"end_date":     {Type: "string"},
+							"description":  {Type: "string"},
+						},
+					},
+				},
+				"education_entries": {
+					Type: "array",
+					Items: &model.JSONSchema{
+						Type:     "object",
+						Required: []string{"institution", "degree", "level", "field_of_study", "graduation_year"},
+						Properties: map[string]*model.JSONSchema{
+							"institution":     {Type: "string"},
+							"degree":          {Type: "string"},
+							"level":           {Type: "string", Enum: educationLevels},
+							"field_of_study":  {Type: "string"},
+							"graduation_year": {Type: "integer"},
+						},
+					},
+				},
+				"summary": {Type: "string"},
+			},
+		},
+		"cv_feedback": {
+			Type:     "object",
+			Required: []string{"strengths", "weaknesses", "improvement_suggestions"},
+			Properties: map[string]*model.JSONSchema{
+				"strengths":               {Type: "string"},
+				"weaknesses":              {Type: "string"},
+				"improvement_suggestions": {Type: "string"},
+			},
+		},
+		"skill_gaps": skillGapsSchema(),
+	},
+}
+
+func skillGapsSchema() *model.JSONSchema {
+	return &model.JSONSchema{
+		Type: "array",
+		Items: &model.JSONSchema{
+			Type:     "object",
+			Required: []string{"skill_name", "current_level", "recommended_level", "importance", "improvement_suggestions"},
+			Properties: map[string]*model.JSONSchema{
+				"skill_name":              {Type: "string"},
+				"current_level":           {Type: "integer", Minimum: float(minSkillLevel), Maximum: float(maxSkillLevel)},
+				"recommended_level":       {Type: "integer", Minimum: float(minSkillLevel), Maximum: float(maxSkillLevel)},
+				"importance":              {Type: "string", Enum: importanceValues},
+				"improvement_suggestions": {Type: "string"},
+			},
+		},
+	}
+}
+
+// parseAIResponse decodes and validates a CV analysis reply. It returns every problem found so
+// they can all be sent back in one repair request.
+func parseAIResponse(raw string) (aiResponse, []string) {
+	var resp aiResponse
+
+	if err := json.Unmarshal([]byte(stripCodeFence(raw)), &resp); err != nil {
+		return resp, []string{"the reply is not valid JSON of the required structure: " + err.Error()}
+	}
+
+	// decoding fills absent keys with zero values, so an empty object would pass as an empty analysis
+	problems := missingRequired(json.RawMessage(stripCodeFence(raw)), cvAnalysisSchema, "")
+	for i := range resp.CVs.ExperienceEntries {
+		e := &resp.CVs.ExperienceEntries[i]
+		where := fmt.Sprintf("cvs.experience_entries[%d]", i)
+		if _, current, ok := parseAIDate(e.StartDate); !ok || current {
+			problems = append(problems, fmt.Sprintf("%s.start_date must be YYYY-MM or YYYY, got %q", where, e.StartDate))
+		}
+		if _, _, ok := parseAIDate(e.EndDate); !ok {
+			problems = append(problems, fmt.Sprintf("%s.end_date must be YYYY-MM, YYYY or \"present\", got %q", where, e.EndDate))
+		}
+	}
+	for i := range resp.CVs.EducationEntries {
+		e := &resp.CVs.EducationEntries[i]
+		where := fmt.Sprintf("cvs.education_entries[%d]", i)
+		e.Level = strings.ReplaceAll(strings.ToLower(strings.TrimSpace(e.Level)), " ", "_")
+		if !isOneOf(e.Level, educationLevels) {
+			problems = append(problems, fmt.Sprintf("%s.level must be one of %s, got %q", where, strings.Join(educationLevels, ", "), e.Level))
+		}
+		if e.GraduationYear != 0 && (e.GraduationYear < minYear || e.GraduationYear > time.Now().Year()+10) {
+			problems = append(problems, fmt.Sprintf("%s.graduation_year must be a Gregorian year or 0, got %d", where, e.GraduationYear))
+		}
+	}
+	problems = append(problems, validateSkillGaps(resp.SkillGaps)...)
+	return resp, problems
+}
+
+// missingRequired lists the keys schema requires that raw leaves out or sets to null, in nested
+// objects and array items too. Values of the wrong type are left to decoding to report.
+func missingRequired(raw json.RawMessage, schema *model.JSONSchema, path string) []string {
+	var problems []string
+	switch schema.Type {
+	case "object":
+		var fields map[string]json.RawMessage
+		if json.Unmarshal(raw, &fields) != nil {
+			return nil
+		}
+		for _, key := range schema.Required {
+			where := key
+			if path != "" {
+				where = path + "." + key
+			}
+			value, ok := fields[key]
+			if !ok || string(value) == "null" {
+				problems = append(problems, where+" is required but missing")
+				continue
+			}
+			if sub := schema.Properties[key]; sub != nil {
+				problems = append(problems, missingRequired(value, sub, where)...)
+			}
+		}
+	case "array":
+		var items []json.RawMessage
+		if schema.Items == nil || json.Unmarshal(raw, &items) != nil {
+			return nil
+		}
+		for i, item := range items {
+			problems = append(problems, missingRequired(item, schema.Items, fmt.Sprintf("%s[%d]", path, i))...)
+		}
+	}
+	return problems
+}
+
+// stripCodeFence removes the code fences models without JSON mode still wrap replies in now and then
+func stripCodeFence(raw string) string {
+	text := strings.TrimSpace(raw)
+	text = strings.TrimPrefix(text, "```json")
+	text = strings.TrimPrefix(text, "```")
+	text = strings.TrimSuffix(text, "```")
+	return strings.TrimSpace(text)
+}
+
+// validateSkillGaps checks skill gaps in place, tidying harmless variations first
+func validateSkillGaps(gaps []aiSkillGap) []string {
+	var problems []string
+	for i := range gaps {
+		g := &gaps[i]
+		where := fmt.Sprintf("skill_gaps[%d]", i)
+		g.SkillName = strings.TrimSpace(g.SkillName)
+		if g.SkillName == "" {
+			problems = append(problems, where+".skill_name must not be empty")
+		}
+		if g.CurrentLevel < minSkillLevel || g.CurrentLevel > maxSkillLevel {
+			problems = append(problems, fmt.Sprintf("%s.current_level must be between %d and %d, got %d", where, minSkillLevel, maxSkillLevel, g.CurrentLevel))
+		}
+		if g.RecommendedLevel < minSkillLevel || g.RecommendedLevel > maxSkillLevel {
+			problems = append(problems, fmt.Sprintf("%s.recommended_level must be between %d and %d, got %d", where, minSkillLevel, maxSkillLevel, g.RecommendedLevel))
+		}
+
+		// accept harmless variations such as "Nice to have" before judging the value
+		g.Importance = strings.ReplaceAll(strings.ToLower(strings.TrimSpace(g.Importance)), " ", "_")
+		g.Importance = strings.ReplaceAll(g.Importance, "-", "_")
+		if !isOneOf(g.Importance, importanceValues) {
+			problems = append(problems, fmt.Sprintf("%s.importance must be one of %s, got %q", where, strings.Join(importanceValues, ", "), g.Importance))
+		}
+	}
+	return problems
+}
+
+func isOneOf(value string, allowed []string) bool {
+	for _, v := range allowed {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// minYear is the earliest year accepted in CV dates
+const minYear = 1950
+
+// parseAIDate reads "YYYY-MM", "YYYY" or "present". An empty end date is taken as unknown.
+func parseAIDate(value string) (date model.YearMonth, current, ok bool) {
+	value = strings.ToLower(strings.TrimSpace(value))
+	switch value {
+	case "":
+		return date, false, true
+	case "present", "current", "now":
+		return date, true, true
+	}
+	year, month, hasMonth := strings.Cut(value, "-")
+	y, err := strconv.Atoi(year)
+	if err != nil || y < minYear || y > time.Now().Year()+1 {
+		return date, false, false
+	}
+	date.Year = y
+	if hasMonth {
+		m, err := strconv.Atoi(month)
+		if err != nil || m < 1 || m > 12 {
+			return date, false, false
+		}
+		date.Month = m
+	}
+	return date, false, true
+}
@@ -0,0 +1,18 @@
+package ai_service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/tsigemariamzewdu/JobMate-backend/infrastructure/ai"
+)
+
+func TestParseAIResponseAcceptsStubReply(t *testing.T) {
+	raw, err := ai.NewStubClient().GetJSONCompletion(context.Background(), nil, cvAnalysisSchema)
+	if err != nil {
+		t.Fatalf("GetJSONCompletion: %v", err)
+	}
+	if _, problems := parseAIResponse(raw); len(problems) > 0 {
+		t.Errorf("stub reply %s has problems: %q", raw, problems)
+	}
+}
@@ -0,0 +1,164 @@
+package ai_service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	model "github.com/tsigemariamzewdu/JobMate-backend/domain/models"
+)
+
+// maxTailorBullets keeps the reply focused on the lines that matter most for the job
+const maxTailorBullets = 8
+
+type aiTailoringResponse struct {
+	MissingRequirements []string `json:"missing_requirements"`
+	BulletSuggestions   []struct {
+		Original  string `json:"original"`
+		Suggested string `json:"suggested"`
+		Reason    string `json:"reason"`
+	} `json:"bullet_suggestions"`
+	TailoredSummary string       `json:"tailored_summary"`
+	SkillGaps       []aiSkillGap `json:"skill_gaps"`
+}
+
+// cvTailoringSchema is the shape of aiTailoringResponse
+var cvTailoringSchema = &model.JSONSchema{
+	Type:     "object",
+	Required: []string{"missing_requirements", "bullet_suggestions", "tailored_summary", "skill_gaps"},
+	Properties: map[string]*model.JSONSchema{
+		"missing_requirements": stringArray(),
+		"bullet_suggestions": {
+			Type: "array",
+			Items: &model.JSONSchema{
+				Type:     "object",
+				Required: []string{"original", "suggested", "reason"},
+				Properties: map[string]*model.JSONSchema{
+					"original":  {Type: "string"},
+					"suggested": {Type: "string"},
+					"reason":    {Type: "string"},
+				},
+			},
+		},
+		"tailored_summary": {Type: "string"},
+		"skill_gaps":       skillGapsSchema(),
+	},
+}
+
+func (s *AISuggestionService) Tailor(ctx context.Context, cvText string, job *model.Job, missingKeywords []string) (*model.AITailoring, error) {
+	var posting strings.Builder
+	if job.Title != "" {
+		fmt.Fprintf(&posting, "Title: %s\n", job.Title)
+	}
+	if job.Company != "" {
+		fmt.Fprintf(&posting, "Company: %s\n", job.Company)
+	}
+	if len(job.Requirements) > 0 {
+		fmt.Fprintf(&posting, "Requirements: %s\n", strings.Join(job.Requirements, ", "))
+	}
+	posting.WriteString(job.Description)
+
+	missing := "none"
+	if len(missingKeywords) > 0 {
+		missing = strings.Join(missingKeywords, ", ")
+	}
+
+	prompt := fmt.Sprintf(`You are a career coach AI helping a candidate tailor their CV to one job. Compare the CV with the job posting and return **only JSON**, strictly matching this structure. Use empty arrays or empty strings if there is no data:
+
+{
+  "missing_requirements": ["A requirement of the job the CV does not show"],
+  "bullet_suggestions": [
+    {
+      "original": "A line copied exactly from the CV",
+      "suggested": "The line rewritten for this job",
+      "reason": "Why the rewrite fits the job better"
+    }
+  ],
+  "tailored_summary": "A professional summary written for this job",
+  "skill_gaps": [
+    {
+      "skill_name": "Name",
+      "current_level": 1,
+      "recommended_level": 5,
+      "importance": "critical",
+      "improvement_suggestions": "How to improve"
+    }
+  ]
+}
+
+missing_requirements covers qualifications, experience, certifications and languages as well as skills.
+Give at most %d bullet_suggestions, for the lines that matter most to this job. original is copied word for word from the CV.
+Never invent experience, employers, numbers or qualifications the CV does not state; rephrase and reorder what is there.
+skill_gaps are judged against this job: importance is exactly one of critical, important, nice_to_have for this job.
+current_level and recommended_level are whole numbers from 1 (beginner) to 5 (expert).
+Write suggested lines and the summary in the language the CV is written in.
+Keywords of the job the CV does not mention: %s
+
+Job posting:
+%s
+
+CV Text:
+%s
+`, maxTailorBullets, missing, posting.String(), cvText)
+
+	var resp aiTailoringResponse
+	err := s.completeJSON(ctx, "CV tailoring", prompt, cvTailoringSchema, func(reply string) []string {
+		var problems []string
+		resp, problems = parseTailoringResponse(reply)
+		return problems
+	})
+	if err != nil {
+		return nil, err
+	}
+	return toTailoring(resp), nil
+}
+
+// parseTailoringResponse decodes and validates a CV tailoring reply
+func parseTailoringResponse(raw string) (aiTailoringResponse, []string) {
+	var resp aiTailoringResponse
+	if err := json.Unmarshal([]byte(stripCodeFence(raw)), &resp); err != nil {
+		return resp, []string{"the reply is not valid JSON of the required structure: " + err.Error()}
+	}
+
+	var problems []string
+	for i := range resp.BulletSuggestions {
+		b := &resp.BulletSuggestions[i]
+		b.Original, b.Suggested = strings.TrimSpace(b.Original), strings.TrimSpace(b.Suggested)
+		if b.Original == "" || b.Suggested == "" {
+			problems = append(problems, fmt.Sprintf("bullet_suggestions[%d] needs both original and suggested", i))
+		}
+	}
+	if len(resp.BulletSuggestions) > maxTailorBullets {
+		resp.BulletSuggestions = resp.BulletSuggestions[:maxTailorBullets]
+	}
+	problems = append(problems, validateSkillGaps(resp.SkillGaps)...)
+	return resp, problems
+}
+
+// toTailoring maps a validated reply to the domain. The caller fills in whose skill gaps they are.
+func toTailoring(resp aiTailoringResponse) *model.AITailoring {
+	tailoring := &model.AITailoring{TailoredSummary: strings.TrimSpace(resp.TailoredSummary)}
+	for _, r := range resp.MissingRequirements {
+		if r = strings.TrimSpace(r); r != "" {
+			tailoring.MissingRequirements = append(tailoring.MissingRequirements, r)
+		}
+	}
+	for _, b := range resp.BulletSuggestions {
+		tailoring.BulletSuggestions = append(tailoring.BulletSuggestions, model.BulletSuggestion{
+			Original:  b.Original,
+			Suggested: b.Suggested,
+			Reason:    strings.TrimSpace(b.Reason),
+		})
+	}
+	for _, g := range resp.SkillGaps {
+		tailoring.SkillGaps = append(tailoring.SkillGaps, model.SkillGap{
+			SkillName:              g.SkillName,
+			CurrentLevel:           g.CurrentLevel,
+			RecommendedLevel:       g.RecommendedLevel,
+			Importance:             model.Importance(g.Importance),
+			ImprovementSuggestions: g.ImprovementSuggestions,
+		})
+	}
+	return tailoring
+}
@@ -52,3 +52,21 @@ func (a *AuthMiddleware) Middleware() gin.HandlerFunc {
 		c.Next()
 	}
 }
+
+// AdminOnly lets through only the configured admin users. It must run after Middleware.
+func (a *AuthMiddleware) AdminOnly(adminIDs []string) gin.HandlerFunc {
+	admins := make(map[string]bool, len(adminIDs))
+	for _, id := range adminIDs {
+		admins[id] = true
+	}
+	return func(c *gin.Context) {
+		if !admins[c.GetString("userID")] {
+			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{
+				"success": false,
+				"message": "Admin access required",
+			})
+			return
+		}
+		c.Next()
+	}
+}
@@ -42,10 +42,14 @@ func NewTwilioOTPSender(cfg *config.Config) (*TwilioOTPSender, error) {
 
 // SendOTP sends the OTP message to the given phone (E.164 format, e.g. +2519xxxxxxx)
 func (s *TwilioOTPSender) SendOTP(phone string, code string) error {
+	return s.SendSMS(phone, fmt.Sprintf("JobMate: your verification code is %s", code))
+}
+
+// SendSMS sends an arbitrary message to the given phone (E.164 format)
+func (s *TwilioOTPSender) SendSMS(phone string, message string) error {
 	if phone == "" {
 		return fmt.Errorf("phone is empty")
 	}
-	message := fmt.Sprintf("JobMate: your verification code is %s", code)
 
 	form := url.Values{}
 	form.Set("From", s.From)
@@ -87,6 +91,11 @@ func (s *StubOTPSender) SendOTP(phone string, code string) error {
 	return nil
 }
 
+func (s *StubOTPSender) SendSMS(phone string, message string) error {
+	fmt.Printf("[DEV SMS] to %s: %s\n", phone, message)
+	return nil
+}
+
 // NewOTPSenderFromEnv picks Twilio in production (with valid creds) else returns the dev stub.
 // This avoids accidentally sending real SMS from development.
 func NewOTPSenderFromEnv(cfg *config.Config) (svc.IOTPSender, error) {
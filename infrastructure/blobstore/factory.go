@@ -0,0 +1,31 @@
+package blobstore
+
+import (
+	"fmt"
+	"strings"
+
+	"go.mongodb.org/mongo-driver/mongo"
+
+	svc "github.com/tsigemariamzewdu/JobMate-backend/domain/interfaces/services"
+)
+
+const (
+	BackendGridFS = "gridfs"
+	BackendLocal  = "local"
+)
+
+// New returns the configured store. GridFS is the default since it needs nothing beyond the
+// database and works across instances; local storage keeps files under localDir.
+func New(backend, localDir string, db *mongo.Database) (svc.BlobStore, error) {
+	switch strings.ToLower(strings.TrimSpace(backend)) {
+	case "", BackendGridFS:
+		return NewGridFSStore(db, "files"), nil
+	case BackendLocal:
+		if localDir == "" {
+			localDir = "data/blobs"
+		}
+		return NewLocalStore(localDir)
+	default:
+		return nil, fmt.Errorf("unknown blob store %q", backend)
+	}
+}
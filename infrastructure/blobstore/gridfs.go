@@ -0,0 +1,165 @@
+package blobstore
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/gridfs"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/tsigemariamzewdu/JobMate-backend/domain"
+	svc "github.com/tsigemariamzewdu/JobMate-backend/domain/interfaces/services"
+	"github.com/tsigemariamzewdu/JobMate-backend/domain/models"
+)
+
+// GridFSStore keeps blobs in a MongoDB GridFS bucket, with the key as the file name.
+// Replacing a key uploads a new revision and removes the older ones.
+type GridFSStore struct {
+	db     *mongo.Database
+	bucket string
+}
+
+var _ svc.BlobStore = (*GridFSStore)(nil)
+
+func NewGridFSStore(db *mongo.Database, bucket string) *GridFSStore {
+	return &GridFSStore{db: db, bucket: bucket}
+}
+
+type gridfsMeta struct {
+	ContentType string `bson:"content_type"`
+	Checksum    string `bson:"checksum,omitempty"`
+}
+
+// open returns a bucket bound to ctx's deadline. Buckets carry their deadlines, so each
+// call gets its own rather than sharing one across requests.
+func (s *GridFSStore) open(ctx context.Context) (*gridfs.Bucket, error) {
+	b, err := gridfs.NewBucket(s.db, options.GridFSBucket().SetName(s.bucket))
+	if err != nil {
+		return nil, fmt.Errorf("failed to open GridFS bucket: %w", err)
+	}
+	if deadline, ok := ctx.Deadline(); ok {
+		if err := b.SetWriteDeadline(deadline); err != nil {
+			return nil, err
+		}
+		if err := b.SetReadDeadline(deadline); err != nil {
+			return nil, err
+		}
+	}
+	return b, nil
+}
+
+func (s *GridFSStore) Put(ctx context.Context, key string, content io.Reader, contentType string) (*models.BlobInfo, error) {
+	if key == "" {
+		return nil, domain.ErrInvalidBlobKey
+	}
+	b, err := s.open(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	hash := sha256.New()
+	opts := options.GridFSUpload().SetMetadata(gridfsMeta{ContentType: contentType})
+	id, err := b.UploadFromStream(key, io.TeeReader(readerWithContext(ctx, content), hash), opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to upload blob: %w", err)
+	}
+
+	// the checksum is only known once the content has been read
+	checksum := hex.EncodeToString(hash.Sum(nil))
+	if _, err := b.GetFilesCollection().UpdateByID(ctx, id, bson.M{"$set": bson.M{"metadata.checksum": checksum}}); err != nil {
+		return nil, fmt.Errorf("failed to store blob checksum: %w", err)
+	}
+
+	file, err := s.latest(ctx, b, key)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.deleteOlder(ctx, b, key, id); err != nil {
+		return nil, err
+	}
+	return file, nil
+}
+
+func (s *GridFSStore) Get(ctx context.Context, key string) (io.ReadCloser, *models.BlobInfo, error) {
+	b, err := s.open(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	info, err := s.latest(ctx, b, key)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	stream, err := b.OpenDownloadStreamByName(key)
+	if errors.Is(err, gridfs.ErrFileNotFound) {
+		return nil, nil, domain.ErrBlobNotFound
+	}
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to open blob: %w", err)
+	}
+	return stream, info, nil
+}
+
+func (s *GridFSStore) Delete(ctx context.Context, key string) error {
+	b, err := s.open(ctx)
+	if err != nil {
+		return err
+	}
+	return s.deleteOlder(ctx, b, key, nil)
+}
+
+// latest describes the newest revision stored under key
+func (s *GridFSStore) latest(ctx context.Context, b *gridfs.Bucket, key string) (*models.BlobInfo, error) {
+	var file struct {
+		Length     int64      `bson:"length"`
+		UploadDate time.Time  `bson:"uploadDate"`
+		Metadata   gridfsMeta `bson:"metadata"`
+	}
+	opts := options.FindOne().SetSort(bson.D{{Key: "uploadDate", Value: -1}})
+	err := b.GetFilesCollection().FindOne(ctx, bson.M{"filename": key}, opts).Decode(&file)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, domain.ErrBlobNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find blob: %w", err)
+	}
+	return &models.BlobInfo{
+		Key:         key,
+		ContentType: file.Metadata.ContentType,
+		Size:        file.Length,
+		Checksum:    file.Metadata.Checksum,
+		CreatedAt:   file.UploadDate,
+	}, nil
+}
+
+// deleteOlder removes every revision of key except keep; a nil keep removes them all
+func (s *GridFSStore) deleteOlder(ctx context.Context, b *gridfs.Bucket, key string, keep interface{}) error {
+	filter := bson.M{"filename": key}
+	if keep != nil {
+		filter["_id"] = bson.M{"$ne": keep}
+	}
+	cursor, err := b.FindContext(ctx, filter)
+	if err != nil {
+		return fmt.Errorf("failed to find blob revisions: %w", err)
+	}
+	var files []struct {
+		ID primitive.ObjectID `bson:"_id"`
+	}
+	if err := cursor.All(ctx, &files); err != nil {
+		return fmt.Errorf("failed to read blob revisions: %w", err)
+	}
+	for _, f := range files {
+		if err := b.DeleteContext(ctx, f.ID); err != nil && !errors.Is(err, gridfs.ErrFileNotFound) {
+			return fmt.Errorf("failed to delete blob revision: %w", err)
+		}
+	}
+	return nil
+}
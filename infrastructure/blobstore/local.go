@@ -0,0 +1,164 @@
+package blobstore
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/tsigemariamzewdu/JobMate-backend/domain"
+	svc "github.com/tsigemariamzewdu/JobMate-backend/domain/interfaces/services"
+	"github.com/tsigemariamzewdu/JobMate-backend/domain/models"
+)
+
+// metaSuffix names the JSON file kept next to each blob with its content type and checksum
+const metaSuffix = ".meta.json"
+
+// LocalStore keeps blobs as files under Root. It suits a single instance or a shared volume.
+type LocalStore struct {
+	Root string
+}
+
+var _ svc.BlobStore = (*LocalStore)(nil)
+
+func NewLocalStore(root string) (*LocalStore, error) {
+	if err := os.MkdirAll(root, 0o750); err != nil {
+		return nil, fmt.Errorf("failed to create blob directory %s: %w", root, err)
+	}
+	return &LocalStore{Root: root}, nil
+}
+
+type localMeta struct {
+	ContentType string    `json:"content_type"`
+	Size        int64     `json:"size"`
+	Checksum    string    `json:"checksum"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+func (s *LocalStore) Put(ctx context.Context, key string, content io.Reader, contentType string) (*models.BlobInfo, error) {
+	path, err := s.path(key)
+	if err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+		return nil, fmt.Errorf("failed to create blob directory: %w", err)
+	}
+
+	// write to a temporary file and rename, so readers never see a partial blob
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".upload-*")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create blob file: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	hash := sha256.New()
+	size, err := io.Copy(io.MultiWriter(tmp, hash), readerWithContext(ctx, content))
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to write blob: %w", err)
+	}
+
+	info := &models.BlobInfo{
+		Key:         key,
+		ContentType: contentType,
+		Size:        size,
+		Checksum:    hex.EncodeToString(hash.Sum(nil)),
+		CreatedAt:   time.Now(),
+	}
+	meta, err := json.Marshal(localMeta{ContentType: info.ContentType, Size: info.Size, Checksum: info.Checksum, CreatedAt: info.CreatedAt})
+	if err != nil {
+		return nil, err
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return nil, fmt.Errorf("failed to store blob: %w", err)
+	}
+	// the metadata file is written last, so a blob is only found once it is complete
+	if err := os.WriteFile(path+metaSuffix, meta, 0o640); err != nil {
+		os.Remove(path)
+		return nil, fmt.Errorf("failed to write blob metadata: %w", err)
+	}
+	return info, nil
+}
+
+func (s *LocalStore) Get(ctx context.Context, key string) (io.ReadCloser, *models.BlobInfo, error) {
+	path, err := s.path(key)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	raw, err := os.ReadFile(path + metaSuffix)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil, domain.ErrBlobNotFound
+	}
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read blob metadata: %w", err)
+	}
+	var meta localMeta
+	if err := json.Unmarshal(raw, &meta); err != nil {
+		return nil, nil, fmt.Errorf("failed to decode blob metadata: %w", err)
+	}
+
+	f, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil, domain.ErrBlobNotFound
+	}
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to open blob: %w", err)
+	}
+	return f, &models.BlobInfo{
+		Key:         key,
+		ContentType: meta.ContentType,
+		Size:        meta.Size,
+		Checksum:    meta.Checksum,
+		CreatedAt:   meta.CreatedAt,
+	}, nil
+}
+
+func (s *LocalStore) Delete(ctx context.Context, key string) error {
+	path, err := s.path(key)
+	if err != nil {
+		return err
+	}
+	for _, p := range []string{path, path + metaSuffix} {
+		if err := os.Remove(p); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("failed to delete blob: %w", err)
+		}
+	}
+	return nil
+}
+
+// path maps a key to a file under Root, rejecting keys that would escape it
+func (s *LocalStore) path(key string) (string, error) {
+	clean := filepath.Clean(filepath.FromSlash(key))
+	if key == "" || filepath.IsAbs(clean) || clean == "." || clean == ".." ||
+		strings.HasPrefix(clean, ".."+string(filepath.Separator)) || strings.HasSuffix(clean, metaSuffix) {
+		return "", domain.ErrInvalidBlobKey
+	}
+	return filepath.Join(s.Root, clean), nil
+}
+
+// readerWithContext stops a copy once ctx is done
+func readerWithContext(ctx context.Context, r io.Reader) io.Reader {
+	return ctxReader{ctx: ctx, r: r}
+}
+
+type ctxReader struct {
+	ctx context.Context
+	r   io.Reader
+}
+
+func (c ctxReader) Read(p []byte) (int, error) {
+	if err := c.ctx.Err(); err != nil {
+		return 0, err
+	}
+	return c.r.Read(p)
+}
@@ -25,6 +25,7 @@ type Config struct {
 	RefreshTokenSecret        string
 	AccessTokenSecret         string
 	RefreshTokenExpirationMin int
+	AdminUserIDs              []string // users allowed on /admin routes
 
 	SMTPHost      string
 	SMTPPort      int
@@ -38,12 +39,23 @@ type Config struct {
 	AIApiKey       string 
 	AIModelName    string 
 	AIApiBaseUrl   string 
-	AIProvider     string 
+	AIProvider     string // ordered, comma-separated providers for chat, e.g. "groq,gemini"
+	CVAIProvider   string // providers for CV analysis; defaults to gemini, not AIProvider
 	AITemperature float32 
+	AIMaxRetries             int // retries per provider on 429/5xx; negative disables
+	AIRetryBaseDelayMs       int
+	AIBreakerThreshold       int // consecutive failures before a provider is skipped
+	AIBreakerCooldownSeconds int
+	AIDailyTokenQuota        int // tokens per user per UTC day; 0 means unlimited
 	
 	// Separate config for OpenAI if needed later for CV specific
 	OpenAIApiKey string 
 	OpenAIModelName string 
+	OpenAIApiBaseUrl string
+
+	// Gemini, when used as a provider
+	GeminiApiKey    string
+	GeminiModelName string
 
 
 	DefaultPageSize int
@@ -74,6 +86,32 @@ type Config struct {
 
 	// JobData
 	JobDataApiKey    string
+
+	// Job sources
+	JobSources              []string // enabled source names; empty enables all
+	JobSourceTimeoutSeconds int
+
+	// Job catalog ingestion
+	JobIngestionIntervalMinutes int
+	JobIngestionFields          []string
+	JobTTLHours                 int
+	JobRequirementsUseAI        bool // let the AI fill requirements the rules miss
+
+	// Job alerts
+	JobAlertCheckIntervalMinutes int
+
+	// CV analysis workers
+	CVAnalysisWorkers        int // analyses run at the same time; each is a paid AI call
+	CVAnalysisTimeoutSeconds int
+
+	// File storage for uploaded originals
+	BlobStore    string // "gridfs" (default) or "local"
+	BlobLocalDir string
+
+	// Text recognition for scanned and photographed CVs
+	OCRDisabled       bool
+	OCRLanguages      string // tesseract languages, default "amh+eng"
+	OCRTimeoutSeconds int
 }
 
 // LoadConfig loads config.env from project root (if present) and also supports environment variables.
@@ -108,6 +146,7 @@ func LoadConfig() (*Config, error) {
 		JWTExpirationMinutes:      viper.GetInt("JWT_EXPIRATION_MINUTES"),
 		RefreshTokenSecret:        viper.GetString("REFRESH_TOKEN_SECRET"),
 		RefreshTokenExpirationMin: viper.GetInt("REFRESH_TOKEN_EXPIRATION_MINUTES"),
+		AdminUserIDs:              splitAndTrim(viper.GetString("ADMIN_USER_IDS")),
 
 		SMTPHost:      viper.GetString("SMTP_HOST"),
 		SMTPPort:      viper.GetInt("SMTP_PORT"),
@@ -122,11 +161,22 @@ func LoadConfig() (*Config, error) {
 		AIModelName:  viper.GetString("AI_MODEL_NAME"),  
 		AIApiBaseUrl: viper.GetString("AI_API_BASE_URL"), 
 		AIProvider:   viper.GetString("AI_PROVIDER"),    
+		CVAIProvider: viper.GetString("CV_AI_PROVIDER"),
 		AITemperature:         float32(viper.GetFloat64("AI_TEMPERATURE")), 
+		AIMaxRetries:             viper.GetInt("AI_MAX_RETRIES"),
+		AIRetryBaseDelayMs:       viper.GetInt("AI_RETRY_BASE_DELAY_MS"),
+		AIBreakerThreshold:       viper.GetInt("AI_BREAKER_THRESHOLD"),
+		AIBreakerCooldownSeconds: viper.GetInt("AI_BREAKER_COOLDOWN_SECONDS"),
+		AIDailyTokenQuota:        viper.GetInt("AI_DAILY_TOKEN_QUOTA"),
 		
 		// OpenAI Specific (for CV analysis, if separate)
 		OpenAIApiKey: viper.GetString("OPENAI_API_KEY"),
 		OpenAIModelName: viper.GetString("OPENAI_MODEL_NAME"),
+		OpenAIApiBaseUrl: viper.GetString("OPENAI_API_BASE_URL"),
+
+		// Gemini
+		GeminiApiKey:    viper.GetString("GEMINI_API_KEY"),
+		GeminiModelName: viper.GetString("GEMINI_MODEL_NAME"),
 
 		DefaultPageSize: viper.GetInt("DEFAULT_PAGE_SIZE"),
 		MaxPageSize:     viper.GetInt("MAX_PAGE_SIZE"),
@@ -157,7 +207,44 @@ func LoadConfig() (*Config, error) {
 
 		// JobData
 		JobDataApiKey: viper.GetString("JOBDATA_API_KEY"),
+
+		// Job sources
+		JobSources:              splitAndTrim(viper.GetString("JOB_SOURCES")),
+		JobSourceTimeoutSeconds: viper.GetInt("JOB_SOURCE_TIMEOUT_SECONDS"),
+
+		// Job catalog ingestion
+		JobIngestionIntervalMinutes: viper.GetInt("JOB_INGESTION_INTERVAL_MINUTES"),
+		JobIngestionFields:          splitAndTrim(viper.GetString("JOB_INGESTION_FIELDS")),
+		JobTTLHours:                 viper.GetInt("JOB_TTL_HOURS"),
+		JobRequirementsUseAI:        viper.GetBool("JOB_REQUIREMENTS_USE_AI"),
+
+		// Job alerts
+		JobAlertCheckIntervalMinutes: viper.GetInt("JOB_ALERT_CHECK_INTERVAL_MINUTES"),
+
+		// CV analysis workers
+		CVAnalysisWorkers:        viper.GetInt("CV_ANALYSIS_WORKERS"),
+		CVAnalysisTimeoutSeconds: viper.GetInt("CV_ANALYSIS_TIMEOUT_SECONDS"),
+
+		// File storage
+		BlobStore:    viper.GetString("BLOB_STORE"),
+		BlobLocalDir: viper.GetString("BLOB_LOCAL_DIR"),
+
+		// Text recognition
+		OCRDisabled:       viper.GetBool("OCR_DISABLED"),
+		OCRLanguages:      viper.GetString("OCR_LANGUAGES"),
+		OCRTimeoutSeconds: viper.GetInt("OCR_TIMEOUT_SECONDS"),
 	}
 
 	return cfg, nil
 }
+
+// splitAndTrim splits a comma separated value, dropping empty entries
+func splitAndTrim(value string) []string {
+	var out []string
+	for _, part := range strings.Split(value, ",") {
+		if part = strings.TrimSpace(part); part != "" {
+			out = append(out, part)
+		}
+	}
+	return out
+}
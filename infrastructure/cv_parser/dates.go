@@ -0,0 +1,116 @@
+package cvparser
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/tsigemariamzewdu/JobMate-backend/domain/models"
+)
+
+const (
+	datePattern    = `(?:\b(?:jan|feb|mar|apr|may|jun|jul|aug|sep|oct|nov|dec)[a-z]{0,6}\.?,?\s+\d{4}|\d{1,2}\s*[/.]\s*\d{4}|\d{4}\s*[/.]\s*\d{1,2}\b|\d{4})`
+	presentPattern = `(?:present|current|currently|now|to date|date|today|ongoing|till now|እስከ አሁን|እስካሁን|አሁን|ዛሬ)`
+)
+
+var (
+	// "Jan 2019 - Present", "03/2018 – 06/2020", "2015 to 2017", "ከ2010 እስከ 2012"
+	dateRangePattern = regexp.MustCompile(`(?i)(?:ከ\s*)?(` + datePattern + `)\s*(?:-|–|—|to|until|till|እስከ)\s*(` + datePattern + `|` + presentPattern + `)(?:\s*ጀምሮ)?`)
+	// "Since 2020", "ከ2012 ጀምሮ"
+	sincePattern = regexp.MustCompile(`(?i)(?:since|from)\s+(` + datePattern + `)|ከ\s*(` + datePattern + `)\s*(?:ዓ\.?\s*ም\.?\s*)?ጀምሮ`)
+	presentWord  = regexp.MustCompile(`(?i)^` + presentPattern + `$`)
+	yearPattern  = regexp.MustCompile(`\b(19[5-9]\d|20\d\d)\b`)
+	monthYear    = regexp.MustCompile(`(?i)^([a-z]{3,9})\.?,?\s+(\d{4})$`)
+	numericDate  = regexp.MustCompile(`^(\d{1,4})\s*[/.]\s*(\d{1,4})$`)
+	// Ethiopian calendar years are marked "E.C." or "ዓ.ም" and run about seven years behind
+	ethiopianCalendar = regexp.MustCompile(`(?i)\be\.?\s*c\.?(?:\s|$|\))|ዓ\s*[./]?\s*ም`)
+)
+
+var monthNames = map[string]int{
+	"jan": 1, "feb": 2, "mar": 3, "apr": 4, "may": 5, "jun": 6,
+	"jul": 7, "aug": 8, "sep": 9, "oct": 10, "nov": 11, "dec": 12,
+}
+
+// dateRange is a period found in a line, with its position so it can be cut out
+type dateRange struct {
+	start, end models.YearMonth
+	current    bool
+	at         []int
+}
+
+// findDateRange returns the first period in line that has a believable start date
+func findDateRange(line string) (dateRange, bool) {
+	ethiopian := ethiopianCalendar.MatchString(line)
+	if m := dateRangePattern.FindStringSubmatchIndex(line); m != nil {
+		start, ok := parseDate(line[m[2]:m[3]], ethiopian)
+		if ok {
+			r := dateRange{start: start, at: m[:2]}
+			if end, ok := parseDate(line[m[4]:m[5]], ethiopian); ok {
+				if !before(end, start) {
+					r.end = end
+				}
+			} else {
+				r.current = presentWord.MatchString(line[m[4]:m[5]])
+			}
+			return r, true
+		}
+	}
+	if m := sincePattern.FindStringSubmatchIndex(line); m != nil {
+		group := 2
+		if m[group] < 0 {
+			group = 4
+		}
+		if start, ok := parseDate(line[m[group]:m[group+1]], ethiopian); ok {
+			return dateRange{start: start, current: true, at: m[:2]}, true
+		}
+	}
+	return dateRange{}, false
+}
+
+// parseDate reads one date of a range. Words that are not months, like "from 2019", are ignored.
+func parseDate(s string, ethiopian bool) (models.YearMonth, bool) {
+	s = strings.ToLower(strings.TrimSpace(s))
+	var d models.YearMonth
+	switch {
+	case monthYear.MatchString(s):
+		m := monthYear.FindStringSubmatch(s)
+		d.Year, _ = strconv.Atoi(m[2])
+		if len(m[1]) >= 3 {
+			d.Month = monthNames[m[1][:3]]
+		}
+	case numericDate.MatchString(s):
+		m := numericDate.FindStringSubmatch(s)
+		a, _ := strconv.Atoi(m[1])
+		b, _ := strconv.Atoi(m[2])
+		if len(m[1]) == 4 {
+			d.Year, d.Month = a, b
+		} else {
+			d.Month, d.Year = a, b
+		}
+		if d.Month < 1 || d.Month > 12 {
+			d.Month = 0
+		}
+	default:
+		year, err := strconv.Atoi(s)
+		if err != nil {
+			return d, false
+		}
+		d.Year = year
+	}
+
+	if ethiopian {
+		// counted from the Gregorian year the Ethiopian one starts in, each September;
+		// months cannot be mapped without the Ethiopian month names
+		d.Year += 7
+		d.Month = 0
+	}
+	if d.Year < 1950 || d.Year > time.Now().Year()+1 {
+		return d, false
+	}
+	return d, true
+}
+
+func before(a, b models.YearMonth) bool {
+	return a.Year < b.Year || a.Year == b.Year && a.Month != 0 && b.Month != 0 && a.Month < b.Month
+}
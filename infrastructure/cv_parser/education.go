@@ -0,0 +1,133 @@
+package cvparser
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/tsigemariamzewdu/JobMate-backend/domain/models"
+	"github.com/tsigemariamzewdu/JobMate-backend/infrastructure/skills"
+)
+
+// degreeLevels is checked in order, so "ሁለተኛ ዲግሪ" is a master's before "ዲግሪ" alone
+// is read as a bachelor's. Patterns run on text with Ethiopic spelling folded.
+var degreeLevels = []struct {
+	level   models.EducationLevel
+	pattern *regexp.Regexp
+}{
+	{models.EducationPhD, regexp.MustCompile(`(?i)\b(?:ph\.?\s?d|doctorate|doctor of philosophy)\b|ሶስተኛ ዲግሪ|ዶክትሬት`)},
+	{models.EducationMaster, regexp.MustCompile(`(?i)\b(?:m\.?\s?sc|m\.\s?a|ma|mba|m\.?\s?ed|m\.?\s?phil|llm|master'?s?)\b|ሁለተኛ ዲግሪ|ማስተርስ`)},
+	{models.EducationBachelor, regexp.MustCompile(`(?i)\b(?:b\.?\s?sc|b\.\s?a|ba|bba|b\.?\s?ed|b\.?\s?eng|b\.?\s?tech|llb|bachelor'?s?|degree)\b|የመጀመሪያ ዲግሪ|ዲግሪ`)},
+	{models.EducationDiploma, regexp.MustCompile(`(?i)\b(?:advanced diploma|diploma|level\s+(?:iv|v|4|5))\b|ዲፕሎማ`)},
+	{models.EducationHighSchool, regexp.MustCompile(`(?i)\b(?:high school|secondary school|preparatory(?: school)?|grade\s*1[02]|eslce|egsece|matric(?:ulation)?)\b|ሁለተኛ ደረጃ|መሰናዶ|12ኛ ክፍል`)},
+	{models.EducationOther, regexp.MustCompile(`(?i)\b(?:certificate|level\s+(?:i{1,3}|[1-3]))\b|ሰርተፍኬት`)},
+}
+
+var (
+	institutionWords = []string{
+		"university", "college", "institute", "school", "academy", "polytechnic",
+		"ዩኒቨርሲቲ", "ኮሌጅ", "ኢንስቲትዩት", "ትምህርት ቤት", "አካዳሚ",
+	}
+	educationSeparators = regexp.MustCompile(`(?i)\s*[|,;:]\s*|\s+(?:-|–|—|at|from)\s+`)
+	fieldMarker         = regexp.MustCompile(`(?i)\s(?:in|of)\s`)
+)
+
+// parseEducation groups the education section into entries. A line naming a degree or an
+// institution starts a new entry unless the current one still lacks that part.
+func parseEducation(section string) []models.EducationEntry {
+	var entries []models.EducationEntry
+	var current *models.EducationEntry
+	for _, line := range nonEmptyLines(section) {
+		line = bulletPrefix.ReplaceAllString(line, "")
+		var degree, institution string
+		var level models.EducationLevel
+		var field string
+		for _, part := range educationSeparators.Split(line, -1) {
+			part = strings.TrimSpace(part)
+			if l, d, f, ok := matchDegree(part); ok && degree == "" {
+				level, degree, field = l, d, f
+			} else if institution == "" && containsWord(part, institutionWords) {
+				institution = cleanInstitution(part)
+			}
+		}
+
+		if degree != "" || institution != "" {
+			if current == nil || degree != "" && current.Degree != "" || institution != "" && current.Institution != "" {
+				entries = append(entries, models.EducationEntry{})
+				current = &entries[len(entries)-1]
+			}
+			if degree != "" {
+				current.Degree, current.Level, current.FieldOfStudy = degree, level, field
+			}
+			if institution != "" {
+				current.Institution = institution
+			}
+		}
+		if current != nil && current.GraduationYear == 0 {
+			current.StartYear, current.GraduationYear = educationYears(line)
+		}
+	}
+	return entries
+}
+
+// matchDegree finds a degree in part and reads the field of study around it:
+// "BSc in Computer Science", "Bachelor of Arts in Economics", "በአካውንቲንግ የመጀመሪያ ዲግሪ"
+func matchDegree(part string) (level models.EducationLevel, degree, field string, ok bool) {
+	// folding turns each invalid byte into a 3-byte U+FFFD, which would shift the offsets below
+	part = strings.ToValidUTF8(part, "\uFFFD")
+	folded := skills.FoldEthiopic(part)
+	for _, d := range degreeLevels {
+		loc := d.pattern.FindStringIndex(folded)
+		if loc == nil {
+			continue
+		}
+		// folding keeps every letter's length, so offsets in folded hold in part
+		before, after := part[:loc[0]], part[loc[1]:]
+		degree = part[loc[0]:loc[1]]
+		if m := fieldMarker.FindAllStringIndex(after, -1); m != nil {
+			// the last "in" or "of" names the field: "Bachelor of Science in Nursing"
+			last := m[len(m)-1]
+			degree += after[:last[0]]
+			field = after[last[1]:]
+		} else if strings.TrimSpace(after) != "" {
+			field = after
+		} else {
+			field = strings.TrimPrefix(strings.TrimSpace(before), "በ")
+		}
+		if d.level == models.EducationHighSchool {
+			field = "" // "Preparatory School", not a field of study
+		}
+		field, _, _ = strings.Cut(field, "(")
+		field = strings.Trim(yearPattern.ReplaceAllString(field, ""), " \t:;.-–—'’")
+		return d.level, strings.TrimSpace(degree), field, true
+	}
+	return "", "", "", false
+}
+
+// cleanInstitution drops the years written after a school's name
+func cleanInstitution(part string) string {
+	part, _, _ = strings.Cut(part, "(")
+	part = ethiopianCalendar.ReplaceAllString(yearPattern.ReplaceAllString(part, ""), "")
+	return strings.Trim(part, " \t.-–—")
+}
+
+// educationYears returns the years attended, or only the graduation year when one is given
+func educationYears(line string) (start, graduation int) {
+	if r, ok := findDateRange(line); ok {
+		if r.current {
+			return r.start.Year, 0
+		}
+		if r.end.Year != 0 {
+			return r.start.Year, r.end.Year
+		}
+	}
+	years := yearPattern.FindAllString(line, -1)
+	if len(years) == 0 {
+		return 0, 0
+	}
+	graduation, _ = strconv.Atoi(years[len(years)-1])
+	if ethiopianCalendar.MatchString(line) {
+		graduation += 7
+	}
+	return 0, graduation
+}
@@ -0,0 +1,208 @@
+package cvparser
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/tsigemariamzewdu/JobMate-backend/domain/models"
+	"github.com/tsigemariamzewdu/JobMate-backend/infrastructure/skills"
+)
+
+// a line longer than this is description, not a job title or employer
+const maxHeaderWords = 10
+
+var (
+	bulletPrefix     = regexp.MustCompile(`^[-•*▪●◦·–>»✓✔]\s*`)
+	orgAbbreviation  = regexp.MustCompile(`(?i)\b(?:s\.c|p\.?l\.?c|ltd|inc|llc|co|corp)\.$`)
+	headerSeparators = regexp.MustCompile(`(?i)\s+(?:at|@|-|–|—)\s+|\s*[|,@]\s*`)
+
+	organizationWords = []string{
+		"plc", "p.l.c", "ltd", "limited", "inc", "llc", "s.c", "share company", "company", "corporation",
+		"bank", "university", "college", "institute", "school", "academy", "hospital", "clinic", "ministry",
+		"agency", "authority", "bureau", "office of", "commission", "enterprise", "organization",
+		"organisation", "ngo", "foundation", "association", "group", "solutions", "technologies",
+		"telecom", "airlines", "factory", "industry", "union", "consultancy", "services",
+		"ድርጅት", "ባንክ", "ዩኒቨርሲቲ", "ኮሌጅ", "ሚኒስቴር", "ኤጀንሲ", "ባለስልጣን", "ቢሮ", "ኩባንያ", "አክሲዮን ማህበር",
+		"ሆስፒታል", "ትምህርት ቤት", "ኢንተርፕራይዝ", "ፋብሪካ", "ማህበር",
+	}
+	titleWords = []string{
+		"engineer", "developer", "manager", "officer", "assistant", "accountant", "intern", "analyst",
+		"consultant", "teacher", "lecturer", "instructor", "nurse", "doctor", "director", "coordinator",
+		"specialist", "designer", "lead", "head", "clerk", "cashier", "driver", "secretary", "administrator",
+		"supervisor", "technician", "representative", "agent", "advisor", "expert", "auditor", "programmer",
+		"associate", "volunteer", "founder", "pharmacist", "researcher", "receptionist", "teller",
+		"ባለሙያ", "ሰራተኛ", "ሀላፊ", "ኃላፊ", "መምህር", "አስተማሪ", "ሹፌር", "ሂሳብ ሹም", "ጸሀፊ", "ነርስ", "ሀኪም",
+		"ዳይሬክተር", "አስተባባሪ", "ረዳት", "ኦፊሰር", "ገንዘብ ያዥ", "ተቆጣጣሪ",
+	}
+	// locations often follow the employer and are neither title nor organization
+	locationWords = []string{
+		"addis ababa", "ethiopia", "remote", "adama", "hawassa", "bahir dar", "mekelle", "dire dawa",
+		"gondar", "jimma", "dessie", "አዲስ አበባ", "ኢትዮጵያ", "አዳማ", "ሀዋሳ", "ባህር ዳር", "መቀሌ", "ድሬ ዳዋ",
+	}
+)
+
+// experienceHeader holds where one dated entry's lines sit in its section
+type experienceHeader struct {
+	dates     dateRange
+	top       int // first line of the title and employer
+	descStart int
+	parts     []string
+}
+
+// parseExperience reads dated entries from the experience section. Each line with a date
+// range starts an entry; the title and employer are taken from the rest of that line and
+// the lines just above it, or just below it when the dates open a block.
+func parseExperience(section string) []models.ExperienceEntry {
+	lines, blockStart := blocks(section)
+	var headers []experienceHeader
+	floor := 0 // lines above this belong to the previous entry
+	for i, line := range lines {
+		dates, ok := findDateRange(line)
+		if !ok {
+			continue
+		}
+		h := experienceHeader{dates: dates, top: i, descStart: i + 1}
+		h.parts = headerParts(line[:dates.at[0]] + " | " + line[dates.at[1]:])
+		datesFirst := len(h.parts) == 0 && (i == floor || blockStart[i])
+		if len(h.parts) < 2 && !datesFirst {
+			var above []string
+			for h.top > floor && i-h.top < 2 && !blockStart[h.top] && isHeaderLine(lines[h.top-1]) {
+				h.top--
+				above = append(headerParts(lines[h.top]), above...)
+			}
+			h.parts = append(above, h.parts...)
+		}
+		for len(h.parts) < 2 && h.descStart < len(lines) && h.descStart-i <= 2 && isHeaderLine(lines[h.descStart]) {
+			if len(h.parts) > 0 && !datesFirst {
+				break
+			}
+			h.parts = append(h.parts, headerParts(lines[h.descStart])...)
+			h.descStart++
+		}
+		headers = append(headers, h)
+		floor = h.descStart
+	}
+
+	entries := make([]models.ExperienceEntry, 0, len(headers))
+	for n, h := range headers {
+		descEnd := len(lines)
+		if n+1 < len(headers) {
+			descEnd = headers[n+1].top
+		}
+		var description []string
+		for _, line := range lines[min(h.descStart, descEnd):descEnd] {
+			description = append(description, bulletPrefix.ReplaceAllString(line, ""))
+		}
+
+		title, organization := titleAndOrganization(h.parts)
+		entries = append(entries, models.ExperienceEntry{
+			Title:        title,
+			Organization: organization,
+			Start:        h.dates.start,
+			End:          h.dates.end,
+			Current:      h.dates.current,
+			Description:  strings.Join(description, "\n"),
+		})
+	}
+	return entries
+}
+
+// titleAndOrganization tells the job title from the employer by their wording,
+// falling back to the common "Title, Employer" order
+func titleAndOrganization(parts []string) (title, organization string) {
+	var rest []string
+	for _, p := range parts {
+		if organization == "" && containsWord(p, organizationWords) && !containsWord(p, titleWords) {
+			organization = p
+			continue
+		}
+		rest = append(rest, p)
+	}
+	for i, p := range rest {
+		if containsWord(p, titleWords) {
+			title = p
+			rest = append(rest[:i:i], rest[i+1:]...)
+			break
+		}
+	}
+	if title == "" && len(rest) > 0 {
+		title, rest = rest[0], rest[1:]
+	}
+	if organization == "" && len(rest) > 0 {
+		organization = rest[0]
+	}
+	return title, organization
+}
+
+// headerParts splits a title or employer line on the usual separators, dropping
+// places and leftover punctuation
+func headerParts(line string) []string {
+	var parts []string
+	for _, p := range headerSeparators.Split(bulletPrefix.ReplaceAllString(line, ""), -1) {
+		p = strings.Trim(ethiopianCalendar.ReplaceAllString(p, ""), " \t()[]:;.-–—/")
+		if p == "" || isLocation(p) {
+			continue
+		}
+		parts = append(parts, p)
+	}
+	return parts
+}
+
+func isHeaderLine(line string) bool {
+	if bulletPrefix.MatchString(line) || strings.HasSuffix(line, "።") {
+		return false
+	}
+	// a full stop ends a sentence of description, but also "Dashen Bank S.C."
+	if strings.HasSuffix(line, ".") && !orgAbbreviation.MatchString(line) {
+		return false
+	}
+	if _, dated := findDateRange(line); dated {
+		return false
+	}
+	return len(strings.Fields(line)) <= maxHeaderWords
+}
+
+func isLocation(part string) bool {
+	key := skills.FoldEthiopic(strings.ToLower(part))
+	for _, place := range locationWords {
+		if key == place {
+			return true
+		}
+	}
+	return false
+}
+
+// containsWord reports whether text has one of words as whole words
+func containsWord(text string, words []string) bool {
+	for _, w := range words {
+		if skills.Mentions(text, w) {
+			return true
+		}
+	}
+	return false
+}
+
+// blocks returns the non-empty lines of text, marking those that follow a blank line
+func blocks(text string) (lines []string, blockStart []bool) {
+	blank := false
+	for _, line := range strings.Split(text, "\n") {
+		if line = strings.TrimSpace(line); line == "" {
+			blank = true
+			continue
+		}
+		lines = append(lines, line)
+		blockStart = append(blockStart, blank)
+		blank = false
+	}
+	return lines, blockStart
+}
+
+func nonEmptyLines(text string) []string {
+	var lines []string
+	for _, line := range strings.Split(text, "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			lines = append(lines, line)
+		}
+	}
+	return lines
+}
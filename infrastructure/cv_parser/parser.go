@@ -0,0 +1,38 @@
+package cvparser
+
+import (
+	"strings"
+
+	service "github.com/tsigemariamzewdu/JobMate-backend/domain/interfaces/services"
+	"github.com/tsigemariamzewdu/JobMate-backend/domain/models"
+	"github.com/tsigemariamzewdu/JobMate-backend/infrastructure/skills"
+)
+
+// CVParser segments CV text by its headings, in English or Amharic, and reads each
+// section with rules. It is deterministic and needs no network.
+type CVParser struct{}
+
+func NewCVParser() service.CVParser {
+	return &CVParser{}
+}
+
+func (p *CVParser) Parse(text string) *models.ParsedCV {
+	sections := splitSections(text)
+
+	// skills count wherever they are mentioned, except under references, where they
+	// would describe the referees
+	var skillText []string
+	for section, body := range sections {
+		if section != models.CVSectionReferences {
+			skillText = append(skillText, body)
+		}
+	}
+
+	return &models.ParsedCV{
+		Sections:   sections,
+		Summary:    sections[models.CVSectionSummary],
+		Skills:     skills.FindIn(strings.Join(skillText, "\n")),
+		Experience: parseExperience(sections[models.CVSectionExperience]),
+		Education:  parseEducation(sections[models.CVSectionEducation]),
+	}
+}
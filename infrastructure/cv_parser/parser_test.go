@@ -0,0 +1,273 @@
+package cvparser
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/tsigemariamzewdu/JobMate-backend/domain/models"
+)
+
+const englishCV = `Abebe Kebede
+abebe.kebede@example.com | +251 911 234 567
+
+PROFESSIONAL SUMMARY
+Accountant with five years of experience in banking and audit.
+
+WORK EXPERIENCE
+Senior Accountant, Dashen Bank S.C.
+Mar 2021 - Present
+- Prepared monthly IFRS financial statements for 40 branches
+- Reduced month-end closing time by 30%
+- Led a team of 4 junior accountants
+
+Junior Accountant | Abay Trading PLC | 06/2018 – 02/2021
+- Reconciled accounts in Peachtree
+- Processed payroll for 120 employees
+
+EDUCATION
+BSc in Accounting and Finance, Addis Ababa University, 2014 - 2018
+Preparatory School, Menelik II Secondary School, 2013
+
+Skills: IFRS, Peachtree, Excel, financial analysis
+
+REFERENCES
+Dr. Almaz Tesfaye, Python instructor, Addis Ababa University`
+
+const amharicCV = `ሰላማዊት ታደሰ
+selam@example.com
+0911 234 567
+
+ስለ እኔ
+በጤና ዘርፍ ልምድ ያላት ነርስ።
+
+የስራ ልምድ
+ነርስ, ጥቁር አንበሳ ሆስፒታል
+ከ2010 እስከ 2014 ዓ.ም
+- ለታካሚዎች እንክብካቤ ሰጠሁ
+- አዳዲስ ነርሶችን አሰለጠንኩ
+- በቀን 50 ታካሚዎችን አስተናገድኩ
+
+የትምህርት ዝግጅት
+በነርሲንግ የመጀመሪያ ዲግሪ, ጅማ ዩኒቨርሲቲ, 2009 ዓ.ም
+
+ክህሎቶች
+nursing, public health, communication`
+
+func TestSplitSections(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want map[models.CVSection]string
+	}{
+		{
+			name: "english headings",
+			text: "Abebe Kebede\n\nSUMMARY\nAccountant.\n\nWork Experience\nAccountant at Dashen Bank",
+			want: map[models.CVSection]string{
+				models.CVSectionContact:    "Abebe Kebede",
+				models.CVSectionSummary:    "Accountant.",
+				models.CVSectionExperience: "Accountant at Dashen Bank",
+			},
+		},
+		{
+			name: "decorated and numbered headings",
+			text: "== EDUCATION ==\nBSc in Nursing\n2. Skills:\nnursing",
+			want: map[models.CVSection]string{
+				models.CVSectionEducation: "BSc in Nursing",
+				models.CVSectionSkills:    "nursing",
+			},
+		},
+		{
+			name: "inline heading",
+			text: "Skills: Go, SQL\nLanguages: Amharic, English",
+			want: map[models.CVSection]string{
+				models.CVSectionSkills:    "Go, SQL",
+				models.CVSectionLanguages: "Amharic, English",
+			},
+		},
+		{
+			name: "amharic headings with ethiopic spelling variants",
+			text: "የሥራ ልምድ\nነርስ\nክህሎቶች\nnursing",
+			want: map[models.CVSection]string{
+				models.CVSectionExperience: "ነርስ",
+				models.CVSectionSkills:     "nursing",
+			},
+		},
+		{
+			name: "sentence starting with a heading word",
+			text: "Summary\nExperience in audit and tax for several large clients.",
+			want: map[models.CVSection]string{
+				models.CVSectionSummary: "Experience in audit and tax for several large clients.",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := splitSections(tt.text); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("splitSections() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFindDateRange(t *testing.T) {
+	tests := []struct {
+		line       string
+		ok         bool
+		start, end models.YearMonth
+		current    bool
+	}{
+		{line: "Jan 2019 - Present", ok: true, start: models.YearMonth{Year: 2019, Month: 1}, current: true},
+		{line: "Accountant | 03/2018 – 06/2020", ok: true, start: models.YearMonth{Year: 2018, Month: 3}, end: models.YearMonth{Year: 2020, Month: 6}},
+		{line: "2015 to 2017", ok: true, start: models.YearMonth{Year: 2015}, end: models.YearMonth{Year: 2017}},
+		{line: "September 2016 until March 2019", ok: true, start: models.YearMonth{Year: 2016, Month: 9}, end: models.YearMonth{Year: 2019, Month: 3}},
+		{line: "Since 2020", ok: true, start: models.YearMonth{Year: 2020}, current: true},
+		// Ethiopian calendar years run seven behind and their months are dropped
+		{line: "ከ2010 እስከ 2014 ዓ.ም", ok: true, start: models.YearMonth{Year: 2017}, end: models.YearMonth{Year: 2021}},
+		{line: "ከ2012 ዓ.ም ጀምሮ", ok: true, start: models.YearMonth{Year: 2019}, current: true},
+		// an end before the start is dropped rather than trusted
+		{line: "2019 - 2017", ok: true, start: models.YearMonth{Year: 2019}},
+		{line: "Processed payroll for 120 employees", ok: false},
+		{line: "1890 - 1895", ok: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.line, func(t *testing.T) {
+			got, ok := findDateRange(tt.line)
+			if ok != tt.ok {
+				t.Fatalf("findDateRange() ok = %v, want %v", ok, tt.ok)
+			}
+			if !ok {
+				return
+			}
+			if got.start != tt.start || got.end != tt.end || got.current != tt.current {
+				t.Errorf("findDateRange() = %+v to %+v (current %v), want %+v to %+v (current %v)",
+					got.start, got.end, got.current, tt.start, tt.end, tt.current)
+			}
+		})
+	}
+}
+
+func TestParseExperience(t *testing.T) {
+	tests := []struct {
+		name    string
+		section string
+		want    []models.ExperienceEntry
+	}{
+		{
+			name:    "english",
+			section: splitSections(englishCV)[models.CVSectionExperience],
+			want: []models.ExperienceEntry{
+				{
+					Title:        "Senior Accountant",
+					Organization: "Dashen Bank S.C",
+					Start:        models.YearMonth{Year: 2021, Month: 3},
+					Current:      true,
+					Description: "Prepared monthly IFRS financial statements for 40 branches\n" +
+						"Reduced month-end closing time by 30%\n" +
+						"Led a team of 4 junior accountants",
+				},
+				{
+					Title:        "Junior Accountant",
+					Organization: "Abay Trading PLC",
+					Start:        models.YearMonth{Year: 2018, Month: 6},
+					End:          models.YearMonth{Year: 2021, Month: 2},
+					Description:  "Reconciled accounts in Peachtree\nProcessed payroll for 120 employees",
+				},
+			},
+		},
+		{
+			name:    "amharic",
+			section: splitSections(amharicCV)[models.CVSectionExperience],
+			want: []models.ExperienceEntry{
+				{
+					Title:        "ነርስ",
+					Organization: "ጥቁር አንበሳ ሆስፒታል",
+					Start:        models.YearMonth{Year: 2017},
+					End:          models.YearMonth{Year: 2021},
+					Description:  "ለታካሚዎች እንክብካቤ ሰጠሁ\nአዳዲስ ነርሶችን አሰለጠንኩ\nበቀን 50 ታካሚዎችን አስተናገድኩ",
+				},
+			},
+		},
+		{
+			name:    "dates opening a block",
+			section: "2016 - 2018\nCashier\nCommercial Bank of Ethiopia\nHandled customer deposits",
+			want: []models.ExperienceEntry{
+				{
+					Title:        "Cashier",
+					Organization: "Commercial Bank of Ethiopia",
+					Start:        models.YearMonth{Year: 2016},
+					End:          models.YearMonth{Year: 2018},
+					Description:  "Handled customer deposits",
+				},
+			},
+		},
+		{
+			name:    "no dates",
+			section: "Volunteer tutor at a local school",
+			want:    []models.ExperienceEntry{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := parseExperience(tt.section); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseExperience() =\n%+v\nwant\n%+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseEducation(t *testing.T) {
+	tests := []struct {
+		name    string
+		section string
+		want    []models.EducationEntry
+	}{
+		{
+			name:    "english",
+			section: splitSections(englishCV)[models.CVSectionEducation],
+			want: []models.EducationEntry{
+				{Institution: "Addis Ababa University", Degree: "BSc", Level: models.EducationBachelor, FieldOfStudy: "Accounting and Finance", StartYear: 2014, GraduationYear: 2018},
+				{Institution: "Menelik II Secondary School", Degree: "Preparatory School", Level: models.EducationHighSchool, GraduationYear: 2013},
+			},
+		},
+		{
+			name:    "amharic degree and ethiopian calendar year",
+			section: splitSections(amharicCV)[models.CVSectionEducation],
+			want: []models.EducationEntry{
+				{Institution: "ጅማ ዩኒቨርሲቲ", Degree: "የመጀመሪያ ዲግሪ", Level: models.EducationBachelor, FieldOfStudy: "ነርሲንግ", GraduationYear: 2016},
+			},
+		},
+		{
+			name:    "degree and institution on separate lines",
+			section: "Master of Business Administration\nUnity University\n2020",
+			want: []models.EducationEntry{
+				{Institution: "Unity University", Degree: "Master", Level: models.EducationMaster, FieldOfStudy: "Business Administration", GraduationYear: 2020},
+			},
+		},
+		{
+			name:    "invalid utf-8",
+			section: "\xff\xfeBSc in Computer Science, Bahir Dar University, 2019",
+			want: []models.EducationEntry{
+				{Institution: "Bahir Dar University", Degree: "BSc", Level: models.EducationBachelor, FieldOfStudy: "Computer Science", GraduationYear: 2019},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := parseEducation(tt.section); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseEducation() =\n%+v\nwant\n%+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseSkipsSkillsOfReferees(t *testing.T) {
+	parsed := NewCVParser().Parse(englishCV)
+
+	want := []string{"excel", "accounting", "financial analysis", "ifrs", "peachtree"}
+	if !reflect.DeepEqual(parsed.Skills, want) {
+		t.Errorf("Skills = %q, want %q", parsed.Skills, want)
+	}
+	if parsed.Summary != "Accountant with five years of experience in banking and audit." {
+		t.Errorf("Summary = %q", parsed.Summary)
+	}
+}
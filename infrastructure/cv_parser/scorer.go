@@ -0,0 +1,330 @@
+package cvparser
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"strings"
+	"time"
+
+	service "github.com/tsigemariamzewdu/JobMate-backend/domain/interfaces/services"
+	"github.com/tsigemariamzewdu/JobMate-backend/domain/models"
+	"github.com/tsigemariamzewdu/JobMate-backend/infrastructure/skills"
+)
+
+// points per check, summing to 100 when no check is skipped
+const (
+	sectionsPoints    = 20
+	contactPoints     = 10
+	lengthPoints      = 10
+	quantifiedPoints  = 15
+	actionVerbPoints  = 15
+	datePoints        = 10
+	keywordPoints     = 20
+	minKeywordShare   = 0.6 // of the job's keywords a passing CV mentions
+	minActionShare    = 0.5 // of experience lines a passing CV starts with an action verb
+	minQuantifiedLine = 3
+)
+
+// a CV of one to two pages; ATS and recruiters skim past much longer ones
+const (
+	minWords, maxWords                   = 200, 1000
+	minToleratedWords, maxToleratedWords = 120, 1500
+)
+
+// scoredSections are the sections every CV is expected to have
+var scoredSections = []models.CVSection{
+	models.CVSectionSummary,
+	models.CVSectionExperience,
+	models.CVSectionEducation,
+	models.CVSectionSkills,
+}
+
+var (
+	emailPattern = regexp.MustCompile(`(?i)[a-z0-9._%+-]+@[a-z0-9.-]+\.[a-z]{2,}`)
+	// Ethiopian mobile and landline numbers, with or without +251, and other international numbers
+	phonePattern = regexp.MustCompile(`(?:\+?251[\s-]?|\b0)[1-9](?:[\s-]?\d){8}\b|\+\d{1,3}[\s-]?\d[\d\s-]{6,}\d`)
+	// a number once dates are removed: "30%", "5 engineers", "two branches", "2 ሚሊዮን ብር"
+	quantity = regexp.MustCompile(`(?i)\d|\b(?:two|three|four|five|six|seven|eight|nine|ten|dozens?|hundreds?|thousands?|millions?)\b|ሚሊዮን|ሺህ|መቶ`)
+
+	actionVerbs = map[string]bool{
+		"achieved": true, "administered": true, "analyzed": true, "analysed": true, "built": true,
+		"collaborated": true, "conducted": true, "coordinated": true, "created": true, "cut": true,
+		"delivered": true, "designed": true, "developed": true, "directed": true, "drove": true,
+		"established": true, "evaluated": true, "expanded": true, "facilitated": true, "generated": true,
+		"grew": true, "handled": true, "helped": true, "implemented": true, "improved": true,
+		"increased": true, "initiated": true, "introduced": true, "launched": true, "led": true,
+		"maintained": true, "managed": true, "mentored": true, "migrated": true, "monitored": true,
+		"negotiated": true, "organized": true, "organised": true, "oversaw": true, "planned": true,
+		"prepared": true, "presented": true, "processed": true, "produced": true, "reduced": true,
+		"resolved": true, "reviewed": true, "saved": true, "served": true, "spearheaded": true,
+		"streamlined": true, "supervised": true, "supported": true, "taught": true, "tested": true,
+		"trained": true, "won": true, "wrote": true,
+		// present tense, for current roles
+		"build": true, "coordinate": true, "design": true, "develop": true, "lead": true,
+		"maintain": true, "manage": true, "oversee": true, "prepare": true, "supervise": true,
+		"support": true, "teach": true,
+	}
+	// Amharic puts the verb last; a first person past ending ("አዘጋጀሁ", "መራሁ", "ሰርቻለሁ")
+	// is how Amharic CVs state what the writer did
+	amharicVerbEnding = regexp.MustCompile(`[ሁኩ][።.]?$`)
+)
+
+// CVScorer grades CVs with a fixed rubric, so the same CV always gets the same score
+type CVScorer struct{}
+
+func NewCVScorer() service.CVScorer {
+	return &CVScorer{}
+}
+
+func (s *CVScorer) Score(text string, parsed *models.ParsedCV, target *models.Job) *models.CVScore {
+	checks := []models.CVCheckResult{
+		checkSections(parsed),
+		checkContactInfo(text),
+		checkLength(text),
+		checkQuantifiedAchievements(parsed),
+		checkActionVerbs(parsed),
+		checkDateConsistency(parsed.Experience, time.Now()),
+		checkKeywordCoverage(text, parsed, target),
+	}
+
+	score := &models.CVScore{Checks: checks}
+	points, maxPoints := 0, 0
+	for _, c := range checks {
+		if c.Skipped {
+			continue
+		}
+		points += c.Points
+		maxPoints += c.MaxPoints
+	}
+	if maxPoints > 0 {
+		score.Score = int(math.Round(float64(points) * 100 / float64(maxPoints)))
+	}
+	if target != nil {
+		score.TargetJobID = target.ID
+	}
+	return score
+}
+
+func checkSections(parsed *models.ParsedCV) models.CVCheckResult {
+	var missing []string
+	for _, section := range scoredSections {
+		if parsed.Sections[section] == "" {
+			missing = append(missing, string(section))
+		}
+	}
+	found := len(scoredSections) - len(missing)
+	result := models.CVCheckResult{
+		Check:     models.CVCheckSections,
+		Passed:    len(missing) == 0,
+		Points:    sectionsPoints * found / len(scoredSections),
+		MaxPoints: sectionsPoints,
+		Detail:    "Has summary, experience, education and skills sections",
+	}
+	if len(missing) > 0 {
+		result.Detail = "Missing sections: " + strings.Join(missing, ", ")
+	}
+	return result
+}
+
+func checkContactInfo(text string) models.CVCheckResult {
+	hasEmail := emailPattern.MatchString(text)
+	hasPhone := phonePattern.MatchString(text)
+	result := models.CVCheckResult{
+		Check:     models.CVCheckContactInfo,
+		Passed:    hasEmail && hasPhone,
+		MaxPoints: contactPoints,
+		Detail:    "Has an email address and a phone number",
+	}
+	if hasEmail {
+		result.Points += contactPoints / 2
+	}
+	if hasPhone {
+		result.Points += contactPoints / 2
+	}
+	switch {
+	case !hasEmail && !hasPhone:
+		result.Detail = "No email address or phone number found"
+	case !hasEmail:
+		result.Detail = "No email address found"
+	case !hasPhone:
+		result.Detail = "No phone number found"
+	}
+	return result
+}
+
+func checkLength(text string) models.CVCheckResult {
+	words := len(strings.Fields(text))
+	result := models.CVCheckResult{
+		Check:     models.CVCheckLength,
+		MaxPoints: lengthPoints,
+		Detail:    fmt.Sprintf("%d words", words),
+	}
+	switch {
+	case words >= minWords && words <= maxWords:
+		result.Passed = true
+		result.Points = lengthPoints
+	case words >= minToleratedWords && words <= maxToleratedWords:
+		result.Points = lengthPoints / 2
+	}
+	switch {
+	case words < minWords:
+		result.Detail += fmt.Sprintf("; aim for at least %d", minWords)
+	case words > maxWords:
+		result.Detail += fmt.Sprintf("; aim for at most %d", maxWords)
+	}
+	return result
+}
+
+// checkQuantifiedAchievements counts experience and project lines that give a number,
+// once dates, years and phone numbers are taken out
+func checkQuantifiedAchievements(parsed *models.ParsedCV) models.CVCheckResult {
+	count := 0
+	for _, line := range achievementLines(parsed) {
+		if r, ok := findDateRange(line); ok {
+			line = line[:r.at[0]] + line[r.at[1]:]
+		}
+		line = phonePattern.ReplaceAllString(yearPattern.ReplaceAllString(line, ""), "")
+		if quantity.MatchString(line) {
+			count++
+		}
+	}
+	result := models.CVCheckResult{
+		Check:     models.CVCheckQuantifiedAchievements,
+		Passed:    count >= minQuantifiedLine,
+		Points:    quantifiedPoints * min(count, minQuantifiedLine) / minQuantifiedLine,
+		MaxPoints: quantifiedPoints,
+		Detail:    fmt.Sprintf("%d experience or project lines give numbers", count),
+	}
+	if !result.Passed {
+		result.Detail += fmt.Sprintf("; aim for at least %d", minQuantifiedLine)
+	}
+	return result
+}
+
+// checkActionVerbs looks at how experience lines open, or in Amharic how they end
+func checkActionVerbs(parsed *models.ParsedCV) models.CVCheckResult {
+	lines := descriptionLines(parsed.Experience)
+	result := models.CVCheckResult{
+		Check:     models.CVCheckActionVerbs,
+		MaxPoints: actionVerbPoints,
+	}
+	if len(lines) == 0 {
+		result.Detail = "No descriptions of your work found under experience"
+		return result
+	}
+
+	count := 0
+	for _, line := range lines {
+		if startsWithActionVerb(line) || amharicVerbEnding.MatchString(line) {
+			count++
+		}
+	}
+	share := float64(count) / float64(len(lines))
+	result.Passed = share >= minActionShare
+	result.Points = int(math.Round(actionVerbPoints * math.Min(share/minActionShare, 1)))
+	result.Detail = fmt.Sprintf("%d of %d experience lines start with an action verb", count, len(lines))
+	return result
+}
+
+func startsWithActionVerb(line string) bool {
+	words := strings.Fields(strings.ToLower(line))
+	if len(words) == 0 {
+		return false
+	}
+	return actionVerbs[strings.Trim(words[0], ",.;:")]
+}
+
+// checkDateConsistency wants every position to have a start, an end or a current marker,
+// no dates in the future, and the newest position listed first
+func checkDateConsistency(entries []models.ExperienceEntry, now time.Time) models.CVCheckResult {
+	result := models.CVCheckResult{
+		Check:     models.CVCheckDateConsistency,
+		MaxPoints: datePoints,
+	}
+	if len(entries) == 0 {
+		result.Skipped = true
+		result.Detail = "No dated experience found"
+		return result
+	}
+
+	var issues []string
+	thisMonth := models.YearMonth{Year: now.Year(), Month: int(now.Month())}
+	openEnded, future, outOfOrder := false, false, false
+	for i, e := range entries {
+		if e.End.Year == 0 && !e.Current {
+			openEnded = true
+		}
+		if before(thisMonth, e.Start) || e.End.Year != 0 && before(thisMonth, e.End) {
+			future = true
+		}
+		if i > 0 && before(entries[i-1].Start, e.Start) {
+			outOfOrder = true
+		}
+	}
+	if openEnded {
+		issues = append(issues, "a position has no end date or one before its start")
+	}
+	if future {
+		issues = append(issues, "a date is in the future")
+	}
+	if outOfOrder {
+		issues = append(issues, "positions are not listed newest first")
+	}
+
+	result.Passed = len(issues) == 0
+	result.Points = max(datePoints-len(issues)*datePoints/2, 0)
+	result.Detail = "Dates are complete and in order"
+	if len(issues) > 0 {
+		result.Detail = "Check your dates: " + strings.Join(issues, "; ")
+	}
+	return result
+}
+
+// checkKeywordCoverage matches the target job's keywords against the CV
+func checkKeywordCoverage(text string, parsed *models.ParsedCV, target *models.Job) models.CVCheckResult {
+	result := models.CVCheckResult{
+		Check:     models.CVCheckKeywordCoverage,
+		MaxPoints: keywordPoints,
+	}
+	if target == nil {
+		result.Skipped = true
+		result.Detail = "No target job given"
+		return result
+	}
+	keywords := skills.JobKeywords(target.Requirements, target.Title+"\n"+target.Description)
+	if len(keywords) == 0 {
+		result.Skipped = true
+		result.Detail = "The target job lists no keywords to match"
+		return result
+	}
+
+	matched, missing := skills.Overlap(text, parsed.Skills, keywords)
+	share := float64(len(matched)) / float64(len(keywords))
+	result.Passed = share >= minKeywordShare
+	result.Points = int(math.Round(keywordPoints * share))
+	result.Detail = fmt.Sprintf("Mentions %d of %d keywords from the job", len(matched), len(keywords))
+	if len(missing) > 0 {
+		result.Detail += "; missing: " + strings.Join(missing, ", ")
+	}
+	return result
+}
+
+// achievementLines are the lines under experience and projects, without their bullets
+func achievementLines(parsed *models.ParsedCV) []string {
+	var lines []string
+	for _, section := range []models.CVSection{models.CVSectionExperience, models.CVSectionProjects} {
+		for _, line := range nonEmptyLines(parsed.Sections[section]) {
+			lines = append(lines, bulletPrefix.ReplaceAllString(line, ""))
+		}
+	}
+	return lines
+}
+
+func descriptionLines(entries []models.ExperienceEntry) []string {
+	var lines []string
+	for _, e := range entries {
+		lines = append(lines, nonEmptyLines(e.Description)...)
+	}
+	return lines
+}
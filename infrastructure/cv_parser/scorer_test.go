@@ -0,0 +1,270 @@
+package cvparser
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/tsigemariamzewdu/JobMate-backend/domain/models"
+)
+
+func TestScore(t *testing.T) {
+	tests := []struct {
+		name   string
+		text   string
+		target *models.Job
+		want   int
+		points map[models.CVCheck]int
+	}{
+		{
+			// 70 of the 80 points left once keyword coverage is skipped
+			name: "english without a target job",
+			text: englishCV,
+			want: 88,
+			points: map[models.CVCheck]int{
+				models.CVCheckSections:               20,
+				models.CVCheckContactInfo:            10,
+				models.CVCheckLength:                 0,
+				models.CVCheckQuantifiedAchievements: 15,
+				models.CVCheckActionVerbs:            15,
+				models.CVCheckDateConsistency:        10,
+			},
+		},
+		{
+			name:   "english against a job",
+			text:   englishCV,
+			target: &models.Job{ID: "job-1", Requirements: []string{"IFRS", "Excel", "Tax", "SAP"}},
+			want:   80,
+			points: map[models.CVCheck]int{
+				models.CVCheckKeywordCoverage: 10,
+			},
+		},
+		{
+			name:   "target job without keywords is skipped",
+			text:   englishCV,
+			target: &models.Job{ID: "job-2", Title: "Staff", Description: "Come work with us."},
+			want:   88,
+		},
+		{
+			// 60 of 80; the action verbs are found by their Amharic first person endings
+			name: "amharic",
+			text: amharicCV,
+			want: 75,
+			points: map[models.CVCheck]int{
+				models.CVCheckSections:               20,
+				models.CVCheckContactInfo:            10,
+				models.CVCheckLength:                 0,
+				models.CVCheckQuantifiedAchievements: 5,
+				models.CVCheckActionVerbs:            15,
+				models.CVCheckDateConsistency:        10,
+			},
+		},
+		{
+			// dates and keywords are skipped, leaving the email's 5 of 70 points
+			name: "no experience",
+			text: "Abebe Kebede\nabebe@example.com",
+			want: 7,
+		},
+	}
+	parser := NewCVParser()
+	scorer := NewCVScorer()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			score := scorer.Score(tt.text, parser.Parse(tt.text), tt.target)
+			if score.Score != tt.want {
+				t.Errorf("Score = %d, want %d; checks: %+v", score.Score, tt.want, score.Checks)
+			}
+			for _, c := range score.Checks {
+				if want, ok := tt.points[c.Check]; ok && c.Points != want {
+					t.Errorf("%s points = %d, want %d (%s)", c.Check, c.Points, want, c.Detail)
+				}
+			}
+			if tt.target != nil && score.TargetJobID != tt.target.ID {
+				t.Errorf("TargetJobID = %q, want %q", score.TargetJobID, tt.target.ID)
+			}
+		})
+	}
+}
+
+func TestCheckContactInfo(t *testing.T) {
+	tests := []struct {
+		text   string
+		points int
+	}{
+		{text: "abebe@example.com +251 911 234 567", points: 10},
+		{text: "abebe@example.com 0911 234 567", points: 10},
+		{text: "abebe@example.com 0911-23-45-67", points: 10},
+		{text: "abebe@example.com +251911234567", points: 10},
+		{text: "abebe@example.com 011 551 2345", points: 10},
+		{text: "abebe@example.com +44 20 7946 0958", points: 10},
+		{text: "abebe@example.com", points: 5},
+		{text: "Tel: 0911 234 567", points: 5},
+		{text: "Worked 2014 - 2018 at 40 branches", points: 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.text, func(t *testing.T) {
+			got := checkContactInfo(tt.text)
+			if got.Points != tt.points || got.Passed != (tt.points == contactPoints) {
+				t.Errorf("checkContactInfo() = %d points (passed %v), want %d", got.Points, got.Passed, tt.points)
+			}
+		})
+	}
+}
+
+func TestCheckLength(t *testing.T) {
+	tests := []struct {
+		words  int
+		points int
+	}{
+		{words: 119, points: 0},
+		{words: 120, points: 5},
+		{words: 199, points: 5},
+		{words: 200, points: 10},
+		{words: 1000, points: 10},
+		{words: 1001, points: 5},
+		{words: 1500, points: 5},
+		{words: 1501, points: 0},
+	}
+	for _, tt := range tests {
+		got := checkLength(strings.Repeat("word ", tt.words))
+		if got.Points != tt.points {
+			t.Errorf("checkLength(%d words) = %d points, want %d", tt.words, got.Points, tt.points)
+		}
+	}
+}
+
+func TestCheckQuantifiedAchievements(t *testing.T) {
+	tests := []struct {
+		name       string
+		experience string
+		points     int
+	}{
+		{
+			name:       "numbers and number words",
+			experience: "- Increased sales by 20%\n- Managed two branches\n- Served 2 ሚሊዮን ብር in loans",
+			points:     15,
+		},
+		{
+			name:       "dates, years and phone numbers do not count",
+			experience: "Accountant, Jan 2019 - Present\nWorked there since 2015\nReference: 0911 234 567\n- Reduced costs by 10%",
+			points:     5,
+		},
+		{
+			name:   "no experience",
+			points: 0,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			parsed := &models.ParsedCV{Sections: map[models.CVSection]string{models.CVSectionExperience: tt.experience}}
+			if got := checkQuantifiedAchievements(parsed); got.Points != tt.points {
+				t.Errorf("checkQuantifiedAchievements() = %d points, want %d (%s)", got.Points, tt.points, got.Detail)
+			}
+		})
+	}
+}
+
+func TestCheckActionVerbs(t *testing.T) {
+	tests := []struct {
+		name        string
+		description string
+		points      int
+	}{
+		{name: "english past tense", description: "Led the audit team\nPrepared budgets", points: 15},
+		{name: "half is enough", description: "Managed payroll\nResponsible for filing", points: 15},
+		{name: "a quarter gets half the points", description: "Managed payroll\nResponsible for filing\nIn charge of tax\nDuties included audits", points: 8},
+		{name: "amharic first person endings", description: "ሪፖርቶችን አዘጋጀሁ።\nቡድኑን መራሁ\nበሆስፒታሉ ሰርቻለሁ", points: 15},
+		{name: "no action verbs", description: "Responsible for filing", points: 0},
+		{name: "no descriptions", points: 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			parsed := &models.ParsedCV{Experience: []models.ExperienceEntry{{Title: "Accountant", Description: tt.description}}}
+			if got := checkActionVerbs(parsed); got.Points != tt.points {
+				t.Errorf("checkActionVerbs() = %d points, want %d (%s)", got.Points, tt.points, got.Detail)
+			}
+		})
+	}
+}
+
+func TestCheckDateConsistency(t *testing.T) {
+	now := time.Date(2024, time.June, 15, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name    string
+		entries []models.ExperienceEntry
+		points  int
+		skipped bool
+	}{
+		{
+			name: "complete and newest first",
+			entries: []models.ExperienceEntry{
+				{Start: models.YearMonth{Year: 2021, Month: 3}, Current: true},
+				{Start: models.YearMonth{Year: 2018, Month: 6}, End: models.YearMonth{Year: 2021, Month: 2}},
+			},
+			points: 10,
+		},
+		{
+			name:    "open ended",
+			entries: []models.ExperienceEntry{{Start: models.YearMonth{Year: 2021}}},
+			points:  5,
+		},
+		{
+			name:    "in the future",
+			entries: []models.ExperienceEntry{{Start: models.YearMonth{Year: 2022}, End: models.YearMonth{Year: 2024, Month: 9}}},
+			points:  5,
+		},
+		{
+			name: "oldest first",
+			entries: []models.ExperienceEntry{
+				{Start: models.YearMonth{Year: 2015}, End: models.YearMonth{Year: 2018}},
+				{Start: models.YearMonth{Year: 2019}, Current: true},
+			},
+			points: 5,
+		},
+		{
+			name: "every issue",
+			entries: []models.ExperienceEntry{
+				{Start: models.YearMonth{Year: 2015}},
+				{Start: models.YearMonth{Year: 2025}, Current: true},
+			},
+			points: 0,
+		},
+		{
+			name:    "no experience",
+			skipped: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := checkDateConsistency(tt.entries, now)
+			if got.Points != tt.points || got.Skipped != tt.skipped {
+				t.Errorf("checkDateConsistency() = %d points (skipped %v), want %d (skipped %v): %s",
+					got.Points, got.Skipped, tt.points, tt.skipped, got.Detail)
+			}
+		})
+	}
+}
+
+func TestCheckKeywordCoverage(t *testing.T) {
+	parsed := &models.ParsedCV{Skills: []string{"excel", "ifrs"}}
+	text := "Prepared IFRS statements in Excel and filed tax returns"
+	tests := []struct {
+		name    string
+		target  *models.Job
+		points  int
+		passed  bool
+		skipped bool
+	}{
+		{name: "listed skills and text mentions", target: &models.Job{Requirements: []string{"IFRS", "Excel", "Tax"}}, points: 20, passed: true},
+		{name: "partial coverage", target: &models.Job{Requirements: []string{"IFRS", "Excel", "SAP", "Audit", "Payroll"}}, points: 8},
+		{name: "no target", skipped: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := checkKeywordCoverage(text, parsed, tt.target)
+			if got.Points != tt.points || got.Passed != tt.passed || got.Skipped != tt.skipped {
+				t.Errorf("checkKeywordCoverage() = %+v, want %d points (passed %v, skipped %v)", got, tt.points, tt.passed, tt.skipped)
+			}
+		})
+	}
+}
@@ -0,0 +1,136 @@
+package cvparser
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/tsigemariamzewdu/JobMate-backend/domain/models"
+	"github.com/tsigemariamzewdu/JobMate-backend/infrastructure/skills"
+)
+
+// sectionHeadings lists the headings CVs use for each section, lowercased and with Ethiopic
+// spelling folded. Amharic CVs often mix in English headings, so both are always checked.
+var sectionHeadings = map[models.CVSection][]string{
+	models.CVSectionContact: {
+		"contact", "contacts", "contact information", "contact details", "personal information",
+		"personal details", "personal data", "personal profile",
+		"የግል መረጃ", "የግል ማህደር", "አድራሻ", "የመገኛ አድራሻ",
+	},
+	models.CVSectionSummary: {
+		"summary", "professional summary", "career summary", "profile", "professional profile",
+		"about me", "objective", "career objective", "career objectives", "objectives", "personal statement",
+		"ማጠቃለያ", "አጭር መግለጫ", "ስለ እኔ", "አላማ", "የስራ አላማ", "የሙያ አላማ",
+	},
+	models.CVSectionExperience: {
+		"experience", "experiences", "work experience", "working experience", "professional experience",
+		"employment history", "employment", "work history", "career history", "relevant experience",
+		"internship", "internships", "internship experience",
+		"የስራ ልምድ", "ልምድ", "የስራ ልምዶች", "የስራ ታሪክ", "የስራ ልምድና ታሪክ",
+	},
+	models.CVSectionEducation: {
+		"education", "educational background", "education background", "academic background",
+		"academic qualifications", "academic qualification", "educational qualifications",
+		"education and qualifications", "academic history",
+		"ትምህርት", "የትምህርት ዝግጅት", "የትምህርት ደረጃ", "የትምህርት ታሪክ", "የትምህርት መረጃ",
+	},
+	models.CVSectionSkills: {
+		"skills", "skill", "key skills", "technical skills", "core skills", "professional skills",
+		"core competencies", "competencies", "expertise", "areas of expertise", "skills and abilities",
+		"computer skills", "soft skills", "technical expertise",
+		"ክህሎቶች", "ክህሎት", "ችሎታዎች", "ችሎታ", "የሙያ ክህሎት", "የኮምፒውተር ክህሎት",
+	},
+	models.CVSectionLanguages: {
+		"languages", "language", "language skills", "language proficiency",
+		"ቋንቋዎች", "ቋንቋ", "የቋንቋ ችሎታ",
+	},
+	models.CVSectionCertifications: {
+		"certifications", "certificates", "certification", "training", "trainings", "courses",
+		"training and certifications", "certifications and trainings", "licenses and certifications",
+		"ስልጠናዎች", "ስልጠና", "የምስክር ወረቀቶች", "የተወሰዱ ስልጠናዎች",
+	},
+	models.CVSectionProjects: {
+		"projects", "project", "key projects", "personal projects", "academic projects",
+		"ፕሮጀክቶች", "የሰራኋቸው ፕሮጀክቶች",
+	},
+	models.CVSectionReferences: {
+		"references", "reference", "referees", "referee",
+		"ዋቢዎች", "ምስክሮች", "አጣቃሾች", "ዋቢ",
+	},
+	models.CVSectionOther: {
+		"awards", "achievements", "awards and achievements", "honors", "publications", "volunteering",
+		"volunteer experience", "hobbies", "interests", "hobbies and interests", "activities",
+		"ሽልማቶች", "የትርፍ ጊዜ ማሳለፊያ", "ፍላጎቶች",
+	},
+}
+
+// maxHeadingWords keeps sentences that merely start with "Experience ..." from being headings
+const maxHeadingWords = 5
+
+var (
+	headingIndex = func() map[string]models.CVSection {
+		index := make(map[string]models.CVSection)
+		for section, headings := range sectionHeadings {
+			for _, h := range headings {
+				index[h] = section
+			}
+		}
+		return index
+	}()
+
+	// bullets, numbering ("1.", "IV)") and rules drawn with symbols around a heading
+	headingDecoration = regexp.MustCompile(`^(?:[\p{P}\p{S}\s]+|(?:\d+|[ivx]+)[.)]\s*)+|[\p{P}\p{S}\s]+$`)
+)
+
+// sectionOf recognizes a heading line. Headings written inline ("Skills: Go, SQL") return
+// the text after the colon as rest.
+func sectionOf(line string) (section models.CVSection, rest string, ok bool) {
+	if section, ok := headingIndex[headingKey(line)]; ok {
+		return section, "", true
+	}
+	for _, sep := range []string{":", "፡", "፦"} {
+		head, tail, found := strings.Cut(line, sep)
+		if !found {
+			continue
+		}
+		if section, ok := headingIndex[headingKey(head)]; ok {
+			return section, strings.TrimSpace(tail), true
+		}
+	}
+	return "", "", false
+}
+
+func headingKey(line string) string {
+	key := skills.FoldEthiopic(strings.ToLower(strings.TrimSpace(line)))
+	key = headingDecoration.ReplaceAllString(key, "")
+	key = strings.ReplaceAll(key, "&", " and ")
+	words := strings.Fields(key)
+	if len(words) == 0 || len(words) > maxHeadingWords {
+		return ""
+	}
+	return strings.Join(words, " ")
+}
+
+// splitSections files each line under the most recent heading. Text before the first
+// heading is usually the name and contact details.
+func splitSections(text string) map[models.CVSection]string {
+	lines := make(map[models.CVSection][]string)
+	current := models.CVSectionContact
+	for _, line := range strings.Split(text, "\n") {
+		if section, rest, ok := sectionOf(line); ok {
+			current = section
+			if rest == "" {
+				continue
+			}
+			line = rest
+		}
+		lines[current] = append(lines[current], line)
+	}
+
+	sections := make(map[models.CVSection]string, len(lines))
+	for section, l := range lines {
+		if body := strings.TrimSpace(strings.Join(l, "\n")); body != "" {
+			sections[section] = body
+		}
+	}
+	return sections
+}
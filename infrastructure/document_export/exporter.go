@@ -0,0 +1,68 @@
+package docexport
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/fumiama/go-docx"
+	"github.com/tsigemariamzewdu/JobMate-backend/domain"
+	service "github.com/tsigemariamzewdu/JobMate-backend/domain/interfaces/services"
+	"github.com/tsigemariamzewdu/JobMate-backend/domain/models"
+)
+
+const (
+	mimeText = "text/plain; charset=utf-8"
+	mimeDOCX = "application/vnd.openxmlformats-officedocument.wordprocessingml.document"
+)
+
+// Nyala ships with Windows and covers Ethiopic script, which Calibri does not
+const (
+	latinFont    = "Calibri"
+	ethiopicFont = "Nyala"
+)
+
+var paragraphBreak = regexp.MustCompile(`\n\s*\n`)
+
+type DocumentExporter struct{}
+
+func NewDocumentExporter() service.DocumentExporter {
+	return &DocumentExporter{}
+}
+
+func (e *DocumentExporter) Export(format string, text string, language models.Language) ([]byte, string, error) {
+	text = strings.TrimSpace(strings.ReplaceAll(text, "\r\n", "\n"))
+	switch format {
+	case models.ExportFormatText:
+		return []byte(text + "\n"), mimeText, nil
+	case models.ExportFormatDocx:
+		document, err := toDocx(text, language)
+		if err != nil {
+			return nil, "", err
+		}
+		return document, mimeDOCX, nil
+	default:
+		return nil, "", domain.ErrUnsupportedExportFormat
+	}
+}
+
+// toDocx writes each paragraph of text as a Word paragraph, keeping single line breaks
+// such as those in an address block
+func toDocx(text string, language models.Language) ([]byte, error) {
+	font := latinFont
+	if language == models.LanguageAm {
+		font = ethiopicFont
+	}
+
+	doc := docx.New().WithDefaultTheme()
+	for _, paragraph := range paragraphBreak.Split(text, -1) {
+		doc.AddParagraph().AddText(strings.TrimSpace(paragraph)).Font(font, font, font, "")
+	}
+
+	var buf bytes.Buffer
+	if _, err := doc.WriteTo(&buf); err != nil {
+		return nil, fmt.Errorf("failed to write docx: %w", err)
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,185 @@
+package fileparser
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"unicode/utf16"
+)
+
+// A minimal reader for the OLE compound file format legacy Office documents are stored in.
+// It only reads streams by name, which is all the .doc extractor needs.
+
+var cfbSignature = []byte{0xD0, 0xCF, 0x11, 0xE0, 0xA1, 0xB1, 0x1A, 0xE1}
+
+const (
+	cfbEndOfChain = 0xFFFFFFFE
+	cfbMaxSector  = 0xFFFFFFFA // higher sector numbers are markers
+	cfbDirEntry   = 128
+	cfbStream     = 2
+	cfbRoot       = 5
+)
+
+var errNotCompoundFile = errors.New("not an OLE compound file")
+
+type compoundFile struct {
+	data       []byte
+	sectorSize int
+	miniSize   int
+	miniCutoff uint32
+	fat        []uint32
+	miniFat    []uint32
+	miniStream []byte
+	entries    map[string]cfbEntry
+}
+
+type cfbEntry struct {
+	start uint32
+	size  uint32
+}
+
+func openCompoundFile(data []byte) (*compoundFile, error) {
+	if len(data) < 512 || !bytes.Equal(data[:8], cfbSignature) {
+		return nil, errNotCompoundFile
+	}
+	le := binary.LittleEndian
+	sectorShift := le.Uint16(data[0x1E:])
+	miniShift := le.Uint16(data[0x20:])
+	if sectorShift != 9 && sectorShift != 12 || miniShift != 6 {
+		return nil, fmt.Errorf("unsupported compound file sector sizes")
+	}
+	cf := &compoundFile{
+		data:       data,
+		sectorSize: 1 << sectorShift,
+		miniSize:   1 << miniShift,
+		miniCutoff: le.Uint32(data[0x38:]),
+	}
+
+	// the FAT sectors are listed in the header and then in a chain of DIFAT sectors
+	numFat := le.Uint32(data[0x2C:])
+	var fatSectors []uint32
+	for i := 0; i < 109 && uint32(len(fatSectors)) < numFat; i++ {
+		fatSectors = append(fatSectors, le.Uint32(data[0x4C+4*i:]))
+	}
+	perSector := cf.sectorSize / 4
+	for difat, n := le.Uint32(data[0x44:]), 0; difat < cfbMaxSector && uint32(len(fatSectors)) < numFat; n++ {
+		sector, err := cf.sector(difat)
+		if err != nil || len(sector) < cf.sectorSize || n > len(data)/cf.sectorSize {
+			return nil, fmt.Errorf("corrupt compound file DIFAT")
+		}
+		for i := 0; i < perSector-1 && uint32(len(fatSectors)) < numFat; i++ {
+			fatSectors = append(fatSectors, le.Uint32(sector[4*i:]))
+		}
+		difat = le.Uint32(sector[4*(perSector-1):])
+	}
+	for _, s := range fatSectors {
+		sector, err := cf.sector(s)
+		if err != nil {
+			return nil, err
+		}
+		if len(sector) < cf.sectorSize {
+			return nil, fmt.Errorf("corrupt compound file FAT")
+		}
+		for i := 0; i < perSector; i++ {
+			cf.fat = append(cf.fat, le.Uint32(sector[4*i:]))
+		}
+	}
+
+	dir, err := cf.chain(le.Uint32(data[0x30:]), -1)
+	if err != nil {
+		return nil, fmt.Errorf("corrupt compound file directory: %w", err)
+	}
+	cf.entries = make(map[string]cfbEntry)
+	var root cfbEntry
+	for off := 0; off+cfbDirEntry <= len(dir); off += cfbDirEntry {
+		e := dir[off : off+cfbDirEntry]
+		nameLen := int(le.Uint16(e[64:]))
+		if nameLen < 2 || nameLen > 64 {
+			continue
+		}
+		units := make([]uint16, nameLen/2-1) // drop the terminating NUL
+		for i := range units {
+			units[i] = le.Uint16(e[2*i:])
+		}
+		entry := cfbEntry{start: le.Uint32(e[116:]), size: le.Uint32(e[120:])}
+		switch e[66] {
+		case cfbRoot:
+			root = entry
+		case cfbStream:
+			cf.entries[string(utf16.Decode(units))] = entry
+		}
+	}
+
+	// small streams live in the mini stream, which is the root entry's content
+	if root.size > 0 {
+		if cf.miniStream, err = cf.chain(root.start, int(root.size)); err != nil {
+			return nil, fmt.Errorf("corrupt compound file mini stream: %w", err)
+		}
+		miniFat, err := cf.chain(le.Uint32(data[0x3C:]), -1)
+		if err != nil {
+			return nil, fmt.Errorf("corrupt compound file mini FAT: %w", err)
+		}
+		for i := 0; i+4 <= len(miniFat); i += 4 {
+			cf.miniFat = append(cf.miniFat, le.Uint32(miniFat[i:]))
+		}
+	}
+	return cf, nil
+}
+
+// stream returns the content of the named stream, or nil if there is none
+func (cf *compoundFile) stream(name string) ([]byte, error) {
+	entry, ok := cf.entries[name]
+	if !ok {
+		return nil, nil
+	}
+	if entry.size >= cf.miniCutoff {
+		return cf.chain(entry.start, int(entry.size))
+	}
+
+	out := make([]byte, 0, entry.size)
+	for s, n := entry.start, 0; s < cfbMaxSector && len(out) < int(entry.size); n++ {
+		off := int(s) * cf.miniSize
+		if int(s) >= len(cf.miniFat) || off+cf.miniSize > len(cf.miniStream) || n > len(cf.miniFat) {
+			return nil, fmt.Errorf("corrupt mini stream chain for %s", name)
+		}
+		out = append(out, cf.miniStream[off:off+cf.miniSize]...)
+		s = cf.miniFat[s]
+	}
+	if len(out) < int(entry.size) {
+		return nil, fmt.Errorf("stream %s is truncated", name)
+	}
+	return out[:entry.size], nil
+}
+
+// chain concatenates the sectors of a FAT chain, cut to size unless size is negative
+func (cf *compoundFile) chain(start uint32, size int) ([]byte, error) {
+	var out []byte
+	for s, n := start, 0; s < cfbMaxSector; n++ {
+		if int(s) >= len(cf.fat) || n > len(cf.fat) {
+			return nil, fmt.Errorf("invalid sector chain")
+		}
+		sector, err := cf.sector(s)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, sector...)
+		if size >= 0 && len(out) >= size {
+			return out[:size], nil
+		}
+		s = cf.fat[s]
+	}
+	if size >= 0 && len(out) < size {
+		return nil, fmt.Errorf("sector chain is truncated")
+	}
+	return out, nil
+}
+
+func (cf *compoundFile) sector(n uint32) ([]byte, error) {
+	off := (int(n) + 1) * cf.sectorSize
+	if n >= cfbMaxSector || off >= len(cf.data) {
+		return nil, fmt.Errorf("sector %d out of range", n)
+	}
+	// some writers leave the last sector short
+	return cf.data[off:min(off+cf.sectorSize, len(cf.data))], nil
+}
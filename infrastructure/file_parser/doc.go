@@ -0,0 +1,215 @@
+package fileparser
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"strings"
+	"unicode/utf16"
+
+	"golang.org/x/text/encoding/charmap"
+)
+
+// Text extraction from legacy Word 97-2003 (.doc) files. The text is assembled from the piece
+// table, which maps runs of document characters to where they are stored in the WordDocument
+// stream, either as UTF-16 or as single-byte Windows-1252.
+
+const (
+	wordIdent        = 0xA5EC
+	fibFlagsOffset   = 0x0A
+	fibWhichTable    = 0x0200
+	fibEncrypted     = 0x0100
+	fibRgLwCcpText   = 3  // index of ccpText in FibRgLw97
+	fibRgFcLcbClx    = 33 // index of the fcClx/lcbClx pair in FibRgFcLcb97
+	clxPrc           = 0x01
+	clxPcdt          = 0x02
+	pieceDescSize    = 8
+	fcCompressedFlag = 0x40000000
+)
+
+var errEncryptedDoc = errors.New("password protected .doc files are not supported")
+
+func extractDocText(data []byte) (string, error) {
+	cf, err := openCompoundFile(data)
+	if err != nil {
+		return "", err
+	}
+	word, err := cf.stream("WordDocument")
+	if err != nil {
+		return "", err
+	}
+	if len(word) < 34 || binary.LittleEndian.Uint16(word) != wordIdent {
+		return "", fmt.Errorf("not a Word document")
+	}
+
+	le := binary.LittleEndian
+	flags := le.Uint16(word[fibFlagsOffset:])
+	if flags&fibEncrypted != 0 {
+		return "", errEncryptedDoc
+	}
+	tableName := "0Table"
+	if flags&fibWhichTable != 0 {
+		tableName = "1Table"
+	}
+	table, err := cf.stream(tableName)
+	if err != nil {
+		return "", err
+	}
+	if table == nil {
+		return "", fmt.Errorf("Word document has no %s stream", tableName)
+	}
+
+	// the FIB is a fixed base followed by three length-prefixed arrays
+	off := 32
+	csw := int(le.Uint16(word[off:]))
+	off += 2 + 2*csw
+	if off+2 > len(word) {
+		return "", fmt.Errorf("truncated Word file information block")
+	}
+	cslw := int(le.Uint16(word[off:]))
+	rgLw := off + 2
+	off = rgLw + 4*cslw
+	if cslw <= fibRgLwCcpText || off+2 > len(word) {
+		return "", fmt.Errorf("truncated Word file information block")
+	}
+	ccpText := int(le.Uint32(word[rgLw+4*fibRgLwCcpText:]))
+	cbRgFcLcb := int(le.Uint16(word[off:]))
+	rgFcLcb := off + 2
+	if cbRgFcLcb <= fibRgFcLcbClx || rgFcLcb+8*(fibRgFcLcbClx+1) > len(word) {
+		return "", fmt.Errorf("truncated Word file information block")
+	}
+	fcClx := int(le.Uint32(word[rgFcLcb+8*fibRgFcLcbClx:]))
+	lcbClx := int(le.Uint32(word[rgFcLcb+8*fibRgFcLcbClx+4:]))
+	if fcClx < 0 || lcbClx <= 0 || fcClx+lcbClx > len(table) {
+		return "", fmt.Errorf("Word piece table out of range")
+	}
+
+	pieces, err := pieceTable(table[fcClx : fcClx+lcbClx])
+	if err != nil {
+		return "", err
+	}
+
+	var text strings.Builder
+	cp := 0
+	for _, p := range pieces {
+		if cp >= ccpText {
+			break
+		}
+		n := min(p.cpEnd-p.cpStart, ccpText-cp)
+		chunk, err := p.decode(word, n)
+		if err != nil {
+			return "", err
+		}
+		text.WriteString(chunk)
+		cp += n
+	}
+	return cleanWordText(text.String()), nil
+}
+
+type piece struct {
+	cpStart, cpEnd int
+	fc             uint32
+	compressed     bool
+}
+
+// pieceTable reads the PlcPcd out of a Clx, skipping any property modifiers before it
+func pieceTable(clx []byte) ([]piece, error) {
+	le := binary.LittleEndian
+	for i := 0; i < len(clx); {
+		switch clx[i] {
+		case clxPrc:
+			if i+3 > len(clx) {
+				return nil, fmt.Errorf("truncated Word piece table")
+			}
+			i += 3 + int(le.Uint16(clx[i+1:]))
+		case clxPcdt:
+			if i+5 > len(clx) {
+				return nil, fmt.Errorf("truncated Word piece table")
+			}
+			lcb := int(le.Uint32(clx[i+1:]))
+			plc := clx[i+5:]
+			if lcb < 4 || lcb > len(plc) || (lcb-4)%(4+pieceDescSize) != 0 {
+				return nil, fmt.Errorf("invalid Word piece table")
+			}
+			n := (lcb - 4) / (4 + pieceDescSize)
+			descs := plc[4*(n+1):]
+			pieces := make([]piece, 0, n)
+			for k := 0; k < n; k++ {
+				fc := le.Uint32(descs[k*pieceDescSize+2:])
+				pieces = append(pieces, piece{
+					cpStart:    int(le.Uint32(plc[4*k:])),
+					cpEnd:      int(le.Uint32(plc[4*(k+1):])),
+					fc:         fc &^ fcCompressedFlag,
+					compressed: fc&fcCompressedFlag != 0,
+				})
+			}
+			return pieces, nil
+		default:
+			return nil, fmt.Errorf("invalid Word piece table")
+		}
+	}
+	return nil, fmt.Errorf("Word document has no piece table")
+}
+
+// decode reads n characters of the piece from the WordDocument stream
+func (p piece) decode(word []byte, n int) (string, error) {
+	if n <= 0 {
+		return "", nil
+	}
+	if p.compressed {
+		start := int(p.fc / 2)
+		if start+n > len(word) {
+			return "", fmt.Errorf("Word text piece out of range")
+		}
+		return charmap.Windows1252.NewDecoder().String(string(word[start : start+n]))
+	}
+
+	start := int(p.fc)
+	if start+2*n > len(word) {
+		return "", fmt.Errorf("Word text piece out of range")
+	}
+	units := make([]uint16, n)
+	for i := range units {
+		units[i] = binary.LittleEndian.Uint16(word[start+2*i:])
+	}
+	return string(utf16.Decode(units)), nil
+}
+
+// cleanWordText replaces Word's control characters and drops field codes, keeping field results
+func cleanWordText(s string) string {
+	var out strings.Builder
+	// fields nest; for each open field, whether its code (rather than its result) is being read
+	var inCode []bool
+	for _, r := range s {
+		switch r {
+		case 0x13: // field begin
+			inCode = append(inCode, true)
+			continue
+		case 0x14: // field separator, the result follows
+			if len(inCode) > 0 {
+				inCode[len(inCode)-1] = false
+			}
+			continue
+		case 0x15: // field end
+			if len(inCode) > 0 {
+				inCode = inCode[:len(inCode)-1]
+			}
+			continue
+		}
+		if len(inCode) > 0 && inCode[len(inCode)-1] {
+			continue
+		}
+		switch r {
+		case '\r', 0x0B, 0x0C: // paragraph, line and page breaks
+			out.WriteByte('\n')
+		case 0x07: // end of table cell
+			out.WriteByte('\t')
+		case 0x1E: // non-breaking hyphen
+			out.WriteByte('-')
+		case 0x01, 0x08, 0x1F: // embedded objects, drawings and optional hyphens
+		default:
+			out.WriteRune(r)
+		}
+	}
+	return out.String()
+}
@@ -1,55 +1,162 @@
 package fileparser
 
 import (
+	"bytes"
+	"context"
 	"fmt"
 	"io"
-	"log"
 	"mime/multipart"
-	"os"
-	"path/filepath"
+	"regexp"
 	"strings"
+	"unicode"
 
 	"github.com/fumiama/go-docx"
+	"github.com/gabriel-vasile/mimetype"
 	"github.com/ledongthuc/pdf"
+	"github.com/lu4p/cat/odtxt"
+	"github.com/lu4p/cat/rtftxt"
+	"github.com/tsigemariamzewdu/JobMate-backend/domain"
 	service "github.com/tsigemariamzewdu/JobMate-backend/domain/interfaces/services"
+	textunicode "golang.org/x/text/encoding/unicode"
 )
 
-type FileTextExtractor struct{}
+const (
+	mimePDF  = "application/pdf"
+	mimeDOCX = "application/vnd.openxmlformats-officedocument.wordprocessingml.document"
+	mimeDOC  = "application/msword"
+	mimeOLE  = "application/x-ole-storage" // .doc files saved without the Word class ID
+	mimeODT  = "application/vnd.oasis.opendocument.text"
+	mimeRTF  = "text/rtf"
+	mimeHTML = "text/html"
+	mimeText = "text/plain"
+	mimeJPEG = "image/jpeg"
+	mimePNG  = "image/png"
+)
+
+const (
+	// a PDF with fewer letters than this per page is taken to be a scan
+	minPDFLettersPerPage = 40
+	// extracted text shorter than this is not worth analyzing
+	minTextLetters = 20
+)
 
-func NewFileTextExtractor() service.TextExtractor {
-	return &FileTextExtractor{}
+// extractors maps sniffed content types to the function reading their text. Types not listed
+// fall back to their parent type, so e.g. text/csv is read as plain text.
+var extractors = map[string]func([]byte) (string, error){
+	mimePDF:  extractPDFText,
+	mimeDOCX: extractDocxText,
+	mimeDOC:  extractDocText,
+	mimeOLE:  extractDocText,
+	mimeODT:  odtxt.BytesToStr,
+	mimeRTF:  rtftxt.BytesToStr,
+	mimeHTML: extractHTMLText,
+	mimeText: extractPlainText,
 }
 
-func (e *FileTextExtractor) Extract(fileHeader *multipart.FileHeader) (string, error) {
-	ext := filepath.Ext(fileHeader.Filename)
-	switch ext {
-	case ".pdf":
-		return extractPDFText(fileHeader)
-	case ".docx":
-		return extractDocxText(fileHeader)
-	default:
-		return "", fmt.Errorf("unsupported file type: %s", ext)
-	}
+// imageTypes can only be read with OCR
+var imageTypes = map[string]bool{mimeJPEG: true, mimePNG: true}
+
+type FileTextExtractor struct {
+	ocr service.OCREngine // nil rejects scans and photos
+}
+
+func NewFileTextExtractor(ocr service.OCREngine) service.TextExtractor {
+	return &FileTextExtractor{ocr: ocr}
 }
 
-func extractPDFText(fileHeader *multipart.FileHeader) (string, error) {
+// Extract reads the upload and picks an extractor from its content, not its file name.
+// Photos, and PDFs with little or no text layer, are read with OCR.
+func (e *FileTextExtractor) Extract(ctx context.Context, fileHeader *multipart.FileHeader) (string, string, error) {
 	file, err := fileHeader.Open()
 	if err != nil {
-		return "", err
+		return "", "", err
 	}
 	defer file.Close()
 
-	tmpPath, err := saveTempFile(file, "upload-*.pdf")
+	data, err := io.ReadAll(file)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to read file: %w", err)
+	}
+
+	contentType := detectContentType(data)
+	var text string
+	switch {
+	case contentType == "":
+		return "", "", domain.ErrUnsupportedFileType
+	case imageTypes[contentType]:
+		text, err = e.recognize(ctx, data, contentType)
+	default:
+		text, err = extractors[contentType](data)
+		if err != nil {
+			return "", contentType, fmt.Errorf("failed to read %s: %w", contentType, err)
+		}
+		if contentType == mimePDF && isScannedPDF(data, text) {
+			text, err = e.recognize(ctx, data, contentType)
+		}
+	}
+	if err != nil {
+		return "", contentType, err
+	}
+
+	text = normalizeText(text)
+	if countLetters(text) < minTextLetters {
+		return "", contentType, domain.ErrNoTextFound
+	}
+	return text, contentType, nil
+}
+
+// detectContentType sniffs the data, falling back to parent types until one can be read.
+// An empty result means the format is not supported.
+func detectContentType(data []byte) string {
+	for m := mimetype.Detect(data); m != nil; m = m.Parent() {
+		contentType, _, _ := strings.Cut(m.String(), ";")
+		if _, ok := extractors[contentType]; ok || imageTypes[contentType] {
+			return contentType
+		}
+	}
+	return ""
+}
+
+func (e *FileTextExtractor) recognize(ctx context.Context, data []byte, contentType string) (string, error) {
+	if e.ocr == nil {
+		return "", domain.ErrOCRUnavailable
+	}
+	text, err := e.ocr.Recognize(ctx, data, contentType)
+	if err != nil {
+		return "", fmt.Errorf("text recognition failed: %w", err)
+	}
+	return text, nil
+}
+
+// isScannedPDF reports whether the text layer is too thin to be the document itself. Scanner
+// apps often stamp a line of text on each page, so an empty layer is not required.
+func isScannedPDF(data []byte, text string) bool {
+	r, err := pdf.NewReader(bytes.NewReader(data), int64(len(data)))
 	if err != nil {
-		return "", err
+		return false
 	}
-	defer os.Remove(tmpPath)
+	pages := r.NumPage()
+	if pages < 1 {
+		pages = 1
+	}
+	return countLetters(text) < minPDFLettersPerPage*pages
+}
 
-	f, r, err := pdf.Open(tmpPath)
+func countLetters(text string) int {
+	n := 0
+	for _, r := range text {
+		if unicode.IsLetter(r) {
+			n++
+		}
+	}
+	return n
+}
+
+func extractPDFText(data []byte) (string, error) {
+	r, err := pdf.NewReader(bytes.NewReader(data), int64(len(data)))
 	if err != nil {
 		return "", fmt.Errorf("failed to open PDF: %v", err)
 	}
-	defer f.Close()
 
 	var textBuilder strings.Builder
 	totalPage := r.NumPage()
@@ -86,60 +193,62 @@ func extractPDFText(fileHeader *multipart.FileHeader) (string, error) {
 	return textBuilder.String(), nil
 }
 
-func extractDocxText(fileHeader *multipart.FileHeader) (string, error) {
-	file, err := fileHeader.Open()
-	if err != nil {
-		return "", err
-	}
-	defer file.Close()
-
-	tmpPath, err := saveTempFile(file, "upload-*.docx")
-	if err != nil {
-		return "", err
-	}
-	defer os.Remove(tmpPath)
-
-	f, err := os.Open(tmpPath)
-	if err != nil {
-		return "", fmt.Errorf("failed to open temp docx: %w", err)
-	}
-	defer f.Close()
-
-	info, err := f.Stat()
-	if err != nil {
-		return "", fmt.Errorf("failed to stat docx: %w", err)
-	}
-
-	doc, err := docx.Parse(f, info.Size())
+func extractDocxText(data []byte) (string, error) {
+	doc, err := docx.Parse(bytes.NewReader(data), int64(len(data)))
 	if err != nil {
 		return "", fmt.Errorf("failed to parse docx: %w", err)
 	}
 
-	var text string
+	var text strings.Builder
 	for _, it := range doc.Document.Body.Items {
 		switch v := it.(type) {
 		case *docx.Paragraph:
-			text += v.String() + "\n"
+			text.WriteString(v.String() + "\n")
 		case *docx.Table:
-
-			text += v.String() + "\n"
+			text.WriteString(v.String() + "\n")
 		}
 	}
-
-	log.Print(text)
-	return text, nil
+	return text.String(), nil
 }
 
-func saveTempFile(file multipart.File, pattern string) (string, error) {
-	tmp, err := os.CreateTemp("", pattern)
-	if err != nil {
-		return "", err
+// extractPlainText decodes UTF-8, or UTF-16 when the file starts with a byte order mark
+func extractPlainText(data []byte) (string, error) {
+	if bytes.HasPrefix(data, []byte{0xFF, 0xFE}) || bytes.HasPrefix(data, []byte{0xFE, 0xFF}) {
+		decoded, err := textunicode.UTF16(textunicode.LittleEndian, textunicode.ExpectBOM).NewDecoder().Bytes(data)
+		if err != nil {
+			return "", fmt.Errorf("failed to decode UTF-16 text: %w", err)
+		}
+		data = decoded
 	}
-	defer tmp.Close()
+	data = bytes.TrimPrefix(data, []byte("\xEF\xBB\xBF"))
+	return string(data), nil
+}
 
-	_, err = io.Copy(tmp, file)
-	if err != nil {
-		return "", err
+var (
+	inlineSpace = regexp.MustCompile(`[ \t\f\v\p{Zs}]+`)
+	blankLines  = regexp.MustCompile(`\n{3,}`)
+)
+
+// normalizeText gives every format the same whitespace: Unix line ends, single spaces
+// within lines, no control characters, and at most one blank line in a row
+func normalizeText(text string) string {
+	text = strings.ToValidUTF8(text, "")
+	text = strings.NewReplacer("\r\n", "\n", "\r", "\n", "\u2028", "\n", "\u2029", "\n\n").Replace(text)
+	text = strings.Map(func(r rune) rune {
+		switch {
+		case r == '\n' || r == '\t':
+			return r
+		case r == '\uFEFF' || r == '\u00AD': // byte order marks and soft hyphens
+			return -1
+		case unicode.IsControl(r):
+			return ' '
+		}
+		return r
+	}, text)
+
+	lines := strings.Split(text, "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimSpace(inlineSpace.ReplaceAllString(line, " "))
 	}
-	return tmp.Name(), nil
+	return strings.TrimSpace(blankLines.ReplaceAllString(strings.Join(lines, "\n"), "\n\n"))
 }
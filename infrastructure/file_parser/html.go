@@ -0,0 +1,75 @@
+package fileparser
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"strings"
+
+	"golang.org/x/net/html"
+)
+
+// blockTags start a new line in the extracted text
+var blockTags = map[string]bool{
+	"address": true, "article": true, "aside": true, "blockquote": true, "br": true, "dd": true,
+	"div": true, "dl": true, "dt": true, "footer": true, "h1": true, "h2": true, "h3": true,
+	"h4": true, "h5": true, "h6": true, "header": true, "hr": true, "main": true,
+	"nav": true, "ol": true, "p": true, "pre": true, "section": true, "table": true, "tr": true,
+	"ul": true,
+}
+
+// skippedTags hold no readable content
+var skippedTags = map[string]bool{
+	"head": true, "script": true, "style": true, "noscript": true, "template": true, "svg": true,
+}
+
+// extractHTMLText keeps the visible text of a page, with block elements on their own lines
+// and list items marked with a dash
+func extractHTMLText(data []byte) (string, error) {
+	z := html.NewTokenizer(bytes.NewReader(data))
+	var text strings.Builder
+	skipping := 0
+	for {
+		switch tt := z.Next(); tt {
+		case html.ErrorToken:
+			if errors.Is(z.Err(), io.EOF) {
+				return text.String(), nil
+			}
+			return "", z.Err()
+		case html.TextToken:
+			if skipping == 0 {
+				text.Write(z.Text())
+			}
+		case html.StartTagToken, html.SelfClosingTagToken:
+			name, _ := z.TagName()
+			tag := string(name)
+			if skippedTags[tag] {
+				// a self-closing <script/> or <svg/> has no end tag to stop skipping at
+				if tt == html.StartTagToken {
+					skipping++
+				}
+				continue
+			}
+			switch {
+			case tag == "li":
+				text.WriteString("\n- ")
+			case tag == "td" || tag == "th":
+				text.WriteString("\t")
+			case blockTags[tag]:
+				text.WriteString("\n")
+			}
+		case html.EndTagToken:
+			name, _ := z.TagName()
+			tag := string(name)
+			if skippedTags[tag] {
+				if skipping > 0 {
+					skipping--
+				}
+				continue
+			}
+			if blockTags[tag] {
+				text.WriteString("\n")
+			}
+		}
+	}
+}
@@ -0,0 +1,144 @@
+package job_service
+
+import (
+	"context"
+	"log"
+	"strings"
+	"time"
+
+	repo "github.com/tsigemariamzewdu/JobMate-backend/domain/interfaces/repositories"
+	svc "github.com/tsigemariamzewdu/JobMate-backend/domain/interfaces/services"
+	"github.com/tsigemariamzewdu/JobMate-backend/domain/models"
+)
+
+const (
+	defaultIngestionInterval = time.Hour
+	defaultJobTTL            = 7 * 24 * time.Hour
+)
+
+// jobTypes are the job types every ingestion run pulls
+var jobTypes = []string{"local", "remote", "freelance"}
+
+// IngestionWorker periodically pulls every enabled source into the job catalog
+type IngestionWorker struct {
+	Registry  *SourceRegistry
+	Catalog   repo.IJobCatalogRepository
+	Extractor svc.IJobRequirementExtractor
+	Interval  time.Duration
+	TTL       time.Duration
+	Fields    []string // search terms to ingest; an empty term pulls each source's default listing
+}
+
+func NewIngestionWorker(registry *SourceRegistry, catalog repo.IJobCatalogRepository, extractor svc.IJobRequirementExtractor, interval, ttl time.Duration, fields []string) *IngestionWorker {
+	if interval <= 0 {
+		interval = defaultIngestionInterval
+	}
+	if ttl <= 0 {
+		ttl = defaultJobTTL
+	}
+	if len(fields) == 0 {
+		fields = []string{""}
+	}
+	return &IngestionWorker{
+		Registry:  registry,
+		Catalog:   catalog,
+		Extractor: extractor,
+		Interval:  interval,
+		TTL:       ttl,
+		Fields:    fields,
+	}
+}
+
+// Start runs an ingestion immediately and then on every interval until ctx is cancelled
+func (w *IngestionWorker) Start(ctx context.Context) {
+	go func() {
+		ticker := time.NewTicker(w.Interval)
+		defer ticker.Stop()
+
+		for {
+			w.RunOnce(ctx)
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+// RunOnce ingests every (job type, field) combination and then drops expired postings
+func (w *IngestionWorker) RunOnce(ctx context.Context) {
+	for _, lookingFor := range jobTypes {
+		for _, field := range w.Fields {
+			if ctx.Err() != nil {
+				return
+			}
+			req := models.JobSuggestionRequest{LookingFor: lookingFor, Field: strings.TrimSpace(field)}
+			jobs, failures := w.Registry.FetchAll(ctx, req)
+			for _, f := range failures {
+				log.Printf("job ingestion: source %s failed for %s/%q: %s", f.Source, lookingFor, field, f.Error)
+			}
+
+			prepareJobs(ctx, jobs, lookingFor, w.Catalog, w.Extractor)
+			if _, err := w.Catalog.UpsertMany(ctx, jobs, w.TTL); err != nil {
+				log.Printf("job ingestion: failed to store %d jobs for %s/%q: %v", len(jobs), lookingFor, field, err)
+			}
+		}
+	}
+
+	if removed, err := w.Catalog.DeleteExpired(ctx); err != nil {
+		log.Printf("job ingestion: failed to delete expired jobs: %v", err)
+	} else if removed > 0 {
+		log.Printf("job ingestion: removed %d expired jobs", removed)
+	}
+}
+
+// normalizeJob cleans up source output before it is stored
+func normalizeJob(job *models.Job, lookingFor string) {
+	job.Title = strings.Join(strings.Fields(job.Title), " ")
+	job.Company = strings.Join(strings.Fields(job.Company), " ")
+	job.Location = strings.TrimSpace(job.Location)
+	job.Link = strings.TrimSpace(job.Link)
+	if job.Type == "" {
+		job.Type = lookingFor
+	}
+	if job.Language == "" {
+		job.Language = "en"
+	}
+	if job.Requirements == nil {
+		job.Requirements = []string{}
+	}
+}
+
+// prepareJobs normalizes jobs and extracts the requirements of those the catalog has not
+// seen yet; known jobs keep what was extracted when they were first stored
+func prepareJobs(ctx context.Context, jobs []models.Job, lookingFor string, catalog repo.IJobCatalogRepository, extractor svc.IJobRequirementExtractor) {
+	for i := range jobs {
+		normalizeJob(&jobs[i], lookingFor)
+	}
+	if extractor == nil {
+		return
+	}
+
+	known, err := catalog.LoadExtracted(ctx, jobs)
+	if err != nil {
+		log.Printf("job ingestion: failed to load stored requirements: %v", err)
+		known = make([]bool, len(jobs))
+	}
+	for i := range jobs {
+		if known[i] {
+			continue
+		}
+		if err := extractor.Extract(ctx, &jobs[i]); err != nil {
+			log.Printf("job ingestion: %v", err)
+		}
+	}
+}
+
+// catalogTypesFor maps a requested job type to the catalog types that satisfy it
+func catalogTypesFor(lookingFor string) []string {
+	if lookingFor == "remote" {
+		return []string{"remote", "freelance"}
+	}
+	return []string{lookingFor}
+}
@@ -0,0 +1,207 @@
+package job_service
+
+import (
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	svc "github.com/tsigemariamzewdu/JobMate-backend/domain/interfaces/services"
+	"github.com/tsigemariamzewdu/JobMate-backend/domain/models"
+	"github.com/tsigemariamzewdu/JobMate-backend/infrastructure/skills"
+)
+
+// score weights, summing to 1
+const (
+	skillWeight      = 0.6
+	experienceWeight = 0.25
+	locationWeight   = 0.15
+)
+
+// seniority levels shared by candidates and jobs
+const (
+	levelUnknown = -1
+	levelEntry   = 0
+	levelJunior  = 1
+	levelMid     = 2
+	levelSenior  = 3
+)
+
+var yearsPattern = regexp.MustCompile(`(\d+)\s*\+?\s*(?:years?|yrs?)`)
+
+// level keywords match whole words, so "internal" is not an intern and "middleware" not mid level
+var (
+	seniorKeywords = regexp.MustCompile(`\b(?:senior|sr|lead|principal|head of|manager|director)\b`)
+	midKeywords    = regexp.MustCompile(`\b(?:mid|intermediate)\b`)
+	juniorKeywords = regexp.MustCompile(`\b(?:junior|jr|associate)\b`)
+	entryKeywords  = regexp.MustCompile(`\b(?:interns?|internship|graduates?|entry|trainees?|fresh|freshers?)\b`)
+)
+
+// JobRanker is a rule-based IJobRanker
+type JobRanker struct{}
+
+var _ svc.IJobRanker = (*JobRanker)(nil)
+
+func NewJobRanker() *JobRanker {
+	return &JobRanker{}
+}
+
+// Rank scores every job and sorts by score, keeping upstream order between ties
+func (r *JobRanker) Rank(jobs []models.Job, profile models.CandidateProfile) []models.RankedJob {
+	candidateSkills := skills.Dedupe(profile.Skills)
+	candidateLevel := candidateLevel(profile.Experience)
+
+	ranked := make([]models.RankedJob, 0, len(jobs))
+	for _, job := range jobs {
+		skillScore, matched, missing := scoreSkills(job, candidateSkills)
+		expScore := scoreExperience(candidateLevel, jobLevel(job))
+		locScore := scoreLocation(profile.Location, job)
+
+		total := skillWeight*skillScore + experienceWeight*expScore + locationWeight*locScore
+		ranked = append(ranked, models.RankedJob{
+			Job:           job,
+			MatchScore:    int(total*100 + 0.5),
+			MatchedSkills: matched,
+			MissingSkills: missing,
+		})
+	}
+
+	sort.SliceStable(ranked, func(i, j int) bool {
+		return ranked[i].MatchScore > ranked[j].MatchScore
+	})
+	return ranked
+}
+
+// scoreSkills compares the candidate to the job requirements. Jobs without extracted
+// requirements fall back to checking which candidate skills the title mentions.
+func scoreSkills(job models.Job, candidate []string) (float64, []string, []string) {
+	if len(job.Requirements) > 0 {
+		matched, missing := skills.Match(candidate, job.Requirements)
+		return float64(len(matched)) / float64(len(job.Requirements)), matched, missing
+	}
+
+	var matched []string
+	for _, s := range candidate {
+		if skills.Mentions(job.Title, s) {
+			matched = append(matched, s)
+		}
+	}
+	if len(matched) > 0 {
+		return 1, matched, nil
+	}
+	return 0.5, nil, nil
+}
+
+func scoreExperience(candidate, job int) float64 {
+	if candidate == levelUnknown || job == levelUnknown {
+		return 0.5
+	}
+	diff := candidate - job
+	if diff >= 0 {
+		// over-qualified candidates are still a reasonable fit
+		return 1 - 0.15*float64(diff)
+	}
+	return 1 - 0.4*float64(-diff)
+}
+
+func scoreLocation(preferred string, job models.Job) float64 {
+	preferred = strings.ToLower(strings.TrimSpace(preferred))
+	location := strings.ToLower(job.Location)
+	switch {
+	case preferred == "":
+		return 0.5
+	case strings.Contains(location, preferred):
+		return 1
+	case job.Type == "remote" || job.Type == "freelance" || strings.Contains(location, "remote"):
+		return 0.75
+	default:
+		return 0
+	}
+}
+
+// candidateLevel reads a level from text like "3 years", "junior" or "entry level"
+func candidateLevel(experience string) int {
+	exp := strings.ToLower(experience)
+	if m := yearsPattern.FindStringSubmatch(exp); m != nil {
+		years, _ := strconv.Atoi(m[1])
+		return levelForYears(years)
+	}
+	if years, err := strconv.Atoi(strings.TrimSpace(exp)); err == nil {
+		return levelForYears(years)
+	}
+	return levelFromKeywords(exp)
+}
+
+func levelForYears(years int) int {
+	switch {
+	case years < 1:
+		return levelEntry
+	case years < 3:
+		return levelJunior
+	case years < 6:
+		return levelMid
+	default:
+		return levelSenior
+	}
+}
+
+func jobLevel(job models.Job) int {
+	if job.Seniority != "" {
+		return levelOf(job.Seniority)
+	}
+	return levelFromKeywords(strings.ToLower(job.Title))
+}
+
+func seniorityName(level int) string {
+	switch level {
+	case levelEntry:
+		return models.SeniorityEntry
+	case levelJunior:
+		return models.SeniorityJunior
+	case levelMid:
+		return models.SeniorityMid
+	case levelSenior:
+		return models.SenioritySenior
+	default:
+		return ""
+	}
+}
+
+func levelOf(seniority string) int {
+	switch seniority {
+	case models.SeniorityEntry:
+		return levelEntry
+	case models.SeniorityJunior:
+		return levelJunior
+	case models.SeniorityMid:
+		return levelMid
+	case models.SenioritySenior:
+		return levelSenior
+	default:
+		return levelUnknown
+	}
+}
+
+func levelFromKeywords(text string) int {
+	switch {
+	case seniorKeywords.MatchString(text):
+		return levelSenior
+	case midKeywords.MatchString(text):
+		return levelMid
+	case juniorKeywords.MatchString(text):
+		return levelJunior
+	case entryKeywords.MatchString(text):
+		return levelEntry
+	default:
+		return levelUnknown
+	}
+}
+
+func containsAny(text string, words ...string) bool {
+	for _, w := range words {
+		if strings.Contains(text, w) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,31 @@
+package job_service
+
+import "testing"
+
+func TestLevelFromKeywords(t *testing.T) {
+	tests := []struct {
+		text string
+		want int
+	}{
+		{"senior accountant", levelSenior},
+		{"sr. software engineer", levelSenior},
+		{"team lead, finance", levelSenior},
+		{"head of sales", levelSenior},
+		{"mid-level developer", levelMid},
+		{"junior data analyst", levelJunior},
+		{"jr. designer", levelJunior},
+		{"graduate trainee", levelEntry},
+		{"marketing intern", levelEntry},
+		{"entry-level cashier", levelEntry},
+		{"international relations officer", levelUnknown},
+		{"internal auditor", levelUnknown},
+		{"middleware engineer", levelUnknown},
+		{"community leader", levelUnknown},
+		{"refresh the product catalogue", levelUnknown},
+	}
+	for _, tt := range tests {
+		if got := levelFromKeywords(tt.text); got != tt.want {
+			t.Errorf("levelFromKeywords(%q) = %d, want %d", tt.text, got, tt.want)
+		}
+	}
+}
@@ -1,17 +1,20 @@
 package job_service
 
 import (
+	"context"
+
 	"github.com/tsigemariamzewdu/JobMate-backend/domain/models"
 )
 
 type JobSuggestionService struct {
-	JobRepo JobService
+	JobRepo *JobService
 }
 
-func NewJobSuggestionService(jobRepo JobService) *JobSuggestionService {
+func NewJobSuggestionService(jobRepo *JobService) *JobSuggestionService {
 	return &JobSuggestionService{JobRepo: jobRepo}
 }
 
 func (s *JobSuggestionService) SuggestJobs(req models.JobSuggestionRequest) ([]models.Job, string, error) {
-	return s.JobRepo.GetCuratedJobs(req.Field, req.LookingFor, req.Experience, req.Skills, req.Language)
+	jobs, _, msg, err := s.JobRepo.GetCuratedJobs(context.Background(), req.Field, req.LookingFor, req.Experience, req.Skills, req.Language)
+	return jobs, msg, err
 }
@@ -0,0 +1,152 @@
+package job_service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	svc "github.com/tsigemariamzewdu/JobMate-backend/domain/interfaces/services"
+	"github.com/tsigemariamzewdu/JobMate-backend/domain/models"
+)
+
+// JobDataAPISource fetches local Ethiopian postings from jobdataapi.com
+type JobDataAPISource struct {
+	APIKey     string
+	HTTPClient *http.Client
+
+	mu          sync.Mutex
+	countryCode string // cached after the first successful lookup
+}
+
+var _ svc.JobSource = (*JobDataAPISource)(nil)
+
+func NewJobDataAPISource(apiKey string) *JobDataAPISource {
+	return &JobDataAPISource{
+		APIKey:     apiKey,
+		HTTPClient: http.DefaultClient,
+	}
+}
+
+func (s *JobDataAPISource) Name() string {
+	return "JobDataAPI"
+}
+
+func (s *JobDataAPISource) Supports(lookingFor string) bool {
+	return lookingFor == "local"
+}
+
+func (s *JobDataAPISource) FetchJobs(ctx context.Context, req models.JobSuggestionRequest) ([]models.Job, error) {
+	countryCode, err := s.ethiopiaCountryCode(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return fetchJobsFromJobDataAPI(ctx, s.HTTPClient, s.APIKey, countryCode, req.Field)
+}
+
+// ethiopiaCountryCode looks up Ethiopia's country code once and reuses it afterwards
+func (s *JobDataAPISource) ethiopiaCountryCode(ctx context.Context) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.countryCode != "" {
+		return s.countryCode, nil
+	}
+	code, err := fetchCountryCode(ctx, s.HTTPClient, "Ethiopia")
+	if err != nil {
+		return "", err
+	}
+	s.countryCode = code
+	return code, nil
+}
+
+func fetchCountryCode(ctx context.Context, client *http.Client, country string) (string, error) {
+	countriesReq, err := http.NewRequestWithContext(ctx, "GET", "https://jobdataapi.com/api/jobcountries/", nil)
+	if err != nil {
+		return "", err
+	}
+	resp, err := client.Do(countriesReq)
+	if err != nil {
+		return "", fmt.Errorf("error fetching countries: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var countries []struct {
+		Name string `json:"name"`
+		Code string `json:"code"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&countries); err != nil {
+		return "", fmt.Errorf("error decoding countries: %w", err)
+	}
+
+	for _, c := range countries {
+		if c.Name == country {
+			return c.Code, nil
+		}
+	}
+	return "", fmt.Errorf("%s not found in countries list", country)
+}
+
+// fetch jobs from JobDataAPI for the given country
+func fetchJobsFromJobDataAPI(ctx context.Context, client *http.Client, apiKey, countryCode, titleFilter string) ([]models.Job, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", "https://jobdataapi.com/api/jobs/", nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Authorization", "Api-Key "+apiKey)
+
+	q := req.URL.Query()
+	q.Add("country_code", countryCode)
+	if titleFilter != "" {
+		q.Add("title", titleFilter)
+	}
+	req.URL.RawQuery = q.Encode()
+
+	resp2, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("error fetching jobs: %w", err)
+	}
+	defer resp2.Body.Close()
+
+	if resp2.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("jobdataapi returned status: %s", resp2.Status)
+	}
+
+	var data struct {
+		Count   int `json:"count"`
+		Results []struct {
+			ID      int `json:"id"`
+			Company struct {
+				Name string `json:"name"`
+				Logo string `json:"logo"`
+			} `json:"company"`
+			Title          string `json:"title"`
+			Location       string `json:"location"`
+			Description    string `json:"description"`
+			ApplicationURL string `json:"application_url"`
+			Published      string `json:"published"`
+		} `json:"results"`
+	}
+	if err := json.NewDecoder(resp2.Body).Decode(&data); err != nil {
+		return nil, fmt.Errorf("error decoding jobs: %w", err)
+	}
+
+	var jobs []models.Job
+	for _, j := range data.Results {
+		postedAt, _ := time.Parse(time.RFC3339, j.Published)
+		jobs = append(jobs, models.Job{
+			Title:        j.Title,
+			Company:      j.Company.Name,
+			Location:     j.Location,
+			Description:  j.Description,
+			Link:         j.ApplicationURL,
+			Source:       "JobDataAPI",
+			Requirements: []string{},
+			Type:         "local",
+			PostedAt:     postedAt,
+		})
+	}
+
+	return jobs, nil
+}
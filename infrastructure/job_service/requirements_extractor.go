@@ -0,0 +1,260 @@
+package job_service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"html"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/tsigemariamzewdu/JobMate-backend/domain"
+	svc "github.com/tsigemariamzewdu/JobMate-backend/domain/interfaces/services"
+	"github.com/tsigemariamzewdu/JobMate-backend/domain/models"
+	"github.com/tsigemariamzewdu/JobMate-backend/infrastructure/skills"
+)
+
+const (
+	// below this many rule-based requirements the AI pass is attempted
+	minRuleRequirements = 3
+	// descriptions sent to the AI are cut to this many bytes to keep prompts small
+	maxAIDescriptionChars = 4000
+)
+
+var (
+	htmlTagPattern    = regexp.MustCompile(`(?s)<[^>]*>`)
+	blankLinesPattern = regexp.MustCompile(`\n{3,}`)
+
+	currencyPattern = `(ETB\b|Birr\b|Br\b\.?|USD\b|US\$|\$|EUR\b|€)`
+	amountPattern   = `(\d[\d,]*(?:\.\d+)?)\s*(k)?`
+	// "ETB 10,000 - 15,000", "$50k to $70k"
+	salaryCurrencyFirst = regexp.MustCompile(`(?i)` + currencyPattern + `\s?` + amountPattern + `(?:\s*(?:-|–|to)\s*` + currencyPattern + `?\s?` + amountPattern + `)?`)
+	// "10,000 - 15,000 ETB", "12000 birr"
+	salaryAmountFirst = regexp.MustCompile(`(?i)` + amountPattern + `(?:\s*(?:-|–|to)\s*` + amountPattern + `)?\s?` + currencyPattern)
+	yearsRequired     = regexp.MustCompile(`(?i)(\d+)\s*\+?\s*(?:years?|yrs?)(?:\s+of)?\s+(?:relevant\s+|work\s+|professional\s+)?experience`)
+)
+
+// RequirementExtractor fills structured requirements from a posting using rules,
+// optionally asking the AI client when the rules find too little.
+type RequirementExtractor struct {
+	AIClient svc.IAIClient // nil disables the AI pass
+}
+
+var _ svc.IJobRequirementExtractor = (*RequirementExtractor)(nil)
+
+func NewRequirementExtractor(aiClient svc.IAIClient) *RequirementExtractor {
+	return &RequirementExtractor{AIClient: aiClient}
+}
+
+func (e *RequirementExtractor) Extract(ctx context.Context, job *models.Job) error {
+	job.Description = cleanDescription(job.Description)
+	text := job.Title + "\n" + job.Description
+
+	job.Requirements = skills.Dedupe(append(job.Requirements, skills.FindIn(text)...))
+	if job.Seniority == "" {
+		job.Seniority = extractSeniority(job.Title, job.Description)
+	}
+	if job.EmploymentType == "" {
+		job.EmploymentType = extractEmploymentType(text)
+	}
+	if job.Salary == nil {
+		job.Salary = extractSalary(job.Description)
+	}
+
+	if e.AIClient == nil || job.Description == "" || len(job.Requirements) >= minRuleRequirements {
+		return nil
+	}
+	if err := e.extractWithAI(ctx, job); err != nil {
+		return fmt.Errorf("AI requirement extraction failed for %q: %w", job.Title, err)
+	}
+	return nil
+}
+
+type aiRequirements struct {
+	Requirements   []string `json:"requirements"`
+	Seniority      string   `json:"seniority"`
+	EmploymentType string   `json:"employment_type"`
+	Salary         *struct {
+		Min      float64 `json:"min"`
+		Max      float64 `json:"max"`
+		Currency string  `json:"currency"`
+		Period   string  `json:"period"`
+	} `json:"salary"`
+}
+
+// extractWithAI merges the AI's reading of the posting into fields the rules left empty
+func (e *RequirementExtractor) extractWithAI(ctx context.Context, job *models.Job) error {
+	description := job.Description
+	if len(description) > maxAIDescriptionChars {
+		// back up to the start of a character so Amharic text is not cut mid-rune
+		cut := maxAIDescriptionChars
+		for cut > 0 && !utf8.RuneStart(description[cut]) {
+			cut--
+		}
+		description = description[:cut]
+	}
+
+	messages := []models.AIMessage{
+		{
+			Role: "system",
+			Content: `You extract structured data from job postings. Reply with only JSON of this shape:
+{"requirements": ["short skill or qualification"], "seniority": "entry|junior|mid|senior|", "employment_type": "full_time|part_time|contract|internship|temporary|", "salary": {"min": 0, "max": 0, "currency": "ETB", "period": "month"} }
+Use an empty string or null when the posting does not say.`,
+		},
+		{Role: "user", Content: "Title: " + job.Title + "\n\n" + description},
+	}
+
+	// background work, so usage is recorded without a user
+	raw, err := e.AIClient.GetChatCompletion(domain.WithAICaller(ctx, "", models.AIFeatureJobRequirements), messages)
+	if err != nil {
+		return err
+	}
+	raw = strings.TrimSpace(raw)
+	raw = strings.TrimPrefix(raw, "```json")
+	raw = strings.TrimPrefix(raw, "```")
+	raw = strings.TrimSuffix(raw, "```")
+
+	var parsed aiRequirements
+	if err := json.Unmarshal([]byte(strings.TrimSpace(raw)), &parsed); err != nil {
+		return fmt.Errorf("failed to parse AI response: %w", err)
+	}
+
+	job.Requirements = skills.Dedupe(append(job.Requirements, parsed.Requirements...))
+	if job.Seniority == "" && isOneOf(parsed.Seniority, models.SeniorityEntry, models.SeniorityJunior, models.SeniorityMid, models.SenioritySenior) {
+		job.Seniority = parsed.Seniority
+	}
+	if job.EmploymentType == "" && isOneOf(parsed.EmploymentType, "full_time", "part_time", "contract", "internship", "temporary") {
+		job.EmploymentType = parsed.EmploymentType
+	}
+	if job.Salary == nil && parsed.Salary != nil && (parsed.Salary.Min > 0 || parsed.Salary.Max > 0) {
+		job.Salary = &models.SalaryRange{
+			Min:      parsed.Salary.Min,
+			Max:      parsed.Salary.Max,
+			Currency: strings.ToUpper(parsed.Salary.Currency),
+			Period:   parsed.Salary.Period,
+		}
+	}
+	return nil
+}
+
+// cleanDescription turns HTML postings into plain text
+func cleanDescription(description string) string {
+	text := strings.NewReplacer("<br>", "\n", "<br/>", "\n", "<br />", "\n", "</p>", "\n", "</li>", "\n", "<li>", "- ").Replace(description)
+	text = htmlTagPattern.ReplaceAllString(text, "")
+	text = html.UnescapeString(text)
+
+	lines := strings.Split(text, "\n")
+	for i, line := range lines {
+		lines[i] = strings.Join(strings.Fields(line), " ")
+	}
+	text = blankLinesPattern.ReplaceAllString(strings.Join(lines, "\n"), "\n\n")
+	return strings.TrimSpace(text)
+}
+
+func extractSeniority(title, description string) string {
+	if level := levelFromKeywords(strings.ToLower(title)); level != levelUnknown {
+		return seniorityName(level)
+	}
+	if m := yearsRequired.FindStringSubmatch(description); m != nil {
+		years, _ := strconv.Atoi(m[1])
+		return seniorityName(levelForYears(years))
+	}
+	return seniorityName(levelFromKeywords(strings.ToLower(description)))
+}
+
+func extractEmploymentType(text string) string {
+	lower := strings.ToLower(text)
+	switch {
+	case containsAny(lower, "internship", "intern "):
+		return "internship"
+	case containsAny(lower, "part-time", "part time"):
+		return "part_time"
+	case containsAny(lower, "contractual", "contract basis", "fixed term", "fixed-term", "contract position", "contract role"):
+		return "contract"
+	case containsAny(lower, "temporary"):
+		return "temporary"
+	case containsAny(lower, "full-time", "full time", "permanent"):
+		return "full_time"
+	default:
+		return ""
+	}
+}
+
+func extractSalary(description string) *models.SalaryRange {
+	var currency, minStr, minK, maxStr, maxK string
+	if m := salaryCurrencyFirst.FindStringSubmatch(description); m != nil {
+		currency, minStr, minK, maxStr, maxK = m[1], m[2], m[3], m[5], m[6]
+	} else if m := salaryAmountFirst.FindStringSubmatch(description); m != nil {
+		minStr, minK, maxStr, maxK, currency = m[1], m[2], m[3], m[4], m[5]
+	} else {
+		return nil
+	}
+
+	minVal := parseAmount(minStr, minK)
+	maxVal := parseAmount(maxStr, maxK)
+	if maxVal == 0 {
+		maxVal = minVal
+	}
+	if minVal <= 0 {
+		return nil
+	}
+
+	return &models.SalaryRange{
+		Min:      minVal,
+		Max:      maxVal,
+		Currency: normalizeCurrency(currency),
+		Period:   salaryPeriod(description),
+	}
+}
+
+func parseAmount(value, thousands string) float64 {
+	if value == "" {
+		return 0
+	}
+	amount, err := strconv.ParseFloat(strings.ReplaceAll(value, ",", ""), 64)
+	if err != nil {
+		return 0
+	}
+	if thousands != "" {
+		amount *= 1000
+	}
+	return amount
+}
+
+func normalizeCurrency(currency string) string {
+	switch strings.ToLower(strings.TrimSuffix(currency, ".")) {
+	case "etb", "birr", "br":
+		return "ETB"
+	case "usd", "us$", "$":
+		return "USD"
+	case "eur", "€":
+		return "EUR"
+	default:
+		return strings.ToUpper(currency)
+	}
+}
+
+func salaryPeriod(description string) string {
+	lower := strings.ToLower(description)
+	switch {
+	case containsAny(lower, "per hour", "hourly", "/hr", "/hour"):
+		return "hour"
+	case containsAny(lower, "per year", "per annum", "annual", "yearly", "/yr", "/year"):
+		return "year"
+	case containsAny(lower, "per month", "monthly", "/month", "/mo"):
+		return "month"
+	default:
+		return ""
+	}
+}
+
+func isOneOf(value string, allowed ...string) bool {
+	for _, a := range allowed {
+		if value == a {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,132 @@
+package job_service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	svc "github.com/tsigemariamzewdu/JobMate-backend/domain/interfaces/services"
+	"github.com/tsigemariamzewdu/JobMate-backend/domain/models"
+)
+
+const defaultSourceTimeout = 10 * time.Second
+
+type registeredSource struct {
+	source  svc.JobSource
+	timeout time.Duration
+}
+
+// SourceRegistry keeps the configured job sources and fans searches out to them
+type SourceRegistry struct {
+	mu             sync.RWMutex
+	sources        []registeredSource
+	enabled        map[string]bool
+	defaultTimeout time.Duration
+}
+
+// NewSourceRegistry creates a registry. An empty enabled list enables every registered source.
+func NewSourceRegistry(defaultTimeout time.Duration, enabled []string) *SourceRegistry {
+	if defaultTimeout <= 0 {
+		defaultTimeout = defaultSourceTimeout
+	}
+	enabledSet := make(map[string]bool)
+	for _, name := range enabled {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name != "" {
+			enabledSet[name] = true
+		}
+	}
+	return &SourceRegistry{
+		enabled:        enabledSet,
+		defaultTimeout: defaultTimeout,
+	}
+}
+
+// Register adds a source. A zero timeout falls back to the registry default.
+func (r *SourceRegistry) Register(source svc.JobSource, timeout time.Duration) {
+	if timeout <= 0 {
+		timeout = r.defaultTimeout
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.sources = append(r.sources, registeredSource{source: source, timeout: timeout})
+}
+
+// Sources returns the names of the enabled sources in registration order
+func (r *SourceRegistry) Sources() []string {
+	var names []string
+	for _, rs := range r.enabledSources() {
+		names = append(names, rs.source.Name())
+	}
+	return names
+}
+
+func (r *SourceRegistry) enabledSources() []registeredSource {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	var out []registeredSource
+	for _, rs := range r.sources {
+		if len(r.enabled) == 0 || r.enabled[strings.ToLower(rs.source.Name())] {
+			out = append(out, rs)
+		}
+	}
+	return out
+}
+
+// FetchAll queries every enabled source supporting req.LookingFor concurrently, each under its own timeout.
+// Results are merged in registration order; sources that error are reported instead of aborting the search.
+func (r *SourceRegistry) FetchAll(ctx context.Context, req models.JobSuggestionRequest) ([]models.Job, []models.JobSourceFailure) {
+	var targets []registeredSource
+	for _, rs := range r.enabledSources() {
+		if rs.source.Supports(req.LookingFor) {
+			targets = append(targets, rs)
+		}
+	}
+
+	results := make([][]models.Job, len(targets))
+	errs := make([]error, len(targets))
+
+	var wg sync.WaitGroup
+	for i, rs := range targets {
+		wg.Add(1)
+		go func(i int, rs registeredSource) {
+			defer wg.Done()
+			defer func() {
+				if rec := recover(); rec != nil {
+					errs[i] = fmt.Errorf("source panicked: %v", rec)
+				}
+			}()
+
+			c, cancel := context.WithTimeout(ctx, rs.timeout)
+			defer cancel()
+
+			jobs, err := rs.source.FetchJobs(c, req)
+			if err != nil && c.Err() == context.DeadlineExceeded {
+				err = fmt.Errorf("timed out after %s", rs.timeout)
+			}
+			results[i], errs[i] = jobs, err
+		}(i, rs)
+	}
+	wg.Wait()
+
+	var jobs []models.Job
+	var failures []models.JobSourceFailure
+	for i, rs := range targets {
+		if errs[i] != nil {
+			failures = append(failures, models.JobSourceFailure{
+				Source: rs.source.Name(),
+				Error:  errs[i].Error(),
+			})
+			continue
+		}
+		for _, job := range results[i] {
+			if job.Source == "" {
+				job.Source = rs.source.Name()
+			}
+			jobs = append(jobs, job)
+		}
+	}
+	return jobs, failures
+}
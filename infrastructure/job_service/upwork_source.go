@@ -0,0 +1,51 @@
+package job_service
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	svc "github.com/tsigemariamzewdu/JobMate-backend/domain/interfaces/services"
+	"github.com/tsigemariamzewdu/JobMate-backend/domain/models"
+)
+
+// UpworkSource links to an Upwork search for remote/freelance positions
+type UpworkSource struct{}
+
+var _ svc.JobSource = (*UpworkSource)(nil)
+
+func NewUpworkSource() *UpworkSource {
+	return &UpworkSource{}
+}
+
+func (s *UpworkSource) Name() string {
+	return "Upwork"
+}
+
+func (s *UpworkSource) Supports(lookingFor string) bool {
+	return lookingFor == "remote" || lookingFor == "freelance"
+}
+
+func (s *UpworkSource) FetchJobs(ctx context.Context, req models.JobSuggestionRequest) ([]models.Job, error) {
+	return fetchUpworkJobs(req.Field)
+}
+
+// fetch jobs from Upwork for remote/freelance positions
+func fetchUpworkJobs(field string) ([]models.Job, error) {
+	// without a search term there is no meaningful Upwork listing to link to
+	if field == "" {
+		return nil, nil
+	}
+	searchURL := fmt.Sprintf("https://www.upwork.com/ab/jobs/search/?q=%s", url.QueryEscape(field))
+	job := models.Job{
+		Title:        fmt.Sprintf("Freelance %s Jobs", field),
+		Company:      "Upwork",
+		Location:     "Remote",
+		Requirements: []string{},
+		Type:         "freelance",
+		Source:       "Upwork",
+		Link:         searchURL,
+		Language:     "en",
+	}
+	return []models.Job{job}, nil
+}
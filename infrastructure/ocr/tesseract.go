@@ -0,0 +1,172 @@
+package ocr
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/tsigemariamzewdu/JobMate-backend/domain"
+	svc "github.com/tsigemariamzewdu/JobMate-backend/domain/interfaces/services"
+)
+
+// DefaultLanguages covers the CVs we receive: Amharic, English, or both on one page
+const DefaultLanguages = "amh+eng"
+
+// TesseractEngine runs the tesseract command line tool. PDF pages are turned into images
+// with pdftoppm first, since tesseract only reads images.
+type TesseractEngine struct {
+	Binary     string // tesseract executable
+	Rasterizer string // pdftoppm executable
+	Languages  string // tesseract language codes joined with "+"
+	DPI        int    // resolution PDF pages are rendered at
+	MaxPages   int    // later pages of long scans are ignored
+	Timeout    time.Duration
+
+	// slots bounds how many recognitions run at once; each one keeps a CPU busy
+	slots chan struct{}
+}
+
+var _ svc.OCREngine = (*TesseractEngine)(nil)
+
+func NewTesseractEngine(languages string, timeout time.Duration) *TesseractEngine {
+	if languages == "" {
+		languages = DefaultLanguages
+	}
+	return &TesseractEngine{
+		Binary:     "tesseract",
+		Rasterizer: "pdftoppm",
+		Languages:  languages,
+		DPI:        300,
+		MaxPages:   5,
+		Timeout:    timeout,
+		slots:      make(chan struct{}, runtime.NumCPU()),
+	}
+}
+
+func (t *TesseractEngine) Recognize(ctx context.Context, data []byte, contentType string) (string, error) {
+	if t.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, t.Timeout)
+		defer cancel()
+	}
+
+	select {
+	case t.slots <- struct{}{}:
+		defer func() { <-t.slots }()
+	case <-ctx.Done():
+		return "", ctx.Err()
+	}
+
+	switch contentType {
+	case "image/jpeg", "image/png":
+		return t.recognizeImage(ctx, data)
+	case "application/pdf":
+		return t.recognizePDF(ctx, data)
+	default:
+		return "", fmt.Errorf("cannot recognize text in %s", contentType)
+	}
+}
+
+// Check reports whether tesseract is installed with every configured language
+func (t *TesseractEngine) Check(ctx context.Context) error {
+	out, err := t.run(ctx, nil, t.Binary, "--list-langs")
+	if err != nil {
+		return err
+	}
+	installed := map[string]bool{}
+	for _, line := range strings.Split(string(out), "\n") {
+		installed[strings.TrimSpace(line)] = true
+	}
+	var missing []string
+	for _, lang := range strings.Split(t.Languages, "+") {
+		if !installed[lang] {
+			missing = append(missing, lang)
+		}
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("%w: tesseract has no data for %s", domain.ErrOCRUnavailable, strings.Join(missing, ", "))
+	}
+	if _, err := exec.LookPath(t.Rasterizer); err != nil {
+		return fmt.Errorf("%w: %s is not installed, scanned PDFs cannot be read", domain.ErrOCRUnavailable, t.Rasterizer)
+	}
+	return nil
+}
+
+func (t *TesseractEngine) recognizeImage(ctx context.Context, data []byte) (string, error) {
+	out, err := t.run(ctx, data, t.Binary, "stdin", "stdout", "-l", t.Languages)
+	if err != nil {
+		return "", err
+	}
+	return string(out), nil
+}
+
+// recognizePDF renders each page to a PNG and reads them in page order
+func (t *TesseractEngine) recognizePDF(ctx context.Context, data []byte) (string, error) {
+	dir, err := os.MkdirTemp("", "ocr-")
+	if err != nil {
+		return "", fmt.Errorf("failed to create OCR work directory: %w", err)
+	}
+	defer os.RemoveAll(dir)
+
+	input := filepath.Join(dir, "input.pdf")
+	if err := os.WriteFile(input, data, 0o600); err != nil {
+		return "", fmt.Errorf("failed to write PDF for OCR: %w", err)
+	}
+	args := []string{"-r", strconv.Itoa(t.DPI), "-gray", "-png"}
+	if t.MaxPages > 0 {
+		args = append(args, "-l", strconv.Itoa(t.MaxPages))
+	}
+	if _, err := t.run(ctx, nil, t.Rasterizer, append(args, input, filepath.Join(dir, "page"))...); err != nil {
+		return "", err
+	}
+
+	// pdftoppm zero-pads page numbers, so name order is page order
+	pages, err := filepath.Glob(filepath.Join(dir, "page-*.png"))
+	if err != nil {
+		return "", err
+	}
+	sort.Strings(pages)
+
+	var text strings.Builder
+	for _, page := range pages {
+		out, err := t.run(ctx, nil, t.Binary, page, "stdout", "-l", t.Languages)
+		if err != nil {
+			return "", err
+		}
+		text.Write(out)
+		text.WriteString("\n\n")
+	}
+	return text.String(), nil
+}
+
+// run executes a tool and returns its standard output. A missing tool is reported as
+// domain.ErrOCRUnavailable, and running out of time as the context's error.
+func (t *TesseractEngine) run(ctx context.Context, stdin []byte, name string, args ...string) ([]byte, error) {
+	cmd := exec.CommandContext(ctx, name, args...)
+	if stdin != nil {
+		cmd.Stdin = bytes.NewReader(stdin)
+	}
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		switch {
+		case errors.Is(err, exec.ErrNotFound):
+			return nil, fmt.Errorf("%w: %s is not installed", domain.ErrOCRUnavailable, name)
+		case ctx.Err() != nil:
+			return nil, ctx.Err()
+		}
+		return nil, fmt.Errorf("%s failed: %v: %s", name, err, strings.TrimSpace(stderr.String()))
+	}
+	return stdout.Bytes(), nil
+}
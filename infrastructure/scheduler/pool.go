@@ -0,0 +1,69 @@
+package scheduler
+
+import (
+	"context"
+	"log"
+	"time"
+)
+
+// Pool runs a fixed number of workers that drain a queue. Workers sleep until Notify is
+// called or the poll interval passes, so work queued by other instances is still picked up.
+type Pool struct {
+	name         string
+	size         int
+	pollInterval time.Duration
+	wake         chan struct{}
+}
+
+func NewPool(name string, size int, pollInterval time.Duration) *Pool {
+	if size <= 0 {
+		size = 1
+	}
+	if pollInterval <= 0 {
+		pollInterval = 30 * time.Second
+	}
+	return &Pool{
+		name:         name,
+		size:         size,
+		pollInterval: pollInterval,
+		wake:         make(chan struct{}, size),
+	}
+}
+
+// Notify wakes an idle worker. It never blocks; if every worker is already awake the
+// signal is dropped since they keep draining until the queue is empty.
+func (p *Pool) Notify() {
+	select {
+	case p.wake <- struct{}{}:
+	default:
+	}
+}
+
+// Start launches the workers. task handles one item and reports whether there was one;
+// workers call it until it reports an empty queue, then wait. Errors are logged.
+func (p *Pool) Start(ctx context.Context, task func(context.Context) (bool, error)) {
+	for i := 0; i < p.size; i++ {
+		go func() {
+			ticker := time.NewTicker(p.pollInterval)
+			defer ticker.Stop()
+
+			for {
+				for ctx.Err() == nil {
+					worked, err := task(ctx)
+					if err != nil {
+						log.Printf("%s: %v", p.name, err)
+					}
+					if !worked {
+						break
+					}
+				}
+				select {
+				case <-ctx.Done():
+					return
+				case <-p.wake:
+				case <-ticker.C:
+				}
+			}
+		}()
+	}
+}
@@ -0,0 +1,27 @@
+package scheduler
+
+import (
+	"context"
+	"log"
+	"time"
+)
+
+// Every runs task immediately and then on each interval until ctx is cancelled.
+// Errors are logged; a failed run does not stop the schedule.
+func Every(ctx context.Context, name string, interval time.Duration, task func(context.Context) error) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			if err := task(ctx); err != nil {
+				log.Printf("%s: %v", name, err)
+			}
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
@@ -0,0 +1,102 @@
+package skills
+
+// catalog is a curated list of skills commonly asked for in Ethiopian and remote job postings
+var catalog = []string{
+	// software
+	"go", "python", "java", "javascript", "typescript", "php", "c#", "c++", "kotlin", "swift", "dart", "ruby",
+	"react", "angular", "vue", "next", "node", "django", "flask", "laravel", "spring", "flutter", "react native",
+	"html", "css", "tailwind", "sql", "mysql", "postgresql", "mongodb", "redis", "graphql", "rest api",
+	"docker", "kubernetes", "aws", "azure", "google cloud", "linux", "git", "ci/cd", "microservices",
+	"machine learning", "data analysis", "data science", "power bi", "tableau", "excel", "statistics",
+	"figma", "ux design", "graphic design", "photoshop", "illustrator",
+	"networking", "cybersecurity", "it support",
+	// business and finance
+	"accounting", "auditing", "financial analysis", "budgeting", "ifrs", "peachtree", "quickbooks", "tax",
+	"procurement", "supply chain", "logistics", "inventory management",
+	"sales", "marketing", "digital marketing", "social media", "seo", "content writing", "customer service",
+	"project management", "agile", "scrum", "business analysis", "research",
+	"human resources", "recruitment", "administration",
+	// general
+	"communication", "teamwork", "leadership", "problem solving", "report writing", "microsoft office", "word",
+	"amharic", "english", "afaan oromo", "tigrinya",
+	// health, engineering and development
+	"nursing", "public health", "pharmacy", "monitoring and evaluation", "grant writing",
+	"civil engineering", "electrical engineering", "autocad", "surveying",
+	"teaching", "driving",
+}
+
+// ambiguous skills are ordinary words too, so in free text they only count in these spellings
+var ambiguous = map[string][]string{
+	"go":   {"golang", "go lang", "go programming", "go developer", "go language"},
+	"next": {"next.js", "nextjs"},
+	"node": {"node.js", "nodejs"},
+	"word": {"ms word", "microsoft word"},
+}
+
+// Catalog returns the curated skill list
+func Catalog() []string {
+	out := make([]string, len(catalog))
+	copy(out, catalog)
+	return out
+}
+
+// FindIn returns the catalog skills mentioned in text, in catalog order
+func FindIn(text string) []string {
+	var found []string
+	words := tokenize(text)
+	for _, s := range catalog {
+		if aliases, ok := ambiguous[s]; ok {
+			for _, alias := range aliases {
+				if containsPhrase(words, tokenize(alias)) {
+					found = append(found, s)
+					break
+				}
+			}
+			continue
+		}
+		if Mentions(text, s) || containsAnyPhrase(words, phraseSynonyms[s]) {
+			found = append(found, s)
+		}
+	}
+	return found
+}
+
+// phraseSynonyms holds, per canonical skill, its synonyms of more than one word. Mentions
+// normalizes text a word at a time, so these are looked up as phrases instead.
+var phraseSynonyms = func() map[string][][]string {
+	out := make(map[string][][]string)
+	for alias, canonical := range synonyms {
+		if words := tokenize(alias); len(words) > 1 {
+			out[canonical] = append(out[canonical], words)
+		}
+	}
+	return out
+}()
+
+func containsAnyPhrase(words []string, phrases [][]string) bool {
+	for _, phrase := range phrases {
+		if containsPhrase(words, phrase) {
+			return true
+		}
+	}
+	return false
+}
+
+func containsPhrase(words, phrase []string) bool {
+	if len(phrase) == 0 {
+		return false
+	}
+	for i := 0; i+len(phrase) <= len(words); i++ {
+		match := true
+		for j, p := range phrase {
+			if words[i+j] != p {
+				match = false
+				break
+			}
+		}
+		if match {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,263 @@
+package skills
+
+import (
+	"strings"
+	"unicode"
+)
+
+// synonyms maps common spellings and abbreviations to a canonical skill name
+var synonyms = map[string]string{
+	"js":                   "javascript",
+	"ecmascript":           "javascript",
+	"ts":                   "typescript",
+	"golang":               "go",
+	"py":                   "python",
+	"python3":              "python",
+	"reactjs":              "react",
+	"react.js":             "react",
+	"nodejs":               "node",
+	"node.js":              "node",
+	"vuejs":                "vue",
+	"vue.js":               "vue",
+	"nextjs":               "next",
+	"next.js":              "next",
+	"postgres":             "postgresql",
+	"psql":                 "postgresql",
+	"mongo":                "mongodb",
+	"k8s":                  "kubernetes",
+	"ml":                   "machine learning",
+	"ai":                   "artificial intelligence",
+	"nlp":                  "natural language processing",
+	"c sharp":              "c#",
+	"csharp":               "c#",
+	"cpp":                  "c++",
+	"ms excel":             "excel",
+	"microsoft excel":      "excel",
+	"ms word":              "word",
+	"microsoft word":       "word",
+	"ms office":            "microsoft office",
+	"powerbi":              "power bi",
+	"aws cloud":            "aws",
+	"amazon web services":  "aws",
+	"gcp":                  "google cloud",
+	"ui/ux":                "ux design",
+	"ux":                   "ux design",
+	"comms":                "communication",
+	"communication skills": "communication",
+	"accounting software":  "accounting",
+	"bookkeeping":          "accounting",
+
+	// Amharic, written after FoldEthiopic
+	"የሂሳብ አያያዝ":      "accounting",
+	"ሂሳብ አያያዝ":       "accounting",
+	"ኦዲት":            "auditing",
+	"በጀት":            "budgeting",
+	"ግብር":            "tax",
+	"ግዢ":             "procurement",
+	"ግዥ":             "procurement",
+	"ሎጂስቲክስ":         "logistics",
+	"የንብረት አስተዳደር":   "inventory management",
+	"ሽያጭ":            "sales",
+	"ግብይት":           "marketing",
+	"ማህበራዊ ሚዲያ":      "social media",
+	"የደንበኞች አገልግሎት":  "customer service",
+	"የፕሮጀክት አስተዳደር":  "project management",
+	"ምርምር":           "research",
+	"የሰው ሀብት":        "human resources",
+	"ቅጥር":            "recruitment",
+	"የቢሮ አስተዳደር":     "administration",
+	"የመረጃ ትንተና":      "data analysis",
+	"መረጃ ትንተና":       "data analysis",
+	"ኤክሴል":           "excel",
+	"ግራፊክ ዲዛይን":      "graphic design",
+	"ኔትወርኪንግ":        "networking",
+	"የቡድን ስራ":        "teamwork",
+	"አመራር":           "leadership",
+	"ችግር ፈቺ":         "problem solving",
+	"ችግር መፍታት":       "problem solving",
+	"ሪፖርት አጻጻፍ":      "report writing",
+	"አማርኛ":           "amharic",
+	"እንግሊዝኛ":         "english",
+	"ኦሮምኛ":           "afaan oromo",
+	"ኦሮሚኛ":           "afaan oromo",
+	"አፋን ኦሮሞ":        "afaan oromo",
+	"ትግርኛ":           "tigrinya",
+	"ነርሲንግ":          "nursing",
+	"ፋርማሲ":           "pharmacy",
+	"የህብረተሰብ ጤና":     "public health",
+	"የህዝብ ጤና":        "public health",
+	"ሲቪል ምህንድስና":     "civil engineering",
+	"ኤሌክትሪካል ምህንድስና": "electrical engineering",
+	"ቅየሳ":            "surveying",
+	"ማስተማር":          "teaching",
+	"ማሽከርከር":         "driving",
+}
+
+// FoldEthiopic writes Ethiopic letters that are used interchangeably (ሥ/ስ, ሐ/ኀ/ሀ, ዐ/አ, ፀ/ጸ)
+// the same way, so "የሥራ" and "የስራ" compare equal
+func FoldEthiopic(s string) string {
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r >= 0x1210 && r <= 0x1217: // ሐ
+			return r - 0x10
+		case r >= 0x1280 && r <= 0x1287: // ኀ
+			return r - 0x80
+		case r >= 0x1220 && r <= 0x1227: // ሠ
+			return r + 0x10
+		case r >= 0x12D0 && r <= 0x12D7: // ዐ
+			return r - 0x30
+		case r >= 0x1340 && r <= 0x1347: // ፀ
+			return r - 0x08
+		}
+		return r
+	}, s)
+}
+
+// Normalize lowercases a skill, collapses whitespace, strips surrounding punctuation
+// and resolves known synonyms to their canonical form.
+func Normalize(skill string) string {
+	s := FoldEthiopic(strings.ToLower(strings.TrimSpace(skill)))
+	s = strings.TrimFunc(s, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '+' && r != '#'
+	})
+	s = strings.Join(strings.Fields(s), " ")
+	if canonical, ok := synonyms[s]; ok {
+		return canonical
+	}
+	return s
+}
+
+// Equal reports whether two skills refer to the same thing, tolerating synonyms and small typos
+func Equal(a, b string) bool {
+	na, nb := Normalize(a), Normalize(b)
+	if na == "" || nb == "" {
+		return false
+	}
+	if na == nb {
+		return true
+	}
+	return withinTypoDistance(na, nb)
+}
+
+// Mentions reports whether text contains the skill as whole words, e.g. "react" in
+// "Senior React Developer" but not "java" in "javascript".
+func Mentions(text, skill string) bool {
+	words := tokenize(text)
+	target := tokenize(Normalize(skill))
+	if len(target) == 0 {
+		return false
+	}
+	// normalize each word too, so "golang" in text matches the skill "go"
+	for i, w := range words {
+		words[i] = Normalize(w)
+	}
+	return containsPhrase(words, target)
+}
+
+// Match splits required skills into those the candidate has and those missing
+func Match(candidate, required []string) (matched, missing []string) {
+	for _, req := range required {
+		found := false
+		for _, have := range candidate {
+			if Equal(have, req) || Mentions(req, have) {
+				found = true
+				break
+			}
+		}
+		if found {
+			matched = append(matched, req)
+		} else {
+			missing = append(missing, req)
+		}
+	}
+	return matched, missing
+}
+
+// JobKeywords returns the skills a job asks for: its extracted requirements, or the catalog
+// skills its posting mentions when none were extracted
+func JobKeywords(requirements []string, posting string) []string {
+	if len(requirements) > 0 {
+		return requirements
+	}
+	return FindIn(posting)
+}
+
+// Overlap splits keywords into those a CV covers, by its skills or anywhere in its text,
+// and those it is missing
+func Overlap(cvText string, cvSkills, keywords []string) (matched, missing []string) {
+	matched, notListed := Match(cvSkills, keywords)
+	for _, k := range notListed {
+		if Mentions(cvText, k) {
+			matched = append(matched, k)
+		} else {
+			missing = append(missing, k)
+		}
+	}
+	return matched, missing
+}
+
+// Dedupe removes skills that normalize to the same value, keeping the first spelling
+func Dedupe(list []string) []string {
+	seen := make(map[string]bool)
+	var out []string
+	for _, s := range list {
+		n := Normalize(s)
+		if n == "" || seen[n] {
+			continue
+		}
+		seen[n] = true
+		out = append(out, strings.TrimSpace(s))
+	}
+	return out
+}
+
+// tokenize splits text into lowercase words, keeping symbols used in skill names (c++, c#, node.js)
+func tokenize(text string) []string {
+	fields := strings.FieldsFunc(FoldEthiopic(strings.ToLower(text)), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '+' && r != '#' && r != '.'
+	})
+	words := fields[:0]
+	for _, f := range fields {
+		if f = strings.Trim(f, "."); f != "" {
+			words = append(words, f)
+		}
+	}
+	return words
+}
+
+// withinTypoDistance allows one edit for medium words and two for long ones
+func withinTypoDistance(a, b string) bool {
+	n := len([]rune(a))
+	if m := len([]rune(b)); m < n {
+		n = m
+	}
+	switch {
+	case n >= 9:
+		return levenshtein(a, b) <= 2
+	case n >= 5:
+		return levenshtein(a, b) <= 1
+	default:
+		return false
+	}
+}
+
+func levenshtein(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	prev := make([]int, len(rb)+1)
+	curr := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		curr[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(rb)]
+}
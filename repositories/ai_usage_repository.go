@@ -0,0 +1,141 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+
+	"github.com/tsigemariamzewdu/JobMate-backend/domain"
+	repo "github.com/tsigemariamzewdu/JobMate-backend/domain/interfaces/repositories"
+	"github.com/tsigemariamzewdu/JobMate-backend/domain/models"
+)
+
+type aiUsageModel struct {
+	ID               primitive.ObjectID `bson:"_id,omitempty"`
+	UserID           string             `bson:"user_id"`
+	Feature          string             `bson:"feature"`
+	Provider         string             `bson:"provider,omitempty"`
+	Model            string             `bson:"model,omitempty"`
+	PromptTokens     int                `bson:"prompt_tokens"`
+	CompletionTokens int                `bson:"completion_tokens"`
+	TotalTokens      int                `bson:"total_tokens"`
+	Estimated        bool               `bson:"estimated"`
+	CreatedAt        time.Time          `bson:"created_at"`
+}
+
+type aiUsageTotalModel struct {
+	ID struct {
+		UserID  string `bson:"user_id"`
+		Feature string `bson:"feature"`
+	} `bson:"_id"`
+	Requests         int `bson:"requests"`
+	PromptTokens     int `bson:"prompt_tokens"`
+	CompletionTokens int `bson:"completion_tokens"`
+	TotalTokens      int `bson:"total_tokens"`
+}
+
+type aiUsageRepository struct {
+	collection *mongo.Collection
+}
+
+func NewAIUsageRepository(db *mongo.Database) repo.IAIUsageRepository {
+	return &aiUsageRepository{collection: db.Collection("ai_usage")}
+}
+
+func (r *aiUsageRepository) EnsureIndexes(ctx context.Context) error {
+	_, err := r.collection.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{Keys: bson.D{{Key: "user_id", Value: 1}, {Key: "created_at", Value: -1}}},
+		{Keys: bson.D{{Key: "created_at", Value: -1}}},
+	})
+	return err
+}
+
+func (r *aiUsageRepository) Record(ctx context.Context, record *models.AIUsageRecord) error {
+	m := aiUsageModel{
+		UserID:           record.UserID,
+		Feature:          record.Feature,
+		Provider:         record.Provider,
+		Model:            record.Model,
+		PromptTokens:     record.PromptTokens,
+		CompletionTokens: record.CompletionTokens,
+		TotalTokens:      record.TotalTokens,
+		Estimated:        record.Estimated,
+		CreatedAt:        record.CreatedAt,
+	}
+	res, err := r.collection.InsertOne(ctx, m)
+	if err != nil {
+		return fmt.Errorf("%w: %v", domain.ErrInsertingDocuments, err)
+	}
+	if oid, ok := res.InsertedID.(primitive.ObjectID); ok {
+		record.ID = oid.Hex()
+	}
+	return nil
+}
+
+func (r *aiUsageRepository) TotalTokensSince(ctx context.Context, userID string, since time.Time) (int, error) {
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{"user_id": userID, "created_at": bson.M{"$gte": since}}}},
+		{{Key: "$group", Value: bson.M{"_id": nil, "total": bson.M{"$sum": "$total_tokens"}}}},
+	}
+	cursor, err := r.collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %v", domain.ErrQueryFailed, err)
+	}
+	defer cursor.Close(ctx)
+
+	var out []struct {
+		Total int `bson:"total"`
+	}
+	if err := cursor.All(ctx, &out); err != nil {
+		return 0, fmt.Errorf("%w: %v", domain.ErrCursorFailed, err)
+	}
+	if len(out) == 0 {
+		return 0, nil
+	}
+	return out[0].Total, nil
+}
+
+func (r *aiUsageRepository) Totals(ctx context.Context, userID string, from, to time.Time) ([]models.AIUsageTotal, error) {
+	match := bson.M{"created_at": bson.M{"$gte": from, "$lt": to}}
+	if userID != "" {
+		match["user_id"] = userID
+	}
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: match}},
+		{{Key: "$group", Value: bson.M{
+			"_id":               bson.M{"user_id": "$user_id", "feature": "$feature"},
+			"requests":          bson.M{"$sum": 1},
+			"prompt_tokens":     bson.M{"$sum": "$prompt_tokens"},
+			"completion_tokens": bson.M{"$sum": "$completion_tokens"},
+			"total_tokens":      bson.M{"$sum": "$total_tokens"},
+		}}},
+		{{Key: "$sort", Value: bson.D{{Key: "total_tokens", Value: -1}}}},
+	}
+	cursor, err := r.collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrQueryFailed, err)
+	}
+	defer cursor.Close(ctx)
+
+	var rows []aiUsageTotalModel
+	if err := cursor.All(ctx, &rows); err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrCursorFailed, err)
+	}
+
+	totals := make([]models.AIUsageTotal, 0, len(rows))
+	for _, row := range rows {
+		totals = append(totals, models.AIUsageTotal{
+			UserID:           row.ID.UserID,
+			Feature:          row.ID.Feature,
+			Requests:         row.Requests,
+			PromptTokens:     row.PromptTokens,
+			CompletionTokens: row.CompletionTokens,
+			TotalTokens:      row.TotalTokens,
+		})
+	}
+	return totals, nil
+}
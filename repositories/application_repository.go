@@ -0,0 +1,273 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/tsigemariamzewdu/JobMate-backend/domain"
+	repo "github.com/tsigemariamzewdu/JobMate-backend/domain/interfaces/repositories"
+	"github.com/tsigemariamzewdu/JobMate-backend/domain/models"
+)
+
+// jobSnapshotModel is the copy of a job kept with an application, so it survives catalog expiry
+type jobSnapshotModel struct {
+	Title          string       `bson:"title"`
+	Company        string       `bson:"company"`
+	Location       string       `bson:"location"`
+	Description    string       `bson:"description,omitempty"`
+	Requirements   []string     `bson:"requirements"`
+	Seniority      string       `bson:"seniority,omitempty"`
+	EmploymentType string       `bson:"employment_type,omitempty"`
+	Salary         *salaryModel `bson:"salary,omitempty"`
+	Type           string       `bson:"type"`
+	Source         string       `bson:"source"`
+	Link           string       `bson:"link"`
+	Language       string       `bson:"language"`
+	PostedAt       time.Time    `bson:"posted_at,omitempty"`
+}
+
+type statusChangeModel struct {
+	Status    string    `bson:"status"`
+	Note      string    `bson:"note,omitempty"`
+	ChangedAt time.Time `bson:"changed_at"`
+}
+
+type applicationModel struct {
+	ID        primitive.ObjectID  `bson:"_id"`
+	UserID    string              `bson:"user_id"`
+	JobID     string              `bson:"job_id,omitempty"`
+	Job       jobSnapshotModel    `bson:"job"`
+	Status    string              `bson:"status"`
+	Notes     string              `bson:"notes"`
+	AppliedAt *time.Time          `bson:"applied_at,omitempty"`
+	History   []statusChangeModel `bson:"history"`
+	CreatedAt time.Time           `bson:"created_at"`
+	UpdatedAt time.Time           `bson:"updated_at"`
+}
+
+func toJobSnapshotModel(j models.Job) jobSnapshotModel {
+	return jobSnapshotModel{
+		Title:          j.Title,
+		Company:        j.Company,
+		Location:       j.Location,
+		Description:    j.Description,
+		Requirements:   j.Requirements,
+		Seniority:      j.Seniority,
+		EmploymentType: j.EmploymentType,
+		Salary:         toSalaryModel(j.Salary),
+		Type:           j.Type,
+		Source:         j.Source,
+		Link:           j.Link,
+		Language:       j.Language,
+		PostedAt:       j.PostedAt,
+	}
+}
+
+func toDomainJobSnapshot(m jobSnapshotModel) models.Job {
+	return toDomainJob(jobModel{
+		Title:          m.Title,
+		Company:        m.Company,
+		Location:       m.Location,
+		Description:    m.Description,
+		Requirements:   m.Requirements,
+		Seniority:      m.Seniority,
+		EmploymentType: m.EmploymentType,
+		Salary:         m.Salary,
+		Type:           m.Type,
+		Source:         m.Source,
+		Link:           m.Link,
+		Language:       m.Language,
+		PostedAt:       m.PostedAt,
+	})
+}
+
+func toDomainApplication(m applicationModel) models.JobApplication {
+	job := toDomainJobSnapshot(m.Job)
+	job.ID = m.JobID
+
+	history := make([]models.ApplicationStatusChange, 0, len(m.History))
+	for _, h := range m.History {
+		history = append(history, models.ApplicationStatusChange{
+			Status:    models.ApplicationStatus(h.Status),
+			Note:      h.Note,
+			ChangedAt: h.ChangedAt,
+		})
+	}
+
+	return models.JobApplication{
+		ID:        m.ID.Hex(),
+		UserID:    m.UserID,
+		JobID:     m.JobID,
+		Job:       job,
+		Status:    models.ApplicationStatus(m.Status),
+		Notes:     m.Notes,
+		AppliedAt: m.AppliedAt,
+		History:   history,
+		CreatedAt: m.CreatedAt,
+		UpdatedAt: m.UpdatedAt,
+	}
+}
+
+func toApplicationModel(d models.JobApplication) (*applicationModel, error) {
+	id := primitive.NewObjectID()
+	if d.ID != "" {
+		var err error
+		id, err = primitive.ObjectIDFromHex(d.ID)
+		if err != nil {
+			return nil, fmt.Errorf("invalid application ID: %w", err)
+		}
+	}
+
+	history := make([]statusChangeModel, 0, len(d.History))
+	for _, h := range d.History {
+		history = append(history, statusChangeModel{
+			Status:    string(h.Status),
+			Note:      h.Note,
+			ChangedAt: h.ChangedAt,
+		})
+	}
+
+	return &applicationModel{
+		ID:        id,
+		UserID:    d.UserID,
+		JobID:     d.JobID,
+		Job:       toJobSnapshotModel(d.Job),
+		Status:    string(d.Status),
+		Notes:     d.Notes,
+		AppliedAt: d.AppliedAt,
+		History:   history,
+		CreatedAt: d.CreatedAt,
+		UpdatedAt: d.UpdatedAt,
+	}, nil
+}
+
+type applicationRepository struct {
+	collection *mongo.Collection
+}
+
+func NewJobApplicationRepository(db *mongo.Database) repo.IJobApplicationRepository {
+	return &applicationRepository{collection: db.Collection("job_applications")}
+}
+
+func (r *applicationRepository) EnsureIndexes(ctx context.Context) error {
+	_, err := r.collection.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		// a job is saved once per user, even when two saves race
+		{Keys: bson.D{{Key: "user_id", Value: 1}, {Key: "job.link", Value: 1}}, Options: options.Index().SetUnique(true)},
+	})
+	return err
+}
+
+func (r *applicationRepository) Create(ctx context.Context, app *models.JobApplication) (string, error) {
+	model, err := toApplicationModel(*app)
+	if err != nil {
+		return "", err
+	}
+
+	if _, err := r.collection.InsertOne(ctx, model); err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return "", domain.ErrJobAlreadySaved
+		}
+		return "", fmt.Errorf("failed to insert application: %w", err)
+	}
+	return model.ID.Hex(), nil
+}
+
+func (r *applicationRepository) GetByID(ctx context.Context, userID, id string) (*models.JobApplication, error) {
+	oid, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, domain.ErrInvalidApplicationID
+	}
+
+	var model applicationModel
+	err = r.collection.FindOne(ctx, bson.M{"_id": oid, "user_id": userID}).Decode(&model)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, domain.ErrApplicationNotFound
+		}
+		return nil, err
+	}
+
+	app := toDomainApplication(model)
+	return &app, nil
+}
+
+func (r *applicationRepository) ListByUser(ctx context.Context, userID string, status models.ApplicationStatus) ([]models.JobApplication, error) {
+	filter := bson.M{"user_id": userID}
+	if status != "" {
+		filter["status"] = string(status)
+	}
+	opts := options.Find().SetSort(bson.D{{Key: "updated_at", Value: -1}})
+
+	cursor, err := r.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, domain.ErrQueryFailed
+	}
+	defer cursor.Close(ctx)
+
+	var docs []applicationModel
+	if err := cursor.All(ctx, &docs); err != nil {
+		return nil, domain.ErrDocumentDecoding
+	}
+
+	apps := make([]models.JobApplication, 0, len(docs))
+	for _, d := range docs {
+		apps = append(apps, toDomainApplication(d))
+	}
+	return apps, nil
+}
+
+func (r *applicationRepository) ExistsForJob(ctx context.Context, userID, jobLink string) (bool, error) {
+	count, err := r.collection.CountDocuments(ctx, bson.M{"user_id": userID, "job.link": jobLink})
+	if err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+func (r *applicationRepository) Update(ctx context.Context, app *models.JobApplication) error {
+	model, err := toApplicationModel(*app)
+	if err != nil {
+		return domain.ErrInvalidApplicationID
+	}
+
+	update := bson.M{
+		"$set": bson.M{
+			"status":     model.Status,
+			"notes":      model.Notes,
+			"applied_at": model.AppliedAt,
+			"history":    model.History,
+			"updated_at": time.Now(),
+		},
+	}
+	res, err := r.collection.UpdateOne(ctx, bson.M{"_id": model.ID, "user_id": app.UserID}, update)
+	if err != nil {
+		return domain.ErrUpdatingDocument
+	}
+	if res.MatchedCount == 0 {
+		return domain.ErrApplicationNotFound
+	}
+	return nil
+}
+
+func (r *applicationRepository) Delete(ctx context.Context, userID, id string) error {
+	oid, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return domain.ErrInvalidApplicationID
+	}
+
+	res, err := r.collection.DeleteOne(ctx, bson.M{"_id": oid, "user_id": userID})
+	if err != nil {
+		return domain.ErrDeletingDocument
+	}
+	if res.DeletedCount == 0 {
+		return domain.ErrApplicationNotFound
+	}
+	return nil
+}
@@ -0,0 +1,195 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/tsigemariamzewdu/JobMate-backend/domain"
+	repo "github.com/tsigemariamzewdu/JobMate-backend/domain/interfaces/repositories"
+	"github.com/tsigemariamzewdu/JobMate-backend/domain/models"
+)
+
+type coverLetterRevisionModel struct {
+	Content   string    `bson:"content"`
+	Source    string    `bson:"source"`
+	CreatedAt time.Time `bson:"created_at"`
+}
+
+type coverLetterModel struct {
+	ID        primitive.ObjectID         `bson:"_id"`
+	UserID    string                     `bson:"user_id"`
+	CVID      string                     `bson:"cv_id"`
+	JobID     string                     `bson:"job_id,omitempty"`
+	Job       jobSnapshotModel           `bson:"job"`
+	Language  string                     `bson:"language"`
+	Content   string                     `bson:"content"`
+	History   []coverLetterRevisionModel `bson:"history"`
+	CreatedAt time.Time                  `bson:"created_at"`
+	UpdatedAt time.Time                  `bson:"updated_at"`
+}
+
+func toDomainCoverLetter(m coverLetterModel) models.CoverLetter {
+	job := toDomainJobSnapshot(m.Job)
+	job.ID = m.JobID
+
+	history := make([]models.CoverLetterRevision, 0, len(m.History))
+	for _, h := range m.History {
+		history = append(history, models.CoverLetterRevision{
+			Content:   h.Content,
+			Source:    models.CoverLetterSource(h.Source),
+			CreatedAt: h.CreatedAt,
+		})
+	}
+
+	return models.CoverLetter{
+		ID:        m.ID.Hex(),
+		UserID:    m.UserID,
+		CVID:      m.CVID,
+		JobID:     m.JobID,
+		Job:       job,
+		Language:  models.Language(m.Language),
+		Content:   m.Content,
+		History:   history,
+		CreatedAt: m.CreatedAt,
+		UpdatedAt: m.UpdatedAt,
+	}
+}
+
+func toCoverLetterModel(d models.CoverLetter) (*coverLetterModel, error) {
+	id := primitive.NewObjectID()
+	if d.ID != "" {
+		var err error
+		id, err = primitive.ObjectIDFromHex(d.ID)
+		if err != nil {
+			return nil, fmt.Errorf("invalid cover letter ID: %w", err)
+		}
+	}
+
+	history := make([]coverLetterRevisionModel, 0, len(d.History))
+	for _, h := range d.History {
+		history = append(history, coverLetterRevisionModel{
+			Content:   h.Content,
+			Source:    string(h.Source),
+			CreatedAt: h.CreatedAt,
+		})
+	}
+
+	return &coverLetterModel{
+		ID:        id,
+		UserID:    d.UserID,
+		CVID:      d.CVID,
+		JobID:     d.JobID,
+		Job:       toJobSnapshotModel(d.Job),
+		Language:  string(d.Language),
+		Content:   d.Content,
+		History:   history,
+		CreatedAt: d.CreatedAt,
+		UpdatedAt: d.UpdatedAt,
+	}, nil
+}
+
+type coverLetterRepository struct {
+	collection *mongo.Collection
+}
+
+func NewCoverLetterRepository(db *mongo.Database) repo.ICoverLetterRepository {
+	return &coverLetterRepository{collection: db.Collection("cover_letters")}
+}
+
+func (r *coverLetterRepository) Create(ctx context.Context, letter *models.CoverLetter) (string, error) {
+	model, err := toCoverLetterModel(*letter)
+	if err != nil {
+		return "", err
+	}
+
+	if _, err := r.collection.InsertOne(ctx, model); err != nil {
+		return "", fmt.Errorf("failed to insert cover letter: %w", err)
+	}
+	return model.ID.Hex(), nil
+}
+
+func (r *coverLetterRepository) GetByID(ctx context.Context, userID, id string) (*models.CoverLetter, error) {
+	oid, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, domain.ErrInvalidCoverLetterID
+	}
+
+	var model coverLetterModel
+	err = r.collection.FindOne(ctx, bson.M{"_id": oid, "user_id": userID}).Decode(&model)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, domain.ErrCoverLetterNotFound
+		}
+		return nil, err
+	}
+
+	letter := toDomainCoverLetter(model)
+	return &letter, nil
+}
+
+func (r *coverLetterRepository) ListByUser(ctx context.Context, userID string) ([]models.CoverLetter, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "updated_at", Value: -1}})
+
+	cursor, err := r.collection.Find(ctx, bson.M{"user_id": userID}, opts)
+	if err != nil {
+		return nil, domain.ErrQueryFailed
+	}
+	defer cursor.Close(ctx)
+
+	var docs []coverLetterModel
+	if err := cursor.All(ctx, &docs); err != nil {
+		return nil, domain.ErrDocumentDecoding
+	}
+
+	letters := make([]models.CoverLetter, 0, len(docs))
+	for _, d := range docs {
+		letters = append(letters, toDomainCoverLetter(d))
+	}
+	return letters, nil
+}
+
+func (r *coverLetterRepository) Update(ctx context.Context, letter *models.CoverLetter) error {
+	model, err := toCoverLetterModel(*letter)
+	if err != nil {
+		return domain.ErrInvalidCoverLetterID
+	}
+
+	update := bson.M{
+		"$set": bson.M{
+			"content":    model.Content,
+			"history":    model.History,
+			"updated_at": model.UpdatedAt,
+		},
+	}
+	res, err := r.collection.UpdateOne(ctx, bson.M{"_id": model.ID, "user_id": letter.UserID}, update)
+	if err != nil {
+		return domain.ErrUpdatingDocument
+	}
+	if res.MatchedCount == 0 {
+		return domain.ErrCoverLetterNotFound
+	}
+	return nil
+}
+
+func (r *coverLetterRepository) Delete(ctx context.Context, userID, id string) error {
+	oid, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return domain.ErrInvalidCoverLetterID
+	}
+
+	res, err := r.collection.DeleteOne(ctx, bson.M{"_id": oid, "user_id": userID})
+	if err != nil {
+		return domain.ErrDeletingDocument
+	}
+	if res.DeletedCount == 0 {
+		return domain.ErrCoverLetterNotFound
+	}
+	return nil
+}
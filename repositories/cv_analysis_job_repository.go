@@ -0,0 +1,256 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/tsigemariamzewdu/JobMate-backend/domain"
+	repo "github.com/tsigemariamzewdu/JobMate-backend/domain/interfaces/repositories"
+	"github.com/tsigemariamzewdu/JobMate-backend/domain/models"
+)
+
+// cvAnalysisResultModel has the same shape as models.AISuggestions, so the two convert directly.
+// That keeps the structured entries as domain types, stored under the driver's lowercase keys.
+type cvAnalysisResultModel struct {
+	CVs struct {
+		ExtractedSkills     []string                 `bson:"extracted_skills"`
+		ExtractedExperience []string                 `bson:"extracted_experience"`
+		ExtractedEducation  []string                 `bson:"extracted_education"`
+		Experience          []models.ExperienceEntry `bson:"experience"`
+		Education           []models.EducationEntry  `bson:"education"`
+		Summary             string                   `bson:"summary"`
+	} `bson:"cv"`
+	CVFeedback struct {
+		Strengths              string `bson:"strengths"`
+		Weaknesses             string `bson:"weaknesses"`
+		ImprovementSuggestions string `bson:"improvement_suggestions"`
+	} `bson:"cv_feedback"`
+	SkillGaps []struct {
+		SkillName              string `bson:"skill_name"`
+		CurrentLevel           int    `bson:"current_level"`
+		RecommendedLevel       int    `bson:"recommended_level"`
+		Importance             string `bson:"importance"`
+		ImprovementSuggestions string `bson:"improvement_suggestions"`
+	} `bson:"skill_gaps"`
+}
+
+type cvAnalysisJobModel struct {
+	ID     primitive.ObjectID `bson:"_id"`
+	UserID string             `bson:"user_id"`
+	CVID   string             `bson:"cv_id"`
+	Status string             `bson:"status"`
+	// Active is true while the job is queued or running; a unique partial index on
+	// (cv_id, active) keeps a CV from being analyzed twice at once
+	Active     bool                   `bson:"active"`
+	Attempts   int                    `bson:"attempts"`
+	Error      string                 `bson:"error,omitempty"`
+	Result     *cvAnalysisResultModel `bson:"result,omitempty"`
+	TargetJob  string                 `bson:"target_job_id,omitempty"`
+	Score      *cvScoreModel          `bson:"score,omitempty"`
+	CreatedAt  time.Time              `bson:"created_at"`
+	StartedAt  *time.Time             `bson:"started_at,omitempty"`
+	FinishedAt *time.Time             `bson:"finished_at,omitempty"`
+	UpdatedAt  time.Time              `bson:"updated_at"`
+}
+
+func toDomainCVAnalysisJob(m cvAnalysisJobModel) *models.CVAnalysisJob {
+	job := &models.CVAnalysisJob{
+		ID:          m.ID.Hex(),
+		UserID:      m.UserID,
+		CVID:        m.CVID,
+		Status:      models.CVAnalysisStatus(m.Status),
+		Attempts:    m.Attempts,
+		Error:       m.Error,
+		TargetJobID: m.TargetJob,
+		Score:       toDomainCVScore(m.Score),
+		CreatedAt:   m.CreatedAt,
+		StartedAt:   m.StartedAt,
+		FinishedAt:  m.FinishedAt,
+		UpdatedAt:   m.UpdatedAt,
+	}
+	if m.Result != nil {
+		result := models.AISuggestions(*m.Result)
+		job.Result = &result
+	}
+	return job
+}
+
+type cvAnalysisJobRepository struct {
+	collection *mongo.Collection
+}
+
+func NewCVAnalysisJobRepository(db *mongo.Database) repo.CVAnalysisJobRepository {
+	return &cvAnalysisJobRepository{collection: db.Collection("cv_analysis_jobs")}
+}
+
+func (r *cvAnalysisJobRepository) EnsureIndexes(ctx context.Context) error {
+	// analyses that succeeded while completion still kept them active would block their CV forever
+	if _, err := r.collection.UpdateMany(ctx,
+		bson.M{"status": string(models.CVAnalysisSucceeded), "active": true},
+		bson.M{"$set": bson.M{"active": false}},
+	); err != nil {
+		return err
+	}
+
+	_, err := r.collection.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{
+			Keys: bson.D{{Key: "cv_id", Value: 1}},
+			Options: options.Index().
+				SetUnique(true).
+				SetPartialFilterExpression(bson.M{"active": true}).
+				SetName("cv_id_active_unique"),
+		},
+		{Keys: bson.D{{Key: "cv_id", Value: 1}, {Key: "created_at", Value: -1}}},
+		{Keys: bson.D{{Key: "status", Value: 1}, {Key: "created_at", Value: 1}}},
+		{Keys: bson.D{{Key: "user_id", Value: 1}, {Key: "status", Value: 1}, {Key: "finished_at", Value: -1}}},
+	})
+	return err
+}
+
+func (r *cvAnalysisJobRepository) CreateOrGetActive(ctx context.Context, job *models.CVAnalysisJob) (*models.CVAnalysisJob, bool, error) {
+	m := cvAnalysisJobModel{
+		ID:        primitive.NewObjectID(),
+		UserID:    job.UserID,
+		CVID:      job.CVID,
+		Status:    string(models.CVAnalysisQueued),
+		Active:    true,
+		TargetJob: job.TargetJobID,
+		CreatedAt: job.CreatedAt,
+		UpdatedAt: job.UpdatedAt,
+	}
+
+	opts := options.FindOneAndUpdate().SetUpsert(true).SetReturnDocument(options.After)
+	filter := bson.M{"cv_id": job.CVID, "active": true}
+	var doc cvAnalysisJobModel
+	err := r.collection.FindOneAndUpdate(ctx, filter, bson.M{"$setOnInsert": m}, opts).Decode(&doc)
+	if mongo.IsDuplicateKeyError(err) {
+		// a concurrent request inserted first; the unique index rejected this one
+		err = r.collection.FindOne(ctx, filter).Decode(&doc)
+	}
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to queue CV analysis: %w", err)
+	}
+	return toDomainCVAnalysisJob(doc), doc.ID == m.ID, nil
+}
+
+func (r *cvAnalysisJobRepository) ClaimNext(ctx context.Context, staleBefore time.Time) (*models.CVAnalysisJob, error) {
+	now := time.Now()
+	filter := bson.M{"$or": []bson.M{
+		{"status": string(models.CVAnalysisQueued)},
+		{"status": string(models.CVAnalysisRunning), "started_at": bson.M{"$lt": staleBefore}},
+	}}
+	update := bson.M{
+		"$set": bson.M{"status": string(models.CVAnalysisRunning), "started_at": now, "updated_at": now},
+		"$inc": bson.M{"attempts": 1},
+	}
+	opts := options.FindOneAndUpdate().
+		SetSort(bson.D{{Key: "created_at", Value: 1}}).
+		SetReturnDocument(options.After)
+
+	var doc cvAnalysisJobModel
+	if err := r.collection.FindOneAndUpdate(ctx, filter, update, opts).Decode(&doc); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, domain.ErrCVAnalysisNotFound
+		}
+		return nil, fmt.Errorf("failed to claim CV analysis: %w", err)
+	}
+	return toDomainCVAnalysisJob(doc), nil
+}
+
+func (r *cvAnalysisJobRepository) Complete(ctx context.Context, id string, result *models.AISuggestions, score *models.CVScore) error {
+	var stored *cvAnalysisResultModel
+	if result != nil {
+		m := cvAnalysisResultModel(*result)
+		stored = &m
+	}
+	now := time.Now()
+	return r.finish(ctx, id, bson.M{
+		"status":      string(models.CVAnalysisSucceeded),
+		"active":      false,
+		"result":      stored,
+		"score":       toCVScoreModel(score),
+		"finished_at": now,
+		"updated_at":  now,
+	})
+}
+
+func (r *cvAnalysisJobRepository) Fail(ctx context.Context, id string, reason string) error {
+	now := time.Now()
+	return r.finish(ctx, id, bson.M{
+		"status":      string(models.CVAnalysisFailed),
+		"active":      false,
+		"error":       reason,
+		"finished_at": now,
+		"updated_at":  now,
+	})
+}
+
+func (r *cvAnalysisJobRepository) finish(ctx context.Context, id string, set bson.M) error {
+	oid, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return domain.ErrCVAnalysisNotFound
+	}
+	res, err := r.collection.UpdateByID(ctx, oid, bson.M{"$set": set})
+	if err != nil {
+		return domain.ErrUpdatingDocument
+	}
+	if res.MatchedCount == 0 {
+		return domain.ErrCVAnalysisNotFound
+	}
+	return nil
+}
+
+func (r *cvAnalysisJobRepository) GetLatestByCVID(ctx context.Context, cvID string) (*models.CVAnalysisJob, error) {
+	opts := options.FindOne().SetSort(bson.D{{Key: "created_at", Value: -1}})
+	var doc cvAnalysisJobModel
+	if err := r.collection.FindOne(ctx, bson.M{"cv_id": cvID}, opts).Decode(&doc); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, domain.ErrCVAnalysisNotFound
+		}
+		return nil, err
+	}
+	return toDomainCVAnalysisJob(doc), nil
+}
+
+func (r *cvAnalysisJobRepository) GetByID(ctx context.Context, id string) (*models.CVAnalysisJob, error) {
+	oid, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, domain.ErrCVAnalysisNotFound
+	}
+
+	var doc cvAnalysisJobModel
+	if err := r.collection.FindOne(ctx, bson.M{"_id": oid}).Decode(&doc); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, domain.ErrCVAnalysisNotFound
+		}
+		return nil, err
+	}
+	return toDomainCVAnalysisJob(doc), nil
+}
+
+func (r *cvAnalysisJobRepository) ListSucceededByUserID(ctx context.Context, userID string, limit int64) ([]models.CVAnalysisJob, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "finished_at", Value: -1}}).SetLimit(limit)
+	cursor, err := r.collection.Find(ctx, bson.M{"user_id": userID, "status": string(models.CVAnalysisSucceeded)}, opts)
+	if err != nil {
+		return nil, domain.ErrQueryFailed
+	}
+	defer cursor.Close(ctx)
+
+	var docs []cvAnalysisJobModel
+	if err := cursor.All(ctx, &docs); err != nil {
+		return nil, domain.ErrDocumentDecoding
+	}
+
+	jobs := make([]models.CVAnalysisJob, 0, len(docs))
+	for _, d := range docs {
+		jobs = append(jobs, *toDomainCVAnalysisJob(d))
+	}
+	return jobs, nil
+}
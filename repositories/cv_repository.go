@@ -13,35 +13,152 @@ import (
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
 type cvModel struct {
-	ID                  primitive.ObjectID `bson:"_id"`
-	UserID              string             `bson:"user_id"`
-	FileName            string             `bson:"file_name"`
-	OriginalText        string             `bson:"original_text"`
-	ExtractedSkills     []string           `bson:"extracted_skills"`
-	ExtractedExperience []string           `bson:"extracted_experience"`
-	ExtractedEducation  []string           `bson:"extracted_education"`
-	Summary             string             `bson:"summary"`
-	IsActive            bool               `bson:"is_active"`
-	CreatedAt           time.Time          `bson:"created_at"`
-	UpdatedAt           time.Time          `bson:"updated_at"`
+	ID                  primitive.ObjectID  `bson:"_id"`
+	UserID              string              `bson:"user_id"`
+	Version             int                 `bson:"version"`
+	FileName            string              `bson:"file_name"`
+	File                *cvFileModel        `bson:"file,omitempty"`
+	OriginalText        string              `bson:"original_text"`
+	ExtractedSkills     []string            `bson:"extracted_skills"`
+	ExtractedExperience []string            `bson:"extracted_experience"`
+	ExtractedEducation  []string            `bson:"extracted_education"`
+	Experience          []cvExperienceModel `bson:"experience,omitempty"`
+	Education           []cvEducationModel  `bson:"education,omitempty"`
+	Summary             string              `bson:"summary"`
+	IsActive            bool                `bson:"is_active"`
+	ActivatedAt         *time.Time          `bson:"activated_at,omitempty"` // orders racing activations
+	CreatedAt           time.Time           `bson:"created_at"`
+	UpdatedAt           time.Time           `bson:"updated_at"`
+	DeletedAt           *time.Time          `bson:"deleted_at,omitempty"`
 }
 
+type cvFileModel struct {
+	Key         string    `bson:"key"`
+	ContentType string    `bson:"content_type"`
+	Size        int64     `bson:"size"`
+	Checksum    string    `bson:"checksum"`
+	CreatedAt   time.Time `bson:"created_at"`
+}
+
+type cvExperienceModel struct {
+	Title        string      `bson:"title"`
+	Organization string      `bson:"organization"`
+	Start        cvDateModel `bson:"start"`
+	End          cvDateModel `bson:"end"`
+	Current      bool        `bson:"current"`
+	Description  string      `bson:"description"`
+}
+
+type cvDateModel struct {
+	Year  int `bson:"year"`
+	Month int `bson:"month,omitempty"`
+}
+
+type cvEducationModel struct {
+	Institution    string `bson:"institution"`
+	Degree         string `bson:"degree"`
+	Level          string `bson:"level"`
+	FieldOfStudy   string `bson:"field_of_study"`
+	StartYear      int    `bson:"start_year,omitempty"`
+	GraduationYear int    `bson:"graduation_year,omitempty"`
+}
+
+func toExperienceModels(entries []models.ExperienceEntry) []cvExperienceModel {
+	var out []cvExperienceModel
+	for _, e := range entries {
+		out = append(out, cvExperienceModel{
+			Title:        e.Title,
+			Organization: e.Organization,
+			Start:        cvDateModel(e.Start),
+			End:          cvDateModel(e.End),
+			Current:      e.Current,
+			Description:  e.Description,
+		})
+	}
+	return out
+}
+
+func toDomainExperience(docs []cvExperienceModel) []models.ExperienceEntry {
+	var out []models.ExperienceEntry
+	for _, d := range docs {
+		out = append(out, models.ExperienceEntry{
+			Title:        d.Title,
+			Organization: d.Organization,
+			Start:        models.YearMonth(d.Start),
+			End:          models.YearMonth(d.End),
+			Current:      d.Current,
+			Description:  d.Description,
+		})
+	}
+	return out
+}
+
+func toEducationModels(entries []models.EducationEntry) []cvEducationModel {
+	var out []cvEducationModel
+	for _, e := range entries {
+		out = append(out, cvEducationModel{
+			Institution:    e.Institution,
+			Degree:         e.Degree,
+			Level:          string(e.Level),
+			FieldOfStudy:   e.FieldOfStudy,
+			StartYear:      e.StartYear,
+			GraduationYear: e.GraduationYear,
+		})
+	}
+	return out
+}
+
+func toDomainEducation(docs []cvEducationModel) []models.EducationEntry {
+	var out []models.EducationEntry
+	for _, d := range docs {
+		out = append(out, models.EducationEntry{
+			Institution:    d.Institution,
+			Degree:         d.Degree,
+			Level:          models.EducationLevel(d.Level),
+			FieldOfStudy:   d.FieldOfStudy,
+			StartYear:      d.StartYear,
+			GraduationYear: d.GraduationYear,
+		})
+	}
+	return out
+}
+
+// notDeleted matches CVs that have not been soft deleted
+var notDeleted = bson.M{"$exists": false}
+
 func toDomainCV(m cvModel) *models.CV {
+	var file *models.BlobInfo
+	if m.File != nil {
+		file = &models.BlobInfo{
+			Key:         m.File.Key,
+			ContentType: m.File.ContentType,
+			Size:        m.File.Size,
+			Checksum:    m.File.Checksum,
+			CreatedAt:   m.File.CreatedAt,
+		}
+	}
+
 	return &models.CV{
 		ID:                  m.ID.Hex(),
 		UserID:              m.UserID,
+		Version:             m.Version,
 		FileName:            m.FileName,
+		File:                file,
 		OriginalText:        m.OriginalText,
 		ExtractedSkills:     m.ExtractedSkills,
 		ExtractedExperience: m.ExtractedExperience,
 		ExtractedEducation:  m.ExtractedEducation,
+		Experience:          toDomainExperience(m.Experience),
+		Education:           toDomainEducation(m.Education),
 		Summary:             m.Summary,
 		IsActive:            m.IsActive,
 		CreatedAt:           m.CreatedAt,
 		UpdatedAt:           m.UpdatedAt,
+		DeletedAt:           m.DeletedAt,
 	}
 }
 
@@ -55,18 +172,34 @@ func toCVModel(d models.CV) (*cvModel, error) {
 		}
 	}
 
+	var file *cvFileModel
+	if d.File != nil {
+		file = &cvFileModel{
+			Key:         d.File.Key,
+			ContentType: d.File.ContentType,
+			Size:        d.File.Size,
+			Checksum:    d.File.Checksum,
+			CreatedAt:   d.File.CreatedAt,
+		}
+	}
+
 	return &cvModel{
 		ID:                  id,
 		UserID:              d.UserID,
+		Version:             d.Version,
 		FileName:            d.FileName,
+		File:                file,
 		OriginalText:        d.OriginalText,
 		ExtractedSkills:     d.ExtractedSkills,
 		ExtractedExperience: d.ExtractedExperience,
 		ExtractedEducation:  d.ExtractedEducation,
+		Experience:          toExperienceModels(d.Experience),
+		Education:           toEducationModels(d.Education),
 		Summary:             d.Summary,
 		IsActive:            d.IsActive,
 		CreatedAt:           d.CreatedAt,
 		UpdatedAt:           d.UpdatedAt,
+		DeletedAt:           d.DeletedAt,
 	}, nil
 }
 
@@ -78,20 +211,70 @@ func NewCVRepository(db *mongo.Database) repo.CVRepository {
 	return &cvRepository{collection: db.Collection("cvs")}
 }
 
+// maxVersionAttempts bounds retries when concurrent uploads pick the same version
+const maxVersionAttempts = 3
+
+func (r *cvRepository) EnsureIndexes(ctx context.Context) error {
+	_, err := r.collection.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{
+			// CVs stored before versioning all have version 0
+			Keys: bson.D{{Key: "user_id", Value: 1}, {Key: "version", Value: -1}},
+			Options: options.Index().
+				SetUnique(true).
+				SetPartialFilterExpression(bson.M{"version": bson.M{"$gt": 0}}),
+		},
+		{Keys: bson.D{{Key: "user_id", Value: 1}, {Key: "is_active", Value: -1}, {Key: "updated_at", Value: -1}}},
+	})
+	return err
+}
+
 func (r *cvRepository) Create(ctx context.Context, cv *models.CV) (string, error) {
 	model, err := toCVModel(*cv)
 	if err != nil {
 		return "", err
 	}
+	if model.IsActive {
+		activatedAt := time.Now()
+		model.ActivatedAt = &activatedAt
+	}
 
-	_, err = r.collection.InsertOne(ctx, model)
-	if err != nil {
-		return "", fmt.Errorf("failed to insert CV: %w", err)
+	for attempt := 1; ; attempt++ {
+		model.Version, err = r.nextVersion(ctx, cv.UserID)
+		if err != nil {
+			return "", err
+		}
+
+		_, err = r.collection.InsertOne(ctx, model)
+		if err == nil {
+			break
+		}
+		if !mongo.IsDuplicateKeyError(err) || attempt == maxVersionAttempts {
+			return "", fmt.Errorf("failed to insert CV: %w", err)
+		}
 	}
 
+	cv.Version = model.Version
 	return model.ID.Hex(), nil
 }
 
+// nextVersion numbers a new CV after every CV the user has stored, deleted or not
+func (r *cvRepository) nextVersion(ctx context.Context, userID string) (int, error) {
+	filter := bson.M{"user_id": userID}
+	count, err := r.collection.CountDocuments(ctx, filter)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count CVs: %w", err)
+	}
+
+	var latest cvModel
+	opts := options.FindOne().SetSort(bson.D{{Key: "version", Value: -1}}).SetProjection(bson.M{"version": 1})
+	err = r.collection.FindOne(ctx, filter, opts).Decode(&latest)
+	if err != nil && !errors.Is(err, mongo.ErrNoDocuments) {
+		return 0, fmt.Errorf("failed to find latest CV version: %w", err)
+	}
+
+	return max(int(count), latest.Version) + 1, nil
+}
+
 func (r *cvRepository) GetByID(ctx context.Context, id string) (*models.CV, error) {
 	oid, err := primitive.ObjectIDFromHex(id)
 	if err != nil {
@@ -99,7 +282,7 @@ func (r *cvRepository) GetByID(ctx context.Context, id string) (*models.CV, erro
 	}
 
 	var model cvModel
-	err = r.collection.FindOne(ctx, bson.M{"_id": oid}).Decode(&model)
+	err = r.collection.FindOne(ctx, bson.M{"_id": oid, "deleted_at": notDeleted}).Decode(&model)
 	if err != nil {
 		if errors.Is(err, mongo.ErrNoDocuments) {
 			return nil, domain.ErrCVNotFound
@@ -116,12 +299,14 @@ func (r *cvRepository) Update(ctx context.Context, cv *models.CV) error {
 		return domain.ErrInvalidCVID
 	}
 
-	filter := bson.M{"_id": oid}
+	filter := bson.M{"_id": oid, "deleted_at": notDeleted}
 	update := bson.M{
 		"$set": bson.M{
 			"extracted_skills":     cv.ExtractedSkills,
 			"extracted_experience": cv.ExtractedExperience,
 			"extracted_education":  cv.ExtractedEducation,
+			"experience":           toExperienceModels(cv.Experience),
+			"education":            toEducationModels(cv.Education),
 			"summary":              cv.Summary,
 			"updated_at":           time.Now(),
 		},
@@ -136,3 +321,106 @@ func (r *cvRepository) Update(ctx context.Context, cv *models.CV) error {
 	}
 	return nil
 }
+
+func (r *cvRepository) GetLatestAnalyzedByUserID(ctx context.Context, userID string) (*models.CV, error) {
+	filter := bson.M{
+		"user_id":            userID,
+		"extracted_skills.0": bson.M{"$exists": true},
+		"deleted_at":         notDeleted,
+	}
+	opts := options.FindOne().SetSort(bson.D{{Key: "is_active", Value: -1}, {Key: "activated_at", Value: -1}, {Key: "updated_at", Value: -1}})
+
+	var model cvModel
+	err := r.collection.FindOne(ctx, filter, opts).Decode(&model)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, domain.ErrCVNotFound
+		}
+		return nil, err
+	}
+
+	return toDomainCV(model), nil
+}
+
+func (r *cvRepository) ListByUserID(ctx context.Context, userID string) ([]models.CV, error) {
+	opts := options.Find().
+		SetSort(bson.D{{Key: "version", Value: -1}, {Key: "created_at", Value: -1}}).
+		SetProjection(bson.M{"original_text": 0})
+
+	cursor, err := r.collection.Find(ctx, bson.M{"user_id": userID, "deleted_at": notDeleted}, opts)
+	if err != nil {
+		return nil, domain.ErrQueryFailed
+	}
+	defer cursor.Close(ctx)
+
+	var docs []cvModel
+	if err := cursor.All(ctx, &docs); err != nil {
+		return nil, domain.ErrDocumentDecoding
+	}
+
+	cvs := make([]models.CV, 0, len(docs))
+	for _, d := range docs {
+		cvs = append(cvs, *toDomainCV(d))
+	}
+	return cvs, nil
+}
+
+// SetActive marks the CV active with a fresh activated_at and then deactivates the user's
+// other CVs activated before it. Each step is a single conditional write, so it needs no
+// transaction, and because only older activations are cleared, two racing calls always
+// leave the later one active.
+func (r *cvRepository) SetActive(ctx context.Context, userID, cvID string) error {
+	oid, err := primitive.ObjectIDFromHex(cvID)
+	if err != nil {
+		return domain.ErrInvalidCVID
+	}
+
+	now := time.Now()
+	res, err := r.collection.UpdateOne(ctx,
+		bson.M{"_id": oid, "user_id": userID, "deleted_at": notDeleted},
+		bson.M{"$set": bson.M{"is_active": true, "activated_at": now, "updated_at": now}},
+	)
+	if err != nil {
+		return domain.ErrUpdatingDocument
+	}
+	if res.MatchedCount == 0 {
+		return domain.ErrCVNotFound
+	}
+
+	_, err = r.collection.UpdateMany(ctx,
+		bson.M{
+			"user_id":   userID,
+			"_id":       bson.M{"$ne": oid},
+			"is_active": true,
+			"$or": []bson.M{
+				{"activated_at": bson.M{"$lt": now}},
+				{"activated_at": bson.M{"$exists": false}},
+			},
+		},
+		bson.M{"$set": bson.M{"is_active": false, "updated_at": now}},
+	)
+	if err != nil {
+		return domain.ErrUpdatingDocument
+	}
+	return nil
+}
+
+func (r *cvRepository) SoftDelete(ctx context.Context, userID, cvID string) error {
+	oid, err := primitive.ObjectIDFromHex(cvID)
+	if err != nil {
+		return domain.ErrInvalidCVID
+	}
+
+	now := time.Now()
+	res, err := r.collection.UpdateOne(ctx,
+		bson.M{"_id": oid, "user_id": userID, "deleted_at": notDeleted},
+		bson.M{"$set": bson.M{"is_active": false, "deleted_at": now, "updated_at": now}},
+	)
+	if err != nil {
+		return domain.ErrUpdatingDocument
+	}
+	if res.MatchedCount == 0 {
+		return domain.ErrCVNotFound
+	}
+	return nil
+}
@@ -2,13 +2,81 @@ package repositories
 
 import (
 	"context"
+	"time"
 
+	"github.com/tsigemariamzewdu/JobMate-backend/domain"
 	repo "github.com/tsigemariamzewdu/JobMate-backend/domain/interfaces/repositories"
 	"github.com/tsigemariamzewdu/JobMate-backend/domain/models"
+	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
+type feedbackModel struct {
+	ID                     primitive.ObjectID `bson:"_id"`
+	UserID                 string             `bson:"user_id"`
+	CVID                   string             `bson:"cv_id"`
+	AnalysisID             string             `bson:"analysis_id,omitempty"`
+	Strengths              string             `bson:"strengths"`
+	Weaknesses             string             `bson:"weaknesses"`
+	ImprovementSuggestions string             `bson:"improvement_suggestions"`
+	Score                  *cvScoreModel      `bson:"score,omitempty"`
+	GeneratedAt            time.Time          `bson:"generated_at"`
+}
+
+// cvScoreModel is also stored on analysis jobs, so both carry the same rubric result
+type cvScoreModel struct {
+	Score       int            `bson:"score"`
+	TargetJobID string         `bson:"target_job_id,omitempty"`
+	Checks      []cvCheckModel `bson:"checks"`
+}
+
+type cvCheckModel struct {
+	Check     string `bson:"check"`
+	Passed    bool   `bson:"passed"`
+	Skipped   bool   `bson:"skipped,omitempty"`
+	Points    int    `bson:"points"`
+	MaxPoints int    `bson:"max_points"`
+	Detail    string `bson:"detail"`
+}
+
+func toCVScoreModel(s *models.CVScore) *cvScoreModel {
+	if s == nil {
+		return nil
+	}
+	m := &cvScoreModel{Score: s.Score, TargetJobID: s.TargetJobID, Checks: make([]cvCheckModel, 0, len(s.Checks))}
+	for _, c := range s.Checks {
+		m.Checks = append(m.Checks, cvCheckModel{
+			Check:     string(c.Check),
+			Passed:    c.Passed,
+			Skipped:   c.Skipped,
+			Points:    c.Points,
+			MaxPoints: c.MaxPoints,
+			Detail:    c.Detail,
+		})
+	}
+	return m
+}
+
+func toDomainCVScore(m *cvScoreModel) *models.CVScore {
+	if m == nil {
+		return nil
+	}
+	s := &models.CVScore{Score: m.Score, TargetJobID: m.TargetJobID, Checks: make([]models.CVCheckResult, 0, len(m.Checks))}
+	for _, c := range m.Checks {
+		s.Checks = append(s.Checks, models.CVCheckResult{
+			Check:     models.CVCheck(c.Check),
+			Passed:    c.Passed,
+			Skipped:   c.Skipped,
+			Points:    c.Points,
+			MaxPoints: c.MaxPoints,
+			Detail:    c.Detail,
+		})
+	}
+	return s
+}
+
 type feedbackRepository struct {
 	collection *mongo.Collection
 }
@@ -23,11 +91,15 @@ func (r *feedbackRepository) Create(ctx context.Context, f *models.CVFeedback) (
 	doc := map[string]interface{}{
 		"user_id":                 f.UserID,
 		"cv_id":                   f.CVID,
+		"analysis_id":             f.AnalysisID,
 		"strengths":               f.Strengths,
 		"weaknesses":              f.Weaknesses,
 		"improvement_suggestions": f.ImprovementSuggestions,
 		"generated_at":            f.GeneratedAt,
 	}
+	if f.Score != nil {
+		doc["score"] = toCVScoreModel(f.Score)
+	}
 
 	res, err := r.collection.InsertOne(ctx, doc)
 	if err != nil {
@@ -37,3 +109,33 @@ func (r *feedbackRepository) Create(ctx context.Context, f *models.CVFeedback) (
 	id := res.InsertedID.(primitive.ObjectID).Hex()
 	return id, nil
 }
+
+func (r *feedbackRepository) ListByCVID(ctx context.Context, cvID string) ([]models.CVFeedback, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "generated_at", Value: -1}})
+	cursor, err := r.collection.Find(ctx, bson.M{"cv_id": cvID}, opts)
+	if err != nil {
+		return nil, domain.ErrQueryFailed
+	}
+	defer cursor.Close(ctx)
+
+	var docs []feedbackModel
+	if err := cursor.All(ctx, &docs); err != nil {
+		return nil, domain.ErrDocumentDecoding
+	}
+
+	feedback := make([]models.CVFeedback, 0, len(docs))
+	for _, d := range docs {
+		feedback = append(feedback, models.CVFeedback{
+			ID:                     d.ID.Hex(),
+			UserID:                 d.UserID,
+			CVID:                   d.CVID,
+			AnalysisID:             d.AnalysisID,
+			Strengths:              d.Strengths,
+			Weaknesses:             d.Weaknesses,
+			ImprovementSuggestions: d.ImprovementSuggestions,
+			Score:                  toDomainCVScore(d.Score),
+			GeneratedAt:            d.GeneratedAt,
+		})
+	}
+	return feedback, nil
+}
@@ -0,0 +1,245 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/tsigemariamzewdu/JobMate-backend/domain"
+	repo "github.com/tsigemariamzewdu/JobMate-backend/domain/interfaces/repositories"
+	"github.com/tsigemariamzewdu/JobMate-backend/domain/models"
+)
+
+type jobAlertModel struct {
+	ID               primitive.ObjectID `bson:"_id"`
+	UserID           string             `bson:"user_id"`
+	Field            string             `bson:"field"`
+	LookingFor       string             `bson:"looking_for"`
+	Skills           []string           `bson:"skills"`
+	Language         string             `bson:"language"`
+	Frequency        string             `bson:"frequency"`
+	Channels         []string           `bson:"channels"`
+	Email            string             `bson:"email,omitempty"`
+	Phone            string             `bson:"phone,omitempty"`
+	UnsubscribeToken string             `bson:"unsubscribe_token"`
+	Active           bool               `bson:"active"`
+	LastSentAt       *time.Time         `bson:"last_sent_at,omitempty"`
+	NextRunAt        time.Time          `bson:"next_run_at"`
+	CreatedAt        time.Time          `bson:"created_at"`
+	UpdatedAt        time.Time          `bson:"updated_at"`
+}
+
+// alertDeliveryModel records one job sent for one alert
+type alertDeliveryModel struct {
+	AlertID string    `bson:"alert_id"`
+	JobKey  string    `bson:"job_key"`
+	SentAt  time.Time `bson:"sent_at"`
+}
+
+func toDomainJobAlert(m jobAlertModel) models.JobAlert {
+	channels := make([]models.AlertChannel, 0, len(m.Channels))
+	for _, c := range m.Channels {
+		channels = append(channels, models.AlertChannel(c))
+	}
+	return models.JobAlert{
+		ID:               m.ID.Hex(),
+		UserID:           m.UserID,
+		Field:            m.Field,
+		LookingFor:       m.LookingFor,
+		Skills:           m.Skills,
+		Language:         m.Language,
+		Frequency:        models.AlertFrequency(m.Frequency),
+		Channels:         channels,
+		Email:            m.Email,
+		Phone:            m.Phone,
+		UnsubscribeToken: m.UnsubscribeToken,
+		Active:           m.Active,
+		LastSentAt:       m.LastSentAt,
+		NextRunAt:        m.NextRunAt,
+		CreatedAt:        m.CreatedAt,
+		UpdatedAt:        m.UpdatedAt,
+	}
+}
+
+type jobAlertRepository struct {
+	collection *mongo.Collection
+	deliveries *mongo.Collection
+}
+
+func NewJobAlertRepository(db *mongo.Database) repo.IJobAlertRepository {
+	return &jobAlertRepository{
+		collection: db.Collection("job_alerts"),
+		deliveries: db.Collection("job_alert_deliveries"),
+	}
+}
+
+func (r *jobAlertRepository) Create(ctx context.Context, alert *models.JobAlert) (string, error) {
+	channels := make([]string, 0, len(alert.Channels))
+	for _, c := range alert.Channels {
+		channels = append(channels, string(c))
+	}
+	model := jobAlertModel{
+		ID:               primitive.NewObjectID(),
+		UserID:           alert.UserID,
+		Field:            alert.Field,
+		LookingFor:       alert.LookingFor,
+		Skills:           alert.Skills,
+		Language:         alert.Language,
+		Frequency:        string(alert.Frequency),
+		Channels:         channels,
+		Email:            alert.Email,
+		Phone:            alert.Phone,
+		UnsubscribeToken: alert.UnsubscribeToken,
+		Active:           alert.Active,
+		NextRunAt:        alert.NextRunAt,
+		CreatedAt:        alert.CreatedAt,
+		UpdatedAt:        alert.UpdatedAt,
+	}
+
+	if _, err := r.collection.InsertOne(ctx, model); err != nil {
+		return "", fmt.Errorf("failed to insert job alert: %w", err)
+	}
+	return model.ID.Hex(), nil
+}
+
+func (r *jobAlertRepository) ListByUser(ctx context.Context, userID string) ([]models.JobAlert, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "created_at", Value: -1}})
+	return r.find(ctx, bson.M{"user_id": userID}, opts)
+}
+
+func (r *jobAlertRepository) Delete(ctx context.Context, userID, id string) error {
+	oid, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return domain.ErrInvalidAlertID
+	}
+
+	res, err := r.collection.DeleteOne(ctx, bson.M{"_id": oid, "user_id": userID})
+	if err != nil {
+		return domain.ErrDeletingDocument
+	}
+	if res.DeletedCount == 0 {
+		return domain.ErrAlertNotFound
+	}
+
+	if _, err := r.deliveries.DeleteMany(ctx, bson.M{"alert_id": id}); err != nil {
+		return domain.ErrDeletingDocument
+	}
+	return nil
+}
+
+func (r *jobAlertRepository) DeactivateByToken(ctx context.Context, token string) error {
+	update := bson.M{"$set": bson.M{"active": false, "updated_at": time.Now()}}
+	res, err := r.collection.UpdateOne(ctx, bson.M{"unsubscribe_token": token}, update)
+	if err != nil {
+		return domain.ErrUpdatingDocument
+	}
+	if res.MatchedCount == 0 {
+		return domain.ErrInvalidUnsubscribe
+	}
+	return nil
+}
+
+func (r *jobAlertRepository) ClaimDue(ctx context.Context, now, lockedUntil time.Time) (*models.JobAlert, error) {
+	filter := bson.M{"active": true, "next_run_at": bson.M{"$lte": now}}
+	update := bson.M{"$set": bson.M{"next_run_at": lockedUntil, "updated_at": now}}
+	opts := options.FindOneAndUpdate().SetSort(bson.D{{Key: "next_run_at", Value: 1}})
+
+	var doc jobAlertModel
+	if err := r.collection.FindOneAndUpdate(ctx, filter, update, opts).Decode(&doc); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, domain.ErrAlertNotFound
+		}
+		return nil, fmt.Errorf("failed to claim job alert: %w", err)
+	}
+	alert := toDomainJobAlert(doc)
+	return &alert, nil
+}
+
+func (r *jobAlertRepository) MarkRun(ctx context.Context, id string, sentAt *time.Time, nextRunAt time.Time) error {
+	oid, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return domain.ErrInvalidAlertID
+	}
+
+	set := bson.M{"next_run_at": nextRunAt, "updated_at": time.Now()}
+	if sentAt != nil {
+		set["last_sent_at"] = *sentAt
+	}
+	if _, err := r.collection.UpdateByID(ctx, oid, bson.M{"$set": set}); err != nil {
+		return domain.ErrUpdatingDocument
+	}
+	return nil
+}
+
+func (r *jobAlertRepository) FilterUnsent(ctx context.Context, alertID string, jobKeys []string) ([]string, error) {
+	if len(jobKeys) == 0 {
+		return nil, nil
+	}
+
+	cursor, err := r.deliveries.Find(ctx, bson.M{"alert_id": alertID, "job_key": bson.M{"$in": jobKeys}})
+	if err != nil {
+		return nil, domain.ErrQueryFailed
+	}
+	defer cursor.Close(ctx)
+
+	var sent []alertDeliveryModel
+	if err := cursor.All(ctx, &sent); err != nil {
+		return nil, domain.ErrDocumentDecoding
+	}
+
+	already := make(map[string]bool, len(sent))
+	for _, s := range sent {
+		already[s.JobKey] = true
+	}
+	var unsent []string
+	for _, k := range jobKeys {
+		if !already[k] {
+			unsent = append(unsent, k)
+		}
+	}
+	return unsent, nil
+}
+
+func (r *jobAlertRepository) MarkSent(ctx context.Context, alertID string, jobKeys []string) error {
+	if len(jobKeys) == 0 {
+		return nil
+	}
+
+	now := time.Now()
+	var writes []mongo.WriteModel
+	for _, k := range jobKeys {
+		writes = append(writes, mongo.NewUpdateOneModel().
+			SetFilter(bson.M{"alert_id": alertID, "job_key": k}).
+			SetUpdate(bson.M{"$setOnInsert": alertDeliveryModel{AlertID: alertID, JobKey: k, SentAt: now}}).
+			SetUpsert(true))
+	}
+	if _, err := r.deliveries.BulkWrite(ctx, writes, options.BulkWrite().SetOrdered(false)); err != nil {
+		return domain.ErrInsertingDocuments
+	}
+	return nil
+}
+
+func (r *jobAlertRepository) find(ctx context.Context, filter bson.M, opts *options.FindOptions) ([]models.JobAlert, error) {
+	cursor, err := r.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, domain.ErrQueryFailed
+	}
+	defer cursor.Close(ctx)
+
+	var docs []jobAlertModel
+	if err := cursor.All(ctx, &docs); err != nil {
+		return nil, domain.ErrDocumentDecoding
+	}
+
+	alerts := make([]models.JobAlert, 0, len(docs))
+	for _, d := range docs {
+		alerts = append(alerts, toDomainJobAlert(d))
+	}
+	return alerts, nil
+}
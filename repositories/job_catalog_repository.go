@@ -0,0 +1,334 @@
+package repositories
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/tsigemariamzewdu/JobMate-backend/domain"
+	repo "github.com/tsigemariamzewdu/JobMate-backend/domain/interfaces/repositories"
+	"github.com/tsigemariamzewdu/JobMate-backend/domain/models"
+)
+
+const defaultCatalogLimit = 50
+
+type salaryModel struct {
+	Min      float64 `bson:"min"`
+	Max      float64 `bson:"max"`
+	Currency string  `bson:"currency"`
+	Period   string  `bson:"period"`
+}
+
+type jobModel struct {
+	ID             primitive.ObjectID `bson:"_id,omitempty"`
+	Fingerprint    string             `bson:"fingerprint"`
+	Title          string             `bson:"title"`
+	Company        string             `bson:"company"`
+	Location       string             `bson:"location"`
+	Description    string             `bson:"description"`
+	Requirements   []string           `bson:"requirements"`
+	Seniority      string             `bson:"seniority,omitempty"`
+	EmploymentType string             `bson:"employment_type,omitempty"`
+	Salary         *salaryModel       `bson:"salary,omitempty"`
+	Type           string             `bson:"type"`
+	Source         string             `bson:"source"`
+	Link           string             `bson:"link"`
+	Language       string             `bson:"language"`
+	PostedAt       time.Time          `bson:"posted_at"`
+	LastSeenAt     time.Time          `bson:"last_seen_at"`
+	ExpiresAt      time.Time          `bson:"expires_at"`
+	CreatedAt      time.Time          `bson:"created_at"`
+}
+
+func toDomainJob(m jobModel) models.Job {
+	job := models.Job{
+		ID:             m.ID.Hex(),
+		Title:          m.Title,
+		Company:        m.Company,
+		Location:       m.Location,
+		Description:    m.Description,
+		Requirements:   m.Requirements,
+		Seniority:      m.Seniority,
+		EmploymentType: m.EmploymentType,
+		Type:           m.Type,
+		Source:         m.Source,
+		Link:           m.Link,
+		Language:       m.Language,
+		PostedAt:       m.PostedAt,
+		LastSeenAt:     m.LastSeenAt,
+		ExpiresAt:      m.ExpiresAt,
+	}
+	if m.Salary != nil {
+		job.Salary = &models.SalaryRange{
+			Min:      m.Salary.Min,
+			Max:      m.Salary.Max,
+			Currency: m.Salary.Currency,
+			Period:   m.Salary.Period,
+		}
+	}
+	return job
+}
+
+func toSalaryModel(s *models.SalaryRange) *salaryModel {
+	if s == nil {
+		return nil
+	}
+	return &salaryModel{Min: s.Min, Max: s.Max, Currency: s.Currency, Period: s.Period}
+}
+
+// jobFingerprint identifies a posting across sources and ingestion runs
+func jobFingerprint(j models.Job) string {
+	normalize := func(s string) string {
+		return strings.Join(strings.Fields(strings.ToLower(s)), " ")
+	}
+	sum := sha256.Sum256([]byte(normalize(j.Company) + "|" + normalize(j.Title) + "|" + strings.TrimSpace(j.Link)))
+	return hex.EncodeToString(sum[:])
+}
+
+type jobCatalogRepository struct {
+	collection *mongo.Collection
+}
+
+func NewJobCatalogRepository(db *mongo.Database) repo.IJobCatalogRepository {
+	return &jobCatalogRepository{collection: db.Collection("jobs")}
+}
+
+func (r *jobCatalogRepository) EnsureIndexes(ctx context.Context) error {
+	_, err := r.collection.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{Keys: bson.D{{Key: "fingerprint", Value: 1}}, Options: options.Index().SetUnique(true)},
+		{Keys: bson.D{{Key: "type", Value: 1}, {Key: "expires_at", Value: 1}}},
+	})
+	return err
+}
+
+func (r *jobCatalogRepository) UpsertMany(ctx context.Context, jobs []models.Job, ttl time.Duration) (int, error) {
+	if len(jobs) == 0 {
+		return 0, nil
+	}
+
+	now := time.Now()
+	seen := make(map[string]bool)
+	var writes []mongo.WriteModel
+	for _, j := range jobs {
+		fp := jobFingerprint(j)
+		if seen[fp] {
+			continue
+		}
+		seen[fp] = true
+
+		postedAt := j.PostedAt
+		if postedAt.IsZero() {
+			postedAt = now
+		}
+		requirements := j.Requirements
+		if requirements == nil {
+			requirements = []string{}
+		}
+
+		update := bson.M{
+			"$set": bson.M{
+				"title":           j.Title,
+				"company":         j.Company,
+				"location":        j.Location,
+				"description":     j.Description,
+				"requirements":    requirements,
+				"seniority":       j.Seniority,
+				"employment_type": j.EmploymentType,
+				"salary":          toSalaryModel(j.Salary),
+				"type":            j.Type,
+				"source":          j.Source,
+				"link":            j.Link,
+				"language":        j.Language,
+				"last_seen_at":    now,
+				"expires_at":      now.Add(ttl),
+			},
+			"$setOnInsert": bson.M{
+				"fingerprint": fp,
+				"posted_at":   postedAt,
+				"created_at":  now,
+			},
+		}
+		writes = append(writes, mongo.NewUpdateOneModel().
+			SetFilter(bson.M{"fingerprint": fp}).
+			SetUpdate(update).
+			SetUpsert(true))
+	}
+
+	if _, err := r.collection.BulkWrite(ctx, writes, options.BulkWrite().SetOrdered(false)); err != nil {
+		return 0, domain.ErrInsertingDocuments
+	}
+	return len(writes), nil
+}
+
+func (r *jobCatalogRepository) LoadExtracted(ctx context.Context, jobs []models.Job) ([]bool, error) {
+	known := make([]bool, len(jobs))
+	if len(jobs) == 0 {
+		return known, nil
+	}
+
+	fingerprints := make([]string, len(jobs))
+	for i, j := range jobs {
+		fingerprints[i] = jobFingerprint(j)
+	}
+	filter := bson.M{"fingerprint": bson.M{"$in": fingerprints}, "requirements.0": bson.M{"$exists": true}}
+	opts := options.Find().SetProjection(bson.M{
+		"fingerprint": 1, "description": 1, "requirements": 1, "seniority": 1, "employment_type": 1, "salary": 1,
+	})
+	cursor, err := r.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, domain.ErrQueryFailed
+	}
+	defer cursor.Close(ctx)
+
+	var docs []jobModel
+	if err := cursor.All(ctx, &docs); err != nil {
+		return nil, domain.ErrDocumentDecoding
+	}
+	stored := make(map[string]models.Job, len(docs))
+	for _, d := range docs {
+		stored[d.Fingerprint] = toDomainJob(d)
+	}
+
+	for i, fp := range fingerprints {
+		s, ok := stored[fp]
+		if !ok {
+			continue
+		}
+		jobs[i].Description = s.Description
+		jobs[i].Requirements = s.Requirements
+		jobs[i].Seniority = s.Seniority
+		jobs[i].EmploymentType = s.EmploymentType
+		jobs[i].Salary = s.Salary
+		known[i] = true
+	}
+	return known, nil
+}
+
+func (r *jobCatalogRepository) Find(ctx context.Context, filter models.JobFilter) ([]models.Job, string, error) {
+	and := bson.A{bson.M{"expires_at": bson.M{"$gt": time.Now()}}}
+	if len(filter.Types) > 0 {
+		and = append(and, bson.M{"type": bson.M{"$in": filter.Types}})
+	}
+	if keyword := strings.TrimSpace(filter.Keyword); keyword != "" {
+		pattern := primitive.Regex{Pattern: regexp.QuoteMeta(keyword), Options: "i"}
+		and = append(and, bson.M{"$or": bson.A{
+			bson.M{"title": pattern},
+			bson.M{"company": pattern},
+			bson.M{"requirements": pattern},
+		}})
+	}
+	if location := strings.TrimSpace(filter.Location); location != "" {
+		and = append(and, bson.M{"location": primitive.Regex{Pattern: regexp.QuoteMeta(location), Options: "i"}})
+	}
+	if source := strings.TrimSpace(filter.Source); source != "" {
+		and = append(and, bson.M{"source": primitive.Regex{Pattern: "^" + regexp.QuoteMeta(source) + "$", Options: "i"}})
+	}
+	if !filter.PostedSince.IsZero() {
+		and = append(and, bson.M{"posted_at": bson.M{"$gte": filter.PostedSince}})
+	}
+	if filter.Cursor != "" {
+		postedAt, id, err := decodeJobCursor(filter.Cursor)
+		if err != nil {
+			return nil, "", domain.ErrInvalidCursor
+		}
+		and = append(and, bson.M{"$or": bson.A{
+			bson.M{"posted_at": bson.M{"$lt": postedAt}},
+			bson.M{"posted_at": postedAt, "_id": bson.M{"$lt": id}},
+		}})
+	}
+
+	limit := filter.Limit
+	if limit <= 0 {
+		limit = defaultCatalogLimit
+	}
+	// one extra document tells whether another page exists
+	opts := options.Find().
+		SetSort(bson.D{{Key: "posted_at", Value: -1}, {Key: "_id", Value: -1}}).
+		SetLimit(int64(limit + 1))
+
+	cursor, err := r.collection.Find(ctx, bson.M{"$and": and}, opts)
+	if err != nil {
+		return nil, "", domain.ErrQueryFailed
+	}
+	defer cursor.Close(ctx)
+
+	var docs []jobModel
+	if err := cursor.All(ctx, &docs); err != nil {
+		return nil, "", domain.ErrDocumentDecoding
+	}
+
+	var next string
+	if len(docs) > limit {
+		docs = docs[:limit]
+		last := docs[len(docs)-1]
+		next = encodeJobCursor(last.PostedAt, last.ID)
+	}
+
+	jobs := make([]models.Job, 0, len(docs))
+	for _, d := range docs {
+		jobs = append(jobs, toDomainJob(d))
+	}
+	return jobs, next, nil
+}
+
+// encodeJobCursor packs the sort key of the last returned job into an opaque token
+func encodeJobCursor(postedAt time.Time, id primitive.ObjectID) string {
+	raw := strconv.FormatInt(postedAt.UnixMilli(), 10) + ":" + id.Hex()
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+func decodeJobCursor(cursor string) (time.Time, primitive.ObjectID, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return time.Time{}, primitive.NilObjectID, err
+	}
+	millis, hexID, ok := strings.Cut(string(raw), ":")
+	if !ok {
+		return time.Time{}, primitive.NilObjectID, domain.ErrInvalidCursor
+	}
+	ms, err := strconv.ParseInt(millis, 10, 64)
+	if err != nil {
+		return time.Time{}, primitive.NilObjectID, err
+	}
+	id, err := primitive.ObjectIDFromHex(hexID)
+	if err != nil {
+		return time.Time{}, primitive.NilObjectID, err
+	}
+	return time.UnixMilli(ms).UTC(), id, nil
+}
+
+func (r *jobCatalogRepository) GetByID(ctx context.Context, id string) (*models.Job, error) {
+	oid, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, domain.ErrInvalidJobID
+	}
+
+	var doc jobModel
+	if err := r.collection.FindOne(ctx, bson.M{"_id": oid}).Decode(&doc); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, domain.ErrJobNotFound
+		}
+		return nil, err
+	}
+	job := toDomainJob(doc)
+	return &job, nil
+}
+
+func (r *jobCatalogRepository) DeleteExpired(ctx context.Context) (int64, error) {
+	res, err := r.collection.DeleteMany(ctx, bson.M{"expires_at": bson.M{"$lte": time.Now()}})
+	if err != nil {
+		return 0, domain.ErrDeletingDocument
+	}
+	return res.DeletedCount, nil
+}
@@ -2,46 +2,72 @@ package repositories
 
 import (
 	"context"
+	"errors"
 	"time"
 
+	"github.com/tsigemariamzewdu/JobMate-backend/domain"
+	repo "github.com/tsigemariamzewdu/JobMate-backend/domain/interfaces/repositories"
 	"github.com/tsigemariamzewdu/JobMate-backend/domain/models"
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
 type JobChatRepository struct {
 	collection *mongo.Collection
 }
 
+var _ repo.IJobChatRepository = (*JobChatRepository)(nil)
+
 func NewJobChatRepository(db *mongo.Database) *JobChatRepository {
 	return &JobChatRepository{
 		collection: db.Collection("job_chats"),
 	}
 }
 
-func (r *JobChatRepository) CreateJobChat(ctx context.Context, userID string, query map[string]any, jobResults []models.Job, messages []models.JobChatMessage) (string, error) {
-	chat := models.JobChat{
-		UserID:         userID,
-		Messages:       messages,
-		JobSearchQuery: query,
-		JobResults:     jobResults,
-		CreatedAt:      time.Now(),
-		UpdatedAt:      time.Now(),
-	}
+func (r *JobChatRepository) CreateJobChat(ctx context.Context, chat *models.JobChat) (string, error) {
+	now := time.Now()
+	chat.ID = ""
+	chat.CreatedAt = now
+	chat.UpdatedAt = now
 	result, err := r.collection.InsertOne(ctx, chat)
 	if err != nil {
 		return "", err
 	}
 	id := result.InsertedID.(primitive.ObjectID).Hex()
+	chat.ID = id
 	return id, nil
 }
 
-func (r *JobChatRepository) AppendMessage(ctx context.Context, chatID string, message models.JobChatMessage) error {
+func (r *JobChatRepository) AppendTurn(ctx context.Context, userID, chatID string, messages []models.JobChatMessage, query map[string]any, jobResults []models.Job) error {
 	objID, err := primitive.ObjectIDFromHex(chatID)
+	if err != nil {
+		return domain.ErrInvalidJobChatID
+	}
+	update := bson.M{
+		"$push": bson.M{"messages": bson.M{"$each": messages}},
+		"$set": bson.M{
+			"job_search_query": query,
+			"job_results":      jobResults,
+			"updated_at":       time.Now(),
+		},
+	}
+	res, err := r.collection.UpdateOne(ctx, bson.M{"_id": objID, "user_id": userID}, update)
 	if err != nil {
 		return err
 	}
+	if res.MatchedCount == 0 {
+		return domain.ErrJobChatNotFound
+	}
+	return nil
+}
+
+func (r *JobChatRepository) AppendMessage(ctx context.Context, chatID string, message models.JobChatMessage) error {
+	objID, err := primitive.ObjectIDFromHex(chatID)
+	if err != nil {
+		return domain.ErrInvalidJobChatID
+	}
 	update := bson.M{
 		"$push": bson.M{"messages": message},
 		"$set":  bson.M{"updated_at": time.Now()},
@@ -50,21 +76,27 @@ func (r *JobChatRepository) AppendMessage(ctx context.Context, chatID string, me
 	return err
 }
 
-func (r *JobChatRepository) GetJobChatByID(ctx context.Context, chatID string) (*models.JobChat, error) {
+func (r *JobChatRepository) GetJobChatByID(ctx context.Context, userID, chatID string) (*models.JobChat, error) {
 	objID, err := primitive.ObjectIDFromHex(chatID)
 	if err != nil {
-		return nil, err
+		return nil, domain.ErrInvalidJobChatID
 	}
 	var chat models.JobChat
-	err = r.collection.FindOne(ctx, bson.M{"_id": objID}).Decode(&chat)
+	err = r.collection.FindOne(ctx, bson.M{"_id": objID, "user_id": userID}).Decode(&chat)
 	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, domain.ErrJobChatNotFound
+		}
 		return nil, err
 	}
 	return &chat, nil
 }
 
 func (r *JobChatRepository) GetJobChatsByUserID(ctx context.Context, userID string) ([]*models.JobChat, error) {
-	cursor, err := r.collection.Find(ctx, bson.M{"user_id": userID})
+	opts := options.Find().
+		SetSort(bson.D{{Key: "updated_at", Value: -1}}).
+		SetProjection(bson.M{"job_results": 0})
+	cursor, err := r.collection.Find(ctx, bson.M{"user_id": userID}, opts)
 	if err != nil {
 		return nil, err
 	}
@@ -74,3 +106,36 @@ func (r *JobChatRepository) GetJobChatsByUserID(ctx context.Context, userID stri
 	}
 	return chats, nil
 }
+
+func (r *JobChatRepository) RenameJobChat(ctx context.Context, userID, chatID, title string) error {
+	objID, err := primitive.ObjectIDFromHex(chatID)
+	if err != nil {
+		return domain.ErrInvalidJobChatID
+	}
+	res, err := r.collection.UpdateOne(ctx,
+		bson.M{"_id": objID, "user_id": userID},
+		bson.M{"$set": bson.M{"title": title, "updated_at": time.Now()}},
+	)
+	if err != nil {
+		return err
+	}
+	if res.MatchedCount == 0 {
+		return domain.ErrJobChatNotFound
+	}
+	return nil
+}
+
+func (r *JobChatRepository) DeleteJobChat(ctx context.Context, userID, chatID string) error {
+	objID, err := primitive.ObjectIDFromHex(chatID)
+	if err != nil {
+		return domain.ErrInvalidJobChatID
+	}
+	res, err := r.collection.DeleteOne(ctx, bson.M{"_id": objID, "user_id": userID})
+	if err != nil {
+		return err
+	}
+	if res.DeletedCount == 0 {
+		return domain.ErrJobChatNotFound
+	}
+	return nil
+}
@@ -0,0 +1,122 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/integration/mtest"
+
+	"github.com/tsigemariamzewdu/JobMate-backend/domain"
+	"github.com/tsigemariamzewdu/JobMate-backend/domain/models"
+)
+
+// These tests run against a mocked deployment, so they need no MongoDB. They check that every
+// call made with another user's ID sends a filter scoped to that user and reports not found.
+
+func noDocuments() bson.D { return mtest.CreateCursorResponse(0, "test.coll", mtest.FirstBatch) }
+
+func noneMatched() bson.D {
+	return mtest.CreateSuccessResponse(bson.E{Key: "n", Value: 0}, bson.E{Key: "nModified", Value: 0})
+}
+
+func noneDeleted() bson.D { return mtest.CreateSuccessResponse(bson.E{Key: "n", Value: 0}) }
+
+// sentFilter returns the filter of the find, update or delete command mt last sent
+func sentFilter(mt *mtest.T) bson.Raw {
+	mt.Helper()
+	ev := mt.GetStartedEvent()
+	if ev == nil {
+		mt.Fatal("no command was sent")
+	}
+	switch ev.CommandName {
+	case "find":
+		return ev.Command.Lookup("filter").Document()
+	case "update":
+		return ev.Command.Lookup("updates", "0", "q").Document()
+	case "delete":
+		return ev.Command.Lookup("deletes", "0", "q").Document()
+	case "findAndModify":
+		return ev.Command.Lookup("query").Document()
+	}
+	mt.Fatalf("unexpected command %s", ev.CommandName)
+	return nil
+}
+
+func TestRepositoryFiltersAreScopedToTheUser(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+	ctx := context.Background()
+	id := primitive.NewObjectID().Hex()
+
+	tests := []struct {
+		name     string
+		response func() bson.D
+		call     func(mt *mtest.T) error
+		want     error
+	}{
+		{"application get", noDocuments, func(mt *mtest.T) error {
+			_, err := NewJobApplicationRepository(mt.DB).GetByID(ctx, intruderID, id)
+			return err
+		}, domain.ErrApplicationNotFound},
+		{"application update", noneMatched, func(mt *mtest.T) error {
+			app := &models.JobApplication{ID: id, UserID: intruderID, Status: models.ApplicationRejected}
+			return NewJobApplicationRepository(mt.DB).Update(ctx, app)
+		}, domain.ErrApplicationNotFound},
+		{"application delete", noneDeleted, func(mt *mtest.T) error {
+			return NewJobApplicationRepository(mt.DB).Delete(ctx, intruderID, id)
+		}, domain.ErrApplicationNotFound},
+		{"job chat get", noDocuments, func(mt *mtest.T) error {
+			_, err := NewJobChatRepository(mt.DB).GetJobChatByID(ctx, intruderID, id)
+			return err
+		}, domain.ErrJobChatNotFound},
+		{"job chat rename", noneMatched, func(mt *mtest.T) error {
+			return NewJobChatRepository(mt.DB).RenameJobChat(ctx, intruderID, id, "mine now")
+		}, domain.ErrJobChatNotFound},
+		{"job chat append", noneMatched, func(mt *mtest.T) error {
+			return NewJobChatRepository(mt.DB).AppendTurn(ctx, intruderID, id, nil, nil, nil)
+		}, domain.ErrJobChatNotFound},
+		{"job chat delete", noneDeleted, func(mt *mtest.T) error {
+			return NewJobChatRepository(mt.DB).DeleteJobChat(ctx, intruderID, id)
+		}, domain.ErrJobChatNotFound},
+		{"CV activate", noneMatched, func(mt *mtest.T) error {
+			return NewCVRepository(mt.DB).SetActive(ctx, intruderID, id)
+		}, domain.ErrCVNotFound},
+		{"CV delete", noneMatched, func(mt *mtest.T) error {
+			return NewCVRepository(mt.DB).SoftDelete(ctx, intruderID, id)
+		}, domain.ErrCVNotFound},
+		{"job alert delete", noneDeleted, func(mt *mtest.T) error {
+			return NewJobAlertRepository(mt.DB).Delete(ctx, intruderID, id)
+		}, domain.ErrAlertNotFound},
+	}
+
+	for _, tt := range tests {
+		mt.Run(tt.name, func(mt *mtest.T) {
+			mt.AddMockResponses(tt.response())
+
+			if err := tt.call(mt); !errors.Is(err, tt.want) {
+				mt.Fatalf("got error %v, want %v", err, tt.want)
+			}
+			filter := sentFilter(mt)
+			if got, ok := filter.Lookup("user_id").StringValueOK(); !ok || got != intruderID {
+				mt.Errorf("filter %s is not scoped to user_id %q", filter, intruderID)
+			}
+			if got, ok := filter.Lookup("_id").ObjectIDOK(); !ok || got.Hex() != id {
+				mt.Errorf("filter %s is not scoped to _id %s", filter, id)
+			}
+		})
+	}
+
+	mt.Run("application list", func(mt *mtest.T) {
+		mt.AddMockResponses(noDocuments())
+
+		apps, err := NewJobApplicationRepository(mt.DB).ListByUser(ctx, intruderID, "")
+		if err != nil || len(apps) != 0 {
+			mt.Fatalf("ListByUser = %v, %v; want no applications", apps, err)
+		}
+		if got := sentFilter(mt).Lookup("user_id").StringValue(); got != intruderID {
+			mt.Errorf("listed user_id %q, want %q", got, intruderID)
+		}
+	})
+}
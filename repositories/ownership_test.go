@@ -0,0 +1,282 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"os"
+	"reflect"
+	"testing"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/tsigemariamzewdu/JobMate-backend/domain"
+	"github.com/tsigemariamzewdu/JobMate-backend/domain/models"
+)
+
+// These tests run against a real MongoDB named by MONGO_TEST_URI, e.g.
+// MONGO_TEST_URI=mongodb://localhost:27017, and are skipped without it; ownership_filter_test.go
+// covers the same filters on every run. Each test gets its own database, dropped afterwards.
+
+const (
+	ownerID    = "owner"
+	intruderID = "intruder"
+)
+
+func testDatabase(t *testing.T) *mongo.Database {
+	t.Helper()
+	uri := os.Getenv("MONGO_TEST_URI")
+	if uri == "" {
+		t.Skip("MONGO_TEST_URI is not set")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	client, err := mongo.Connect(ctx, options.Client().ApplyURI(uri))
+	if err != nil {
+		t.Fatalf("connect: %v", err)
+	}
+	db := client.Database("jobmate_test_" + primitive.NewObjectID().Hex())
+	t.Cleanup(func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		_ = db.Drop(ctx)
+		_ = client.Disconnect(ctx)
+	})
+	return db
+}
+
+func TestApplicationRepositoryRejectsOtherUsers(t *testing.T) {
+	ctx := context.Background()
+	db := testDatabase(t)
+	r := NewJobApplicationRepository(db)
+
+	now := time.Now()
+	id, err := r.Create(ctx, &models.JobApplication{
+		UserID:    ownerID,
+		Job:       models.Job{Title: "Accountant", Company: "Dashen Bank", Link: "https://example.com/jobs/1"},
+		Status:    models.ApplicationSaved,
+		Notes:     "call HR on Monday",
+		History:   []models.ApplicationStatusChange{{Status: models.ApplicationSaved, ChangedAt: now}},
+		CreatedAt: now,
+		UpdatedAt: now,
+	})
+	if err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	before, err := r.GetByID(ctx, ownerID, id)
+	if err != nil {
+		t.Fatalf("GetByID: %v", err)
+	}
+
+	tests := []struct {
+		name string
+		call func() error
+	}{
+		{"get", func() error {
+			_, err := r.GetByID(ctx, intruderID, id)
+			return err
+		}},
+		{"update", func() error {
+			app := *before
+			app.UserID, app.Status, app.Notes = intruderID, models.ApplicationRejected, "overwritten"
+			return r.Update(ctx, &app)
+		}},
+		{"delete", func() error {
+			return r.Delete(ctx, intruderID, id)
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.call(); !errors.Is(err, domain.ErrApplicationNotFound) {
+				t.Fatalf("got error %v, want %v", err, domain.ErrApplicationNotFound)
+			}
+			after, err := r.GetByID(ctx, ownerID, id)
+			if err != nil {
+				t.Fatalf("owner's application is gone: %v", err)
+			}
+			if !reflect.DeepEqual(after, before) {
+				t.Errorf("owner's application changed:\ngot  %+v\nwant %+v", after, before)
+			}
+		})
+	}
+
+	apps, err := r.ListByUser(ctx, intruderID, "")
+	if err != nil {
+		t.Fatalf("ListByUser: %v", err)
+	}
+	if len(apps) != 0 {
+		t.Errorf("intruder listed %d applications, want 0", len(apps))
+	}
+}
+
+func TestJobChatRepositoryRejectsOtherUsers(t *testing.T) {
+	ctx := context.Background()
+	db := testDatabase(t)
+	r := NewJobChatRepository(db)
+
+	id, err := r.CreateJobChat(ctx, &models.JobChat{
+		UserID:         ownerID,
+		Title:          "Accounting jobs",
+		Messages:       []models.JobChatMessage{{Role: "user", Message: "accounting jobs", Timestamp: time.Now()}},
+		JobSearchQuery: map[string]any{"field": "accounting"},
+	})
+	if err != nil {
+		t.Fatalf("CreateJobChat: %v", err)
+	}
+	before, err := r.GetJobChatByID(ctx, ownerID, id)
+	if err != nil {
+		t.Fatalf("GetJobChatByID: %v", err)
+	}
+
+	tests := []struct {
+		name string
+		call func() error
+	}{
+		{"get", func() error {
+			_, err := r.GetJobChatByID(ctx, intruderID, id)
+			return err
+		}},
+		{"append turn", func() error {
+			msgs := []models.JobChatMessage{{Role: "user", Message: "show me more", Timestamp: time.Now()}}
+			return r.AppendTurn(ctx, intruderID, id, msgs, map[string]any{"field": "nursing"}, nil)
+		}},
+		{"rename", func() error {
+			return r.RenameJobChat(ctx, intruderID, id, "mine now")
+		}},
+		{"delete", func() error {
+			return r.DeleteJobChat(ctx, intruderID, id)
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.call(); !errors.Is(err, domain.ErrJobChatNotFound) {
+				t.Fatalf("got error %v, want %v", err, domain.ErrJobChatNotFound)
+			}
+			after, err := r.GetJobChatByID(ctx, ownerID, id)
+			if err != nil {
+				t.Fatalf("owner's chat is gone: %v", err)
+			}
+			if !reflect.DeepEqual(after, before) {
+				t.Errorf("owner's chat changed:\ngot  %+v\nwant %+v", after, before)
+			}
+		})
+	}
+
+	chats, err := r.GetJobChatsByUserID(ctx, intruderID)
+	if err != nil {
+		t.Fatalf("GetJobChatsByUserID: %v", err)
+	}
+	if len(chats) != 0 {
+		t.Errorf("intruder listed %d chats, want 0", len(chats))
+	}
+}
+
+func TestCVRepositoryRejectsOtherUsers(t *testing.T) {
+	ctx := context.Background()
+	db := testDatabase(t)
+	r := NewCVRepository(db)
+
+	now := time.Now()
+	id, err := r.Create(ctx, &models.CV{UserID: ownerID, OriginalText: "Experienced accountant", CreatedAt: now, UpdatedAt: now})
+	if err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	if err := r.SetActive(ctx, ownerID, id); err != nil {
+		t.Fatalf("SetActive: %v", err)
+	}
+	intruderCV, err := r.Create(ctx, &models.CV{UserID: intruderID, OriginalText: "Nurse", CreatedAt: now, UpdatedAt: now})
+	if err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	if err := r.SetActive(ctx, intruderID, intruderCV); err != nil {
+		t.Fatalf("SetActive: %v", err)
+	}
+	before, err := r.GetByID(ctx, id)
+	if err != nil {
+		t.Fatalf("GetByID: %v", err)
+	}
+
+	// GetByID is not scoped to a user; the usecase checks ownership of what it returns
+	tests := []struct {
+		name string
+		call func() error
+	}{
+		{"activate", func() error {
+			return r.SetActive(ctx, intruderID, id)
+		}},
+		{"delete", func() error {
+			return r.SoftDelete(ctx, intruderID, id)
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.call(); !errors.Is(err, domain.ErrCVNotFound) {
+				t.Fatalf("got error %v, want %v", err, domain.ErrCVNotFound)
+			}
+			after, err := r.GetByID(ctx, id)
+			if err != nil {
+				t.Fatalf("owner's CV is gone: %v", err)
+			}
+			if !reflect.DeepEqual(after, before) {
+				t.Errorf("owner's CV changed:\ngot  %+v\nwant %+v", after, before)
+			}
+		})
+	}
+
+	cvs, err := r.ListByUserID(ctx, intruderID)
+	if err != nil {
+		t.Fatalf("ListByUserID: %v", err)
+	}
+	if len(cvs) != 1 || cvs[0].ID != intruderCV {
+		t.Errorf("intruder listed %+v, want only their own CV %s", cvs, intruderCV)
+	}
+}
+
+func TestJobAlertRepositoryRejectsOtherUsers(t *testing.T) {
+	ctx := context.Background()
+	db := testDatabase(t)
+	r := NewJobAlertRepository(db)
+
+	now := time.Now()
+	if _, err := r.Create(ctx, &models.JobAlert{
+		UserID:           ownerID,
+		Field:            "accounting",
+		Skills:           []string{"ifrs"},
+		Frequency:        models.AlertDaily,
+		Channels:         []models.AlertChannel{models.AlertChannelEmail},
+		Email:            "owner@example.com",
+		UnsubscribeToken: "token",
+		Active:           true,
+		NextRunAt:        now.Add(24 * time.Hour),
+		CreatedAt:        now,
+		UpdatedAt:        now,
+	}); err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	before, err := r.ListByUser(ctx, ownerID)
+	if err != nil || len(before) != 1 {
+		t.Fatalf("ListByUser: %v, %d alerts", err, len(before))
+	}
+
+	if err := r.Delete(ctx, intruderID, before[0].ID); !errors.Is(err, domain.ErrAlertNotFound) {
+		t.Fatalf("got error %v, want %v", err, domain.ErrAlertNotFound)
+	}
+	after, err := r.ListByUser(ctx, ownerID)
+	if err != nil {
+		t.Fatalf("ListByUser: %v", err)
+	}
+	if !reflect.DeepEqual(after, before) {
+		t.Errorf("owner's alerts changed:\ngot  %+v\nwant %+v", after, before)
+	}
+
+	alerts, err := r.ListByUser(ctx, intruderID)
+	if err != nil {
+		t.Fatalf("ListByUser: %v", err)
+	}
+	if len(alerts) != 0 {
+		t.Errorf("intruder listed %d alerts, want 0", len(alerts))
+	}
+}
@@ -2,12 +2,44 @@ package repositories
 
 import (
 	"context"
-		repo "github.com/tsigemariamzewdu/JobMate-backend/domain/interfaces/repositories"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/tsigemariamzewdu/JobMate-backend/domain"
+	repo "github.com/tsigemariamzewdu/JobMate-backend/domain/interfaces/repositories"
 	"github.com/tsigemariamzewdu/JobMate-backend/domain/models"
+	"github.com/tsigemariamzewdu/JobMate-backend/infrastructure/skills"
 
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
+type skillGapModel struct {
+	ID     primitive.ObjectID `bson:"_id"`
+	UserID string             `bson:"user_id"`
+	CVID   string             `bson:"cv_id,omitempty"`
+	// SkillKey is the normalized skill name gaps are keyed by; gaps saved before
+	// upserts existed do not have one
+	SkillKey               string    `bson:"skill_key,omitempty"`
+	SkillName              string    `bson:"skill_name"`
+	CurrentLevel           int       `bson:"current_level"`
+	RecommendedLevel       int       `bson:"recommended_level"`
+	Importance             string    `bson:"importance"`
+	ImprovementSuggestions string    `bson:"improvement_suggestions"`
+	CreatedAt              time.Time `bson:"created_at"`
+	UpdatedAt              time.Time `bson:"updated_at"`
+}
+
+// importanceRank orders gaps for listing, most important first
+var importanceRank = map[models.Importance]int{
+	models.ImportanceCritical:   0,
+	models.ImportanceImportant:  1,
+	models.ImportanceNiceToHave: 2,
+}
+
 type skillGapRepository struct {
 	collection *mongo.Collection
 }
@@ -18,25 +50,98 @@ func NewSkillGapRepository(db *mongo.Database) repo.SkillGapRepository {
 	}
 }
 
-func (r *skillGapRepository) CreateMany(ctx context.Context, gaps []*models.SkillGap) error {
-	var docs []interface{}
+func (r *skillGapRepository) EnsureIndexes(ctx context.Context) error {
+	_, err := r.collection.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys: bson.D{{Key: "user_id", Value: 1}, {Key: "skill_key", Value: 1}},
+		Options: options.Index().
+			SetUnique(true).
+			SetPartialFilterExpression(bson.M{"skill_key": bson.M{"$exists": true}}),
+	})
+	return err
+}
+
+func (r *skillGapRepository) ReplaceForUser(ctx context.Context, userID string, gaps []*models.SkillGap) error {
+	var writes []mongo.WriteModel
+	keys := []string{}
 	for _, g := range gaps {
-		docs = append(docs, map[string]interface{}{
-			"user_id":                  g.UserID,
-			"skill_name":               g.SkillName,
-			"current_level":            g.CurrentLevel,
-			"recommended_level":        g.RecommendedLevel,
-			"importance":               g.Importance,
-			"improvement_suggestions":  g.ImprovementSuggestions,
-			"created_at":               g.CreatedAt,
-			"updated_at":               g.UpdatedAt,
-		})
+		key := skills.Normalize(g.SkillName)
+		if key == "" {
+			continue
+		}
+		keys = append(keys, key)
+		writes = append(writes, mongo.NewUpdateOneModel().
+			SetFilter(bson.M{"user_id": userID, "skill_key": key}).
+			SetUpdate(bson.M{
+				"$set": bson.M{
+					"cv_id":                   g.CVID,
+					"skill_name":              g.SkillName,
+					"current_level":           g.CurrentLevel,
+					"recommended_level":       g.RecommendedLevel,
+					"importance":              g.Importance,
+					"improvement_suggestions": g.ImprovementSuggestions,
+					"updated_at":              g.UpdatedAt,
+				},
+				"$setOnInsert": bson.M{"created_at": g.CreatedAt},
+			}).
+			SetUpsert(true))
 	}
 
-	if len(docs) == 0 {
-		return nil
+	if len(writes) > 0 {
+		if _, err := r.collection.BulkWrite(ctx, writes, options.BulkWrite().SetOrdered(false)); err != nil {
+			return fmt.Errorf("%w: %v", domain.ErrUpdatingDocument, err)
+		}
 	}
 
-	_, err := r.collection.InsertMany(ctx, docs)
-	return err
+	// skills the latest analysis no longer reports are closed gaps; $nin also matches the
+	// older documents that have no skill_key
+	if _, err := r.collection.DeleteMany(ctx, bson.M{"user_id": userID, "skill_key": bson.M{"$nin": keys}}); err != nil {
+		return fmt.Errorf("%w: %v", domain.ErrDeletingDocument, err)
+	}
+	return nil
+}
+
+func (r *skillGapRepository) ListByUserID(ctx context.Context, userID string) ([]models.SkillGap, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "updated_at", Value: -1}})
+	cursor, err := r.collection.Find(ctx, bson.M{"user_id": userID}, opts)
+	if err != nil {
+		return nil, domain.ErrQueryFailed
+	}
+	defer cursor.Close(ctx)
+
+	var docs []skillGapModel
+	if err := cursor.All(ctx, &docs); err != nil {
+		return nil, domain.ErrDocumentDecoding
+	}
+
+	// older documents may repeat a skill; the most recently updated one wins
+	seen := make(map[string]bool, len(docs))
+	gaps := make([]models.SkillGap, 0, len(docs))
+	for _, d := range docs {
+		key := skills.Normalize(d.SkillName)
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		gaps = append(gaps, models.SkillGap{
+			ID:                     d.ID.Hex(),
+			UserID:                 d.UserID,
+			CVID:                   d.CVID,
+			SkillName:              d.SkillName,
+			CurrentLevel:           d.CurrentLevel,
+			RecommendedLevel:       d.RecommendedLevel,
+			Importance:             models.Importance(d.Importance),
+			ImprovementSuggestions: d.ImprovementSuggestions,
+			CreatedAt:              d.CreatedAt,
+			UpdatedAt:              d.UpdatedAt,
+		})
+	}
+
+	sort.SliceStable(gaps, func(i, j int) bool {
+		ri, rj := importanceRank[gaps[i].Importance], importanceRank[gaps[j].Importance]
+		if ri != rj {
+			return ri < rj
+		}
+		return gaps[i].RecommendedLevel-gaps[i].CurrentLevel > gaps[j].RecommendedLevel-gaps[j].CurrentLevel
+	})
+	return gaps, nil
 }
@@ -0,0 +1,78 @@
+package usecases
+
+import (
+	"context"
+	"time"
+
+	"github.com/tsigemariamzewdu/JobMate-backend/domain"
+	repo "github.com/tsigemariamzewdu/JobMate-backend/domain/interfaces/repositories"
+	usecase "github.com/tsigemariamzewdu/JobMate-backend/domain/interfaces/usecases"
+	model "github.com/tsigemariamzewdu/JobMate-backend/domain/models"
+	"github.com/tsigemariamzewdu/JobMate-backend/infrastructure/ai"
+)
+
+const maxUsageReportDays = 90
+
+type AIUsageUsecase struct {
+	usageRepo       repo.IAIUsageRepository
+	dailyTokenQuota int
+	timeout         time.Duration
+}
+
+func NewAIUsageUsecase(usageRepo repo.IAIUsageRepository, dailyTokenQuota int, timeout time.Duration) usecase.IAIUsageUsecase {
+	return &AIUsageUsecase{
+		usageRepo:       usageRepo,
+		dailyTokenQuota: dailyTokenQuota,
+		timeout:         timeout,
+	}
+}
+
+func (uc *AIUsageUsecase) GetUserUsage(ctx context.Context, userID string, days int) (*model.AIUsageReport, error) {
+	c, cancel := context.WithTimeout(ctx, uc.timeout)
+	defer cancel()
+
+	if userID == "" {
+		return nil, domain.ErrInvalidUserID
+	}
+	if days <= 0 {
+		days = 1
+	}
+	if days > maxUsageReportDays {
+		days = maxUsageReportDays
+	}
+
+	now := time.Now().UTC()
+	today := ai.StartOfDay(now)
+	from := today.AddDate(0, 0, -(days - 1))
+
+	byFeature, err := uc.usageRepo.Totals(c, userID, from, now)
+	if err != nil {
+		return nil, err
+	}
+	usedToday, err := uc.usageRepo.TotalTokensSince(c, userID, today)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &model.AIUsageReport{
+		From:       from,
+		To:         now,
+		ByFeature:  byFeature,
+		DailyQuota: uc.dailyTokenQuota,
+		UsedToday:  usedToday,
+	}
+	if uc.dailyTokenQuota > 0 {
+		report.RemainingToday = max(uc.dailyTokenQuota-usedToday, 0)
+	}
+	return report, nil
+}
+
+func (uc *AIUsageUsecase) GetReport(ctx context.Context, from, to time.Time) ([]model.AIUsageTotal, error) {
+	c, cancel := context.WithTimeout(ctx, uc.timeout)
+	defer cancel()
+
+	if !from.Before(to) {
+		return nil, domain.ErrInvalidInput
+	}
+	return uc.usageRepo.Totals(c, "", from, to)
+}
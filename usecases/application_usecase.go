@@ -0,0 +1,140 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/tsigemariamzewdu/JobMate-backend/domain"
+	repo "github.com/tsigemariamzewdu/JobMate-backend/domain/interfaces/repositories"
+	usecase "github.com/tsigemariamzewdu/JobMate-backend/domain/interfaces/usecases"
+	model "github.com/tsigemariamzewdu/JobMate-backend/domain/models"
+)
+
+type JobApplicationUsecase struct {
+	applicationRepo repo.IJobApplicationRepository
+	jobCatalog      repo.IJobCatalogRepository
+	timeout         time.Duration
+}
+
+func NewJobApplicationUsecase(applicationRepo repo.IJobApplicationRepository, jobCatalog repo.IJobCatalogRepository, timeout time.Duration) usecase.IJobApplicationUsecase {
+	return &JobApplicationUsecase{
+		applicationRepo: applicationRepo,
+		jobCatalog:      jobCatalog,
+		timeout:         timeout,
+	}
+}
+
+func (uc *JobApplicationUsecase) SaveJob(ctx context.Context, userID, jobID string, job *model.Job, notes string) (*model.JobApplication, error) {
+	c, cancel := context.WithTimeout(ctx, uc.timeout)
+	defer cancel()
+
+	if userID == "" {
+		return nil, domain.ErrInvalidUserID
+	}
+
+	// a catalog job wins over an inline one so the stored snapshot is the canonical posting
+	if jobID != "" {
+		catalogJob, err := uc.jobCatalog.GetByID(c, jobID)
+		if err != nil {
+			return nil, err
+		}
+		job = catalogJob
+	}
+	if job == nil || strings.TrimSpace(job.Title) == "" || strings.TrimSpace(job.Link) == "" {
+		return nil, domain.ErrInvalidInput
+	}
+
+	exists, err := uc.applicationRepo.ExistsForJob(c, userID, job.Link)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check saved jobs: %w", err)
+	}
+	if exists {
+		return nil, domain.ErrJobAlreadySaved
+	}
+
+	now := time.Now()
+	app := &model.JobApplication{
+		UserID: userID,
+		JobID:  jobID,
+		Job:    *job,
+		Status: model.ApplicationSaved,
+		Notes:  notes,
+		History: []model.ApplicationStatusChange{
+			{Status: model.ApplicationSaved, ChangedAt: now},
+		},
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+
+	id, err := uc.applicationRepo.Create(c, app)
+	if err != nil {
+		return nil, err
+	}
+	app.ID = id
+	return app, nil
+}
+
+func (uc *JobApplicationUsecase) Update(ctx context.Context, userID, id string, update model.ApplicationUpdate) (*model.JobApplication, error) {
+	c, cancel := context.WithTimeout(ctx, uc.timeout)
+	defer cancel()
+
+	if update.Status != nil && !update.Status.IsValid() {
+		return nil, domain.ErrInvalidApplicationStatus
+	}
+
+	app, err := uc.applicationRepo.GetByID(c, userID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if update.Notes != nil {
+		app.Notes = *update.Notes
+	}
+	if update.Status != nil {
+		date := update.Date
+		if date.IsZero() {
+			date = time.Now()
+		}
+		app.Status = *update.Status
+		app.History = append(app.History, model.ApplicationStatusChange{
+			Status:    *update.Status,
+			Note:      update.StatusNote,
+			ChangedAt: date,
+		})
+		if app.Status == model.ApplicationApplied && app.AppliedAt == nil {
+			app.AppliedAt = &date
+		}
+	}
+	app.UpdatedAt = time.Now()
+
+	if err := uc.applicationRepo.Update(c, app); err != nil {
+		return nil, err
+	}
+	return app, nil
+}
+
+func (uc *JobApplicationUsecase) Get(ctx context.Context, userID, id string) (*model.JobApplication, error) {
+	c, cancel := context.WithTimeout(ctx, uc.timeout)
+	defer cancel()
+
+	return uc.applicationRepo.GetByID(c, userID, id)
+}
+
+func (uc *JobApplicationUsecase) List(ctx context.Context, userID string, status model.ApplicationStatus) ([]model.JobApplication, error) {
+	c, cancel := context.WithTimeout(ctx, uc.timeout)
+	defer cancel()
+
+	if status != "" && !status.IsValid() {
+		return nil, domain.ErrInvalidApplicationStatus
+	}
+	return uc.applicationRepo.ListByUser(c, userID, status)
+}
+
+func (uc *JobApplicationUsecase) Delete(ctx context.Context, userID, id string) error {
+	c, cancel := context.WithTimeout(ctx, uc.timeout)
+	defer cancel()
+
+	return uc.applicationRepo.Delete(c, userID, id)
+}
@@ -0,0 +1,110 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/tsigemariamzewdu/JobMate-backend/domain"
+	usecase "github.com/tsigemariamzewdu/JobMate-backend/domain/interfaces/usecases"
+	model "github.com/tsigemariamzewdu/JobMate-backend/domain/models"
+)
+
+func TestJobApplicationUsecaseRejectsOtherUsers(t *testing.T) {
+	created := time.Date(2025, 3, 1, 9, 0, 0, 0, time.UTC)
+	original := model.JobApplication{
+		ID:     "app-1",
+		UserID: ownerID,
+		Job:    model.Job{Title: "Accountant", Company: "Dashen Bank", Link: "https://example.com/jobs/1"},
+		Status: model.ApplicationSaved,
+		Notes:  "call HR on Monday",
+		History: []model.ApplicationStatusChange{
+			{Status: model.ApplicationSaved, ChangedAt: created},
+		},
+		CreatedAt: created,
+		UpdatedAt: created,
+	}
+
+	tests := []struct {
+		name string
+		call func(uc usecase.IJobApplicationUsecase) error
+	}{
+		{"get", func(uc usecase.IJobApplicationUsecase) error {
+			_, err := uc.Get(context.Background(), intruderID, original.ID)
+			return err
+		}},
+		{"update status", func(uc usecase.IJobApplicationUsecase) error {
+			status := model.ApplicationRejected
+			_, err := uc.Update(context.Background(), intruderID, original.ID, model.ApplicationUpdate{Status: &status})
+			return err
+		}},
+		{"update notes", func(uc usecase.IJobApplicationUsecase) error {
+			notes := "overwritten"
+			_, err := uc.Update(context.Background(), intruderID, original.ID, model.ApplicationUpdate{Notes: &notes})
+			return err
+		}},
+		{"delete", func(uc usecase.IJobApplicationUsecase) error {
+			return uc.Delete(context.Background(), intruderID, original.ID)
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := &fakeApplicationRepo{apps: map[string]model.JobApplication{original.ID: cloneApplication(original)}}
+			uc := NewJobApplicationUsecase(store, nil, time.Second)
+
+			if err := tt.call(uc); !errors.Is(err, domain.ErrApplicationNotFound) {
+				t.Fatalf("got error %v, want %v", err, domain.ErrApplicationNotFound)
+			}
+			if got := store.apps[original.ID]; !reflect.DeepEqual(got, original) {
+				t.Errorf("owner's application changed:\ngot  %+v\nwant %+v", got, original)
+			}
+		})
+	}
+
+	t.Run("list", func(t *testing.T) {
+		store := &fakeApplicationRepo{apps: map[string]model.JobApplication{original.ID: cloneApplication(original)}}
+		uc := NewJobApplicationUsecase(store, nil, time.Second)
+
+		apps, err := uc.List(context.Background(), intruderID, "")
+		if err != nil {
+			t.Fatalf("List: %v", err)
+		}
+		if len(apps) != 0 {
+			t.Errorf("intruder listed %d applications, want 0", len(apps))
+		}
+	})
+}
+
+func TestJobApplicationUsecaseUpdateIsAllOrNothing(t *testing.T) {
+	original := model.JobApplication{
+		ID:     "app-1",
+		UserID: ownerID,
+		Job:    model.Job{Title: "Accountant", Company: "Dashen Bank", Link: "https://example.com/jobs/1"},
+		Status: model.ApplicationSaved,
+		Notes:  "call HR on Monday",
+	}
+	store := &fakeApplicationRepo{apps: map[string]model.JobApplication{original.ID: cloneApplication(original)}}
+	uc := NewJobApplicationUsecase(store, nil, time.Second)
+
+	notes := "sent the cover letter"
+	status := model.ApplicationStatus("hired")
+	_, err := uc.Update(context.Background(), ownerID, original.ID, model.ApplicationUpdate{Status: &status, Notes: &notes})
+	if !errors.Is(err, domain.ErrInvalidApplicationStatus) {
+		t.Fatalf("got error %v, want %v", err, domain.ErrInvalidApplicationStatus)
+	}
+	if got := store.apps[original.ID]; !reflect.DeepEqual(got, original) {
+		t.Errorf("application changed by a rejected update:\ngot  %+v\nwant %+v", got, original)
+	}
+
+	status = model.ApplicationApplied
+	app, err := uc.Update(context.Background(), ownerID, original.ID, model.ApplicationUpdate{Status: &status, Notes: &notes})
+	if err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+	if app.Status != model.ApplicationApplied || app.Notes != notes || app.AppliedAt == nil || len(app.History) != 1 {
+		t.Errorf("Update applied %+v, want status applied, the new notes and one history entry", app)
+	}
+}
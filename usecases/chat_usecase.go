@@ -2,14 +2,16 @@ package usecases
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"strings"
 	"time"
 
+	"github.com/tsigemariamzewdu/JobMate-backend/domain"
 	repositories "github.com/tsigemariamzewdu/JobMate-backend/domain/interfaces/repositories"
+	svc "github.com/tsigemariamzewdu/JobMate-backend/domain/interfaces/services"
 	chatUsecaseI "github.com/tsigemariamzewdu/JobMate-backend/domain/interfaces/usecases"
 	"github.com/tsigemariamzewdu/JobMate-backend/domain/models"
-	groqClient "github.com/tsigemariamzewdu/JobMate-backend/infrastructure/ai"
 	config "github.com/tsigemariamzewdu/JobMate-backend/infrastructure/config"
 )
 
@@ -24,14 +26,14 @@ var interviewQuestions = []string{
 
 type chatUsecase struct {
 	ConversationRepository repositories.IUserConversationRepository
-	GroqClient             *groqClient.GroqClient
+	AIClient               svc.IAIClient
 	AppConfig              *config.Config
 }
 
-func NewChatUsecase(convRepo repositories.IUserConversationRepository, groq *groqClient.GroqClient, cfg *config.Config) chatUsecaseI.IChatUsecase {
+func NewChatUsecase(convRepo repositories.IUserConversationRepository, aiClient svc.IAIClient, cfg *config.Config) chatUsecaseI.IChatUsecase {
 	return &chatUsecase{
 		ConversationRepository: convRepo,
-		GroqClient:             groq,
+		AIClient:               aiClient,
 		AppConfig:              cfg,
 	}
 }
@@ -62,8 +64,11 @@ func (u *chatUsecase) SendMessage(ctx context.Context, userID string, message st
 	// Define System Prompt & Build AI Request Messages
 	aiMessages := u.buildAIMessages(history, message)
 
-	// Call the AI client (Groq for chat)
-	aiRawResponse, err := u.GroqClient.GetChatCompletion(ctx, aiMessages)
+	// Call the configured AI client
+	aiRawResponse, err := u.AIClient.GetChatCompletion(domain.WithAICaller(ctx, userID, models.AIFeatureChat), aiMessages)
+	if errors.Is(err, domain.ErrAIQuotaExceeded) {
+		return nil, err
+	}
 	if err != nil {
 		// Handle fallback if AI call fails
 		fmt.Printf("Error calling AI client for user %s: %v\n", userID, err)
@@ -74,25 +79,9 @@ func (u *chatUsecase) SendMessage(ctx context.Context, userID string, message st
 	aiCleanedResponse, intent, newContext := u.parseAIResponseAndTagIntent(history, message, aiRawResponse)
 
 	// Update context for interview mode
-	
-	if currentMode, ok := u.extractModeFromHistory(history); ok && currentMode == "interview" {
-		if idx, ok := u.extractQuestionIndexFromHistory(history); ok {
-			if idx < len(interviewQuestions)-1 {
-				newContext["question_index"] = idx + 1
-				aiCleanedResponse = interviewQuestions[idx+1] // Send next question
-				intent = "interview_practice"
-			} else {
-				newContext["mode"] = "general" // End interview mode
-				aiCleanedResponse = "That was the last question! How did you feel about the practice?"
-				intent = "interview_practice"
-			}
-		}
-	} else if strings.Contains(strings.ToLower(message), "start interview") {
-		// User wants to start interview mode
+	if reply, ok := u.interviewReply(history, message, newContext); ok {
+		aiCleanedResponse = reply
 		intent = "interview_practice"
-		aiCleanedResponse = interviewQuestions[0]
-		newContext["mode"] = "interview"
-		newContext["question_index"] = 0
 	} else if aiCleanedResponse == "" {
 		aiCleanedResponse = "Can you rephrase that? I'm not sure how to help."
 		intent = "general"
@@ -118,6 +107,70 @@ func (u *chatUsecase) SendMessage(ctx context.Context, userID string, message st
 	return aiConversation, nil
 }
 
+// StreamMessage is SendMessage with the reply relayed through onDelta as it is generated.
+// The assembled reply is saved even when the stream fails or the caller goes away, so history
+// matches what the user saw; a cut-off reply is marked with "partial" in its context.
+func (u *chatUsecase) StreamMessage(ctx context.Context, userID string, message string, onDelta func(delta string) error) (*models.UserConversation, error) {
+	userConversation := &models.UserConversation{
+		UserID:      userID,
+		IsFromUser:  true,
+		Message:     message,
+		MessageType: "text",
+		CreatedAt:   time.Now(),
+	}
+	if err := u.ConversationRepository.SaveConversationMessage(ctx, userConversation); err != nil {
+		return nil, fmt.Errorf("failed to save user conversation: %w", err)
+	}
+
+	history, err := u.ConversationRepository.GetConversationHistory(ctx, userID, 5)
+	if err != nil {
+		fmt.Printf("Error fetching chat history for user %s: %v\n", userID, err)
+		history = []models.UserConversation{}
+	}
+
+	var reply, intent string
+	var newContext map[string]interface{}
+	var streamErr error
+
+	// interview questions are scripted, so they go out as a single delta without calling the AI
+	scripted := make(map[string]interface{})
+	if question, ok := u.interviewReply(history, message, scripted); ok {
+		reply, intent, newContext = question, "interview_practice", scripted
+		streamErr = onDelta(question)
+	} else {
+		var raw string
+		raw, streamErr = u.AIClient.StreamChatCompletion(domain.WithAICaller(ctx, userID, models.AIFeatureChat), u.buildAIMessages(history, message), onDelta)
+		if streamErr != nil && raw == "" {
+			// nothing reached the user, so there is no reply to keep
+			fmt.Printf("Error streaming AI response for user %s: %v\n", userID, streamErr)
+			return nil, streamErr
+		}
+		reply, intent, newContext = u.parseAIResponseAndTagIntent(history, message, raw)
+	}
+	if streamErr != nil {
+		newContext["partial"] = true
+	}
+
+	aiConversation := &models.UserConversation{
+		UserID:      userID,
+		Message:     reply,
+		IsFromUser:  false,
+		MessageType: "text",
+		Intent:      intent,
+		Context:     newContext,
+		CreatedAt:   time.Now(),
+	}
+
+	// the request context may already be cancelled, which must not stop the save
+	saveCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := u.ConversationRepository.SaveConversationMessage(saveCtx, aiConversation); err != nil {
+		return nil, fmt.Errorf("failed to save AI conversation: %w", err)
+	}
+
+	return aiConversation, streamErr
+}
+
 func (u *chatUsecase) GetConversationHistory(ctx context.Context, userID string, limit int64) ([]models.UserConversation, error) {
 	return u.ConversationRepository.GetConversationHistory(ctx, userID, limit)
 }
@@ -189,6 +242,30 @@ func (u *chatUsecase) createFallbackResponse(userID, userMessage string, aiErr e
 	}, nil
 }
 
+// interviewReply returns the next scripted interview question when the user is in, or starting,
+// interview practice, and records the new mode and position in newContext
+func (u *chatUsecase) interviewReply(history []models.UserConversation, message string, newContext map[string]interface{}) (string, bool) {
+	if currentMode, ok := u.extractModeFromHistory(history); ok && currentMode == "interview" {
+		idx, ok := u.extractQuestionIndexFromHistory(history)
+		if !ok {
+			return "", false
+		}
+		if idx < len(interviewQuestions)-1 {
+			newContext["question_index"] = idx + 1
+			return interviewQuestions[idx+1], true // Send next question
+		}
+		newContext["mode"] = "general" // End interview mode
+		return "That was the last question! How did you feel about the practice?", true
+	}
+	if strings.Contains(strings.ToLower(message), "start interview") {
+		// User wants to start interview mode
+		newContext["mode"] = "interview"
+		newContext["question_index"] = 0
+		return interviewQuestions[0], true
+	}
+	return "", false
+}
+
 func (u *chatUsecase) extractModeFromHistory(history []models.UserConversation) (string, bool) {
 	if len(history) > 0 && history[len(history)-1].Context != nil {
 		if mode, ok := history[len(history)-1].Context["mode"].(string); ok {
@@ -0,0 +1,293 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/tsigemariamzewdu/JobMate-backend/domain"
+	repo "github.com/tsigemariamzewdu/JobMate-backend/domain/interfaces/repositories"
+	svc "github.com/tsigemariamzewdu/JobMate-backend/domain/interfaces/services"
+	usecase "github.com/tsigemariamzewdu/JobMate-backend/domain/interfaces/usecases"
+	model "github.com/tsigemariamzewdu/JobMate-backend/domain/models"
+)
+
+// maxCoverLetterRevisions bounds a letter's history; the oldest versions are dropped first
+const maxCoverLetterRevisions = 50
+
+type CoverLetterUsecase struct {
+	coverLetterRepo   repo.ICoverLetterRepository
+	cvRepo            repo.CVRepository
+	userRepo          repo.IUserRepository
+	jobCatalog        repo.IJobCatalogRepository
+	aiClient          svc.IAIClient
+	exporter          svc.DocumentExporter
+	timeout           time.Duration
+	generationTimeout time.Duration
+}
+
+func NewCoverLetterUsecase(
+	coverLetterRepo repo.ICoverLetterRepository,
+	cvRepo repo.CVRepository,
+	userRepo repo.IUserRepository,
+	jobCatalog repo.IJobCatalogRepository,
+	aiClient svc.IAIClient,
+	exporter svc.DocumentExporter,
+	timeout time.Duration,
+	generationTimeout time.Duration,
+) usecase.ICoverLetterUsecase {
+	return &CoverLetterUsecase{
+		coverLetterRepo:   coverLetterRepo,
+		cvRepo:            cvRepo,
+		userRepo:          userRepo,
+		jobCatalog:        jobCatalog,
+		aiClient:          aiClient,
+		exporter:          exporter,
+		timeout:           timeout,
+		generationTimeout: generationTimeout,
+	}
+}
+
+func (uc *CoverLetterUsecase) Generate(ctx context.Context, userID, jobID string, job *model.Job, language model.Language) (*model.CoverLetter, error) {
+	c, cancel := context.WithTimeout(ctx, uc.timeout)
+	defer cancel()
+
+	if userID == "" {
+		return nil, domain.ErrInvalidUserID
+	}
+	if language != "" && language != model.LanguageEn && language != model.LanguageAm {
+		return nil, domain.ErrInvalidInput
+	}
+
+	// as with saved jobs, a catalog job wins over an inline one
+	if jobID != "" {
+		catalogJob, err := uc.jobCatalog.GetByID(c, jobID)
+		if err != nil {
+			return nil, err
+		}
+		job = catalogJob
+	}
+	if job == nil || strings.TrimSpace(job.Title) == "" {
+		return nil, domain.ErrInvalidInput
+	}
+
+	user, err := uc.userRepo.GetByID(c, userID)
+	if err != nil {
+		return nil, err
+	}
+	cv, err := uc.activeCV(c, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if language == "" {
+		language = letterLanguage(user, cv)
+	}
+
+	// the AI call gets its own timeout rather than the one for database calls
+	aiCtx, aiCancel := context.WithTimeout(ctx, uc.generationTimeout)
+	defer aiCancel()
+	reply, err := uc.aiClient.GetChatCompletion(
+		domain.WithAICaller(aiCtx, userID, model.AIFeatureCoverLetter),
+		coverLetterPrompt(user, cv, job, language),
+	)
+	if err != nil {
+		return nil, err
+	}
+	content := cleanLetter(reply)
+	if content == "" {
+		return nil, fmt.Errorf("%w: empty cover letter", domain.ErrAIInvalidOutput)
+	}
+
+	now := time.Now()
+	letter := &model.CoverLetter{
+		UserID:   userID,
+		CVID:     cv.ID,
+		JobID:    jobID,
+		Job:      *job,
+		Language: language,
+		Content:  content,
+		History: []model.CoverLetterRevision{
+			{Content: content, Source: model.CoverLetterGenerated, CreatedAt: now},
+		},
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+
+	c, cancel = context.WithTimeout(ctx, uc.timeout)
+	defer cancel()
+	id, err := uc.coverLetterRepo.Create(c, letter)
+	if err != nil {
+		return nil, err
+	}
+	letter.ID = id
+	return letter, nil
+}
+
+func (uc *CoverLetterUsecase) Edit(ctx context.Context, userID, id, content string) (*model.CoverLetter, error) {
+	c, cancel := context.WithTimeout(ctx, uc.timeout)
+	defer cancel()
+
+	content = strings.TrimSpace(content)
+	if content == "" {
+		return nil, domain.ErrInvalidInput
+	}
+
+	letter, err := uc.coverLetterRepo.GetByID(c, userID, id)
+	if err != nil {
+		return nil, err
+	}
+	if letter.Content == content {
+		return letter, nil
+	}
+
+	now := time.Now()
+	letter.Content = content
+	letter.History = append(letter.History, model.CoverLetterRevision{
+		Content:   content,
+		Source:    model.CoverLetterEdited,
+		CreatedAt: now,
+	})
+	if len(letter.History) > maxCoverLetterRevisions {
+		letter.History = letter.History[len(letter.History)-maxCoverLetterRevisions:]
+	}
+	letter.UpdatedAt = now
+
+	if err := uc.coverLetterRepo.Update(c, letter); err != nil {
+		return nil, err
+	}
+	return letter, nil
+}
+
+func (uc *CoverLetterUsecase) Get(ctx context.Context, userID, id string) (*model.CoverLetter, error) {
+	c, cancel := context.WithTimeout(ctx, uc.timeout)
+	defer cancel()
+
+	return uc.coverLetterRepo.GetByID(c, userID, id)
+}
+
+func (uc *CoverLetterUsecase) List(ctx context.Context, userID string) ([]model.CoverLetter, error) {
+	c, cancel := context.WithTimeout(ctx, uc.timeout)
+	defer cancel()
+
+	return uc.coverLetterRepo.ListByUser(c, userID)
+}
+
+func (uc *CoverLetterUsecase) Delete(ctx context.Context, userID, id string) error {
+	c, cancel := context.WithTimeout(ctx, uc.timeout)
+	defer cancel()
+
+	return uc.coverLetterRepo.Delete(c, userID, id)
+}
+
+func (uc *CoverLetterUsecase) Export(ctx context.Context, userID, id, format string) (*model.CoverLetter, []byte, string, error) {
+	c, cancel := context.WithTimeout(ctx, uc.timeout)
+	defer cancel()
+
+	letter, err := uc.coverLetterRepo.GetByID(c, userID, id)
+	if err != nil {
+		return nil, nil, "", err
+	}
+	document, contentType, err := uc.exporter.Export(format, letter.Content, letter.Language)
+	if err != nil {
+		return nil, nil, "", err
+	}
+	return letter, document, contentType, nil
+}
+
+// activeCV loads the full text of the user's active CV, or of their newest one when none is active
+func (uc *CoverLetterUsecase) activeCV(ctx context.Context, userID string) (*model.CV, error) {
+	cvs, err := uc.cvRepo.ListByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if len(cvs) == 0 {
+		return nil, domain.ErrCVNotFound
+	}
+	cvID := cvs[0].ID
+	for _, candidate := range cvs {
+		if candidate.IsActive {
+			cvID = candidate.ID
+			break
+		}
+	}
+	// listing leaves out the text
+	return uc.cvRepo.GetByID(ctx, cvID)
+}
+
+// letterLanguage prefers the user's chosen language, then the language their CV is written in
+func letterLanguage(user *model.User, cv *model.CV) model.Language {
+	if user.PreferredLanguage != nil && *user.PreferredLanguage == model.LanguageAmharic {
+		return model.LanguageAm
+	}
+	if user.PreferredLanguage == nil && cv.Language == model.LanguageAm {
+		return model.LanguageAm
+	}
+	return model.LanguageEn
+}
+
+func coverLetterPrompt(user *model.User, cv *model.CV, job *model.Job, language model.Language) []model.AIMessage {
+	var profile strings.Builder
+	if name := strings.TrimSpace(deref(user.FirstName) + " " + deref(user.LastName)); name != "" {
+		fmt.Fprintf(&profile, "Name: %s\n", name)
+	}
+	if v := deref(user.FieldOfStudy); v != "" {
+		fmt.Fprintf(&profile, "Field of study: %s\n", v)
+	}
+	if v := deref(user.CareerGoals); v != "" {
+		fmt.Fprintf(&profile, "Career goals: %s\n", v)
+	}
+
+	var posting strings.Builder
+	fmt.Fprintf(&posting, "Title: %s\n", job.Title)
+	if job.Company != "" {
+		fmt.Fprintf(&posting, "Company: %s\n", job.Company)
+	}
+	if job.Location != "" {
+		fmt.Fprintf(&posting, "Location: %s\n", job.Location)
+	}
+	if len(job.Requirements) > 0 {
+		fmt.Fprintf(&posting, "Requirements: %s\n", strings.Join(job.Requirements, ", "))
+	}
+	posting.WriteString(job.Description)
+
+	write := "Write the letter in English."
+	if language == model.LanguageAm {
+		write = "Write the letter in Amharic, in Ge'ez script, in the formal register used for job applications in Ethiopia."
+	}
+
+	system := `You are a career coach who writes cover letters for job seekers in Ethiopia.
+Write one cover letter of three to five short paragraphs, addressed to the hiring manager of the company.
+Base every claim on the candidate's CV and profile; never invent employers, degrees, numbers or skills.
+Connect the candidate's experience to the job's requirements and, where it fits, to their career goals.
+Reply with the text of the letter only: no subject line placeholders, no notes, no markdown. Separate paragraphs with a blank line.
+` + write
+
+	prompt := fmt.Sprintf("Candidate profile:\n%s\nCV:\n%s\n\nJob posting:\n%s", profile.String(), cv.OriginalText, posting.String())
+
+	return []model.AIMessage{
+		{Role: "system", Content: system},
+		{Role: "user", Content: prompt},
+	}
+}
+
+// cleanLetter strips the markdown fences and surrounding whitespace some models add
+func cleanLetter(reply string) string {
+	text := strings.TrimSpace(reply)
+	if strings.HasPrefix(text, "```") {
+		text = strings.TrimPrefix(text, "```")
+		if i := strings.IndexByte(text, '\n'); i >= 0 && !strings.Contains(text[:i], " ") {
+			text = text[i+1:] // a language tag such as ```text
+		}
+		text = strings.TrimSuffix(strings.TrimSpace(text), "```")
+	}
+	return strings.TrimSpace(strings.ReplaceAll(text, "\r\n", "\n"))
+}
+
+func deref(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return strings.TrimSpace(*s)
+}
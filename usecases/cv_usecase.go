@@ -2,8 +2,15 @@ package usecases
 
 import (
 	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
 	"fmt"
+	"io"
 	"log"
+	"path"
+	"sort"
+	"strings"
 
 	"mime/multipart"
 	"time"
@@ -16,49 +23,79 @@ import (
 	usecase "github.com/tsigemariamzewdu/JobMate-backend/domain/interfaces/usecases"
 
 	model "github.com/tsigemariamzewdu/JobMate-backend/domain/models"
+	"github.com/tsigemariamzewdu/JobMate-backend/infrastructure/skills"
 )
 
+// maxAnalysisAttempts bounds how often a job is picked up again after its worker died mid-run
+const maxAnalysisAttempts = 3
+
 type CVUsecase struct {
-	cvRepo        repo.CVRepository
-	feedbackRepo  repo.FeedbackRepository
-	skillGapRepo  repo.SkillGapRepository
-	aiService     service.AISuggestionService
-	textExtractor service.TextExtractor
-	timeout       time.Duration
+	cvRepo          repo.CVRepository
+	feedbackRepo    repo.FeedbackRepository
+	skillGapRepo    repo.SkillGapRepository
+	analysisRepo    repo.CVAnalysisJobRepository
+	jobCatalogRepo  repo.IJobCatalogRepository
+	aiService       service.AISuggestionService
+	textExtractor   service.TextExtractor
+	cvParser        service.CVParser
+	cvScorer        service.CVScorer
+	blobStore       service.BlobStore
+	analysisQueue   service.WorkNotifier
+	timeout         time.Duration
+	analysisTimeout time.Duration
 }
 
 func NewCVUsecase(
 	cvRepo repo.CVRepository,
 	feedbackRepo repo.FeedbackRepository,
 	skillGapRepo repo.SkillGapRepository,
+	analysisRepo repo.CVAnalysisJobRepository,
+	jobCatalogRepo repo.IJobCatalogRepository,
 	aiService service.AISuggestionService,
 	textExtractor service.TextExtractor,
+	cvParser service.CVParser,
+	cvScorer service.CVScorer,
+	blobStore service.BlobStore,
+	analysisQueue service.WorkNotifier,
 	timeout time.Duration,
+	analysisTimeout time.Duration,
 ) usecase.ICVUsecase {
 	return &CVUsecase{
-		cvRepo:        cvRepo,
-		feedbackRepo:  feedbackRepo,
-		skillGapRepo:  skillGapRepo,
-		aiService:     aiService,
-		textExtractor: textExtractor,
-		timeout:       timeout,
+		cvRepo:          cvRepo,
+		feedbackRepo:    feedbackRepo,
+		skillGapRepo:    skillGapRepo,
+		analysisRepo:    analysisRepo,
+		jobCatalogRepo:  jobCatalogRepo,
+		aiService:       aiService,
+		textExtractor:   textExtractor,
+		cvParser:        cvParser,
+		cvScorer:        cvScorer,
+		blobStore:       blobStore,
+		analysisQueue:   analysisQueue,
+		timeout:         timeout,
+		analysisTimeout: analysisTimeout,
 	}
 }
 
 func (uc *CVUsecase) Upload(ctx context.Context, userID string, rawText string, file *multipart.FileHeader) (*model.CV, error) {
-
-	c, cancel := context.WithTimeout(ctx, uc.timeout)
-	defer cancel()
-
+	var contentType string
 	if rawText == "" && file != nil {
-		text, err := uc.textExtractor.Extract(file)
+		// not bound by uc.timeout: reading a scan with OCR takes longer than a database call,
+		// and the extractor limits OCR time itself
+		text, detected, err := uc.textExtractor.Extract(ctx, file)
 		if err != nil {
 			return nil, fmt.Errorf("failed to extract text from file: %w", err)
 		}
 
 		rawText = text
+		contentType = detected
 	}
 
+	// started once extraction is done, so a slow OCR run does not eat into the database calls
+	c, cancel := context.WithTimeout(ctx, uc.timeout)
+	defer cancel()
+
+	// the newest upload becomes the active CV
 	cv := &model.CV{
 		UserID:       userID,
 		FileName:     "",
@@ -68,51 +105,620 @@ func (uc *CVUsecase) Upload(ctx context.Context, userID string, rawText string,
 		UpdatedAt:    time.Now(),
 	}
 
+	// the rules give the CV its skills, experience and education before any AI analysis
+	fillFromParsed(cv, uc.cvParser.Parse(rawText))
+
 	if file != nil {
 		cv.FileName = file.Filename
+		stored, err := uc.storeOriginal(c, userID, file, contentType)
+		if err != nil {
+			return nil, err
+		}
+		cv.File = stored
 	}
 
 	id, err := uc.cvRepo.Create(c, cv)
 	if err != nil {
+		if cv.File != nil {
+			if delErr := uc.blobStore.Delete(c, cv.File.Key); delErr != nil {
+				log.Printf("failed to remove stored file %s: %v", cv.File.Key, delErr)
+			}
+		}
 		return nil, fmt.Errorf("failed to create CV in repository: %w", err)
 	}
 	cv.ID = id
+
+	// inserted active; this clears the CV that was active before it
+	if err := uc.cvRepo.SetActive(c, userID, id); err != nil {
+		return nil, fmt.Errorf("failed to activate uploaded CV: %w", err)
+	}
 	return cv, nil
 }
 
-func (uc *CVUsecase) Analyze(ctx context.Context, cvID string) (*model.AISuggestions, error) {
+// storeOriginal keeps the uploaded document so it can be downloaded later
+func (uc *CVUsecase) storeOriginal(ctx context.Context, userID string, file *multipart.FileHeader, contentType string) (*model.BlobInfo, error) {
+	f, err := file.Open()
+	if err != nil {
+		return nil, fmt.Errorf("failed to open uploaded file: %w", err)
+	}
+	defer f.Close()
+
+	token, err := randomHex(8)
+	if err != nil {
+		return nil, err
+	}
+	name := path.Base(file.Filename)
+	if name == "." || name == ".." || name == "/" {
+		name = "cv"
+	}
+	key := "cvs/" + userID + "/" + token + "/" + name
+	info, err := uc.blobStore.Put(ctx, key, f, contentType)
+	if err != nil {
+		return nil, fmt.Errorf("failed to store uploaded file: %w", err)
+	}
+	return info, nil
+}
+
+// OpenFile opens the uploaded original of a CV owned by userID. The caller closes the reader.
+func (uc *CVUsecase) OpenFile(ctx context.Context, userID, cvID string) (*model.CV, io.ReadCloser, error) {
 	c, cancel := context.WithTimeout(ctx, uc.timeout)
 	defer cancel()
 
-	cv, err := uc.cvRepo.GetByID(c, cvID)
+	cv, err := uc.ownedCV(c, userID, cvID)
 	if err != nil {
+		return nil, nil, err
+	}
+	if cv.File == nil {
+		return nil, nil, domain.ErrCVFileNotFound
+	}
+
+	content, info, err := uc.blobStore.Get(c, cv.File.Key)
+	if errors.Is(err, domain.ErrBlobNotFound) {
+		return nil, nil, domain.ErrCVFileNotFound
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	if info.ContentType != "" {
+		cv.File.ContentType = info.ContentType
+	}
+	return cv, content, nil
+}
+
+func randomHex(n int) (string, error) {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+func (uc *CVUsecase) List(ctx context.Context, userID string) ([]model.CV, error) {
+	c, cancel := context.WithTimeout(ctx, uc.timeout)
+	defer cancel()
+
+	return uc.cvRepo.ListByUserID(c, userID)
+}
+
+func (uc *CVUsecase) Get(ctx context.Context, userID, cvID string) (*model.CV, error) {
+	c, cancel := context.WithTimeout(ctx, uc.timeout)
+	defer cancel()
+
+	return uc.ownedCV(c, userID, cvID)
+}
+
+func (uc *CVUsecase) Activate(ctx context.Context, userID, cvID string) (*model.CV, error) {
+	c, cancel := context.WithTimeout(ctx, uc.timeout)
+	defer cancel()
+
+	if err := uc.cvRepo.SetActive(c, userID, cvID); err != nil {
 		return nil, err
 	}
+	return uc.ownedCV(c, userID, cvID)
+}
 
-	// Generate AI suggestions
-	suggestions, err := uc.aiService.Analyze(c, cv.OriginalText)
+// Delete soft deletes the CV. Deleting the active CV activates the newest remaining one.
+func (uc *CVUsecase) Delete(ctx context.Context, userID, cvID string) error {
+	c, cancel := context.WithTimeout(ctx, uc.timeout)
+	defer cancel()
+
+	cv, err := uc.ownedCV(c, userID, cvID)
 	if err != nil {
+		return err
+	}
+	if err := uc.cvRepo.SoftDelete(c, userID, cvID); err != nil {
+		return err
+	}
+	if !cv.IsActive {
+		return nil
+	}
+
+	remaining, err := uc.cvRepo.ListByUserID(c, userID)
+	if err != nil {
+		log.Printf("failed to list CVs after deleting active CV %s: %v", cvID, err)
+		return nil
+	}
+	if len(remaining) > 0 {
+		if err := uc.cvRepo.SetActive(c, userID, remaining[0].ID); err != nil {
+			log.Printf("failed to activate CV %s after deleting %s: %v", remaining[0].ID, cvID, err)
+		}
+	}
+	return nil
+}
+
+// RequestAnalysis queues an analysis of the user's CV. A CV that is already queued or running
+// returns that job instead, so repeated requests never pay for a second AI call at once; a
+// finished analysis can be requested again, e.g. for another target job.
+func (uc *CVUsecase) RequestAnalysis(ctx context.Context, userID, cvID, targetJobID string) (*model.CVAnalysisJob, bool, error) {
+	c, cancel := context.WithTimeout(ctx, uc.timeout)
+	defer cancel()
+
+	if _, err := uc.ownedCV(c, userID, cvID); err != nil {
+		return nil, false, err
+	}
+	if targetJobID != "" {
+		if _, err := uc.jobCatalogRepo.GetByID(c, targetJobID); err != nil {
+			return nil, false, err
+		}
+	}
+
+	now := time.Now()
+	job, created, err := uc.analysisRepo.CreateOrGetActive(c, &model.CVAnalysisJob{
+		UserID:      userID,
+		CVID:        cvID,
+		TargetJobID: targetJobID,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	})
+	if err != nil {
+		return nil, false, err
+	}
+	if created {
+		uc.analysisQueue.Notify()
+		return job, true, nil
+	}
+	if targetJobID == "" || job.TargetJobID == targetJobID {
+		return job, false, nil
+	}
+	return nil, false, domain.ErrCVAnalysisInProgress
+}
+
+func (uc *CVUsecase) GetAnalysis(ctx context.Context, userID, cvID string) (*model.CVAnalysisJob, error) {
+	c, cancel := context.WithTimeout(ctx, uc.timeout)
+	defer cancel()
+
+	if _, err := uc.ownedCV(c, userID, cvID); err != nil {
 		return nil, err
 	}
+	return uc.analysisRepo.GetLatestByCVID(c, cvID)
+}
+
+// ProcessNextAnalysis claims one queued analysis and runs it. It reports false when the
+// queue is empty, and is meant to be driven by a worker pool.
+func (uc *CVUsecase) ProcessNextAnalysis(ctx context.Context) (bool, error) {
+	// a job still running after twice the timeout was abandoned by its worker
+	job, err := uc.analysisRepo.ClaimNext(ctx, time.Now().Add(-2*uc.analysisTimeout))
+	if errors.Is(err, domain.ErrCVAnalysisNotFound) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
 
-	// Update CV
-	cv.ExtractedSkills = suggestions.CVs.ExtractedSkills
+	if job.Attempts > maxAnalysisAttempts {
+		return true, uc.failAnalysis(job, "CV analysis could not be completed, please try again")
+	}
+
+	c, cancel := context.WithTimeout(ctx, uc.analysisTimeout)
+	defer cancel()
+
+	suggestions, score, err := uc.analyze(c, job)
+	if err != nil {
+		if ctx.Err() != nil {
+			// shutting down; the job is left running and is picked up again once stale
+			return true, nil
+		}
+		log.Printf("CV analysis %s for CV %s failed: %v", job.ID, job.CVID, err)
+		return true, uc.failAnalysis(job, analysisFailureReason(err))
+	}
+
+	saveCtx, saveCancel := context.WithTimeout(context.Background(), uc.timeout)
+	defer saveCancel()
+	if err := uc.analysisRepo.Complete(saveCtx, job.ID, suggestions, score); err != nil {
+		return true, fmt.Errorf("failed to store CV analysis %s: %w", job.ID, err)
+	}
+	return true, nil
+}
+
+func (uc *CVUsecase) failAnalysis(job *model.CVAnalysisJob, reason string) error {
+	c, cancel := context.WithTimeout(context.Background(), uc.timeout)
+	defer cancel()
+	if err := uc.analysisRepo.Fail(c, job.ID, reason); err != nil {
+		return fmt.Errorf("failed to mark CV analysis %s as failed: %w", job.ID, err)
+	}
+	return nil
+}
+
+// analysisFailureReason turns an analysis error into a message that is safe to show the user
+func analysisFailureReason(err error) string {
+	switch {
+	case errors.Is(err, domain.ErrCVNotFound):
+		return "CV not found"
+	case errors.Is(err, domain.ErrAIQuotaExceeded):
+		return "You have reached today's AI usage limit"
+	case errors.Is(err, domain.ErrAIRateLimited):
+		return "CV analysis is busy, please try again shortly"
+	case errors.Is(err, domain.ErrAIUnavailable), errors.Is(err, domain.ErrAICircuitOpen):
+		return "CV analysis is temporarily unavailable"
+	case errors.Is(err, domain.ErrAIInvalidOutput):
+		return "CV analysis returned an unusable result, please try again"
+	case errors.Is(err, context.DeadlineExceeded):
+		return "CV analysis took too long, please try again"
+	default:
+		return "Failed to analyze CV"
+	}
+}
+
+func (uc *CVUsecase) ListFeedback(ctx context.Context, userID, cvID string) ([]model.CVFeedback, error) {
+	c, cancel := context.WithTimeout(ctx, uc.timeout)
+	defer cancel()
+
+	if _, err := uc.ownedCV(c, userID, cvID); err != nil {
+		return nil, err
+	}
+	return uc.feedbackRepo.ListByCVID(c, cvID)
+}
+
+func (uc *CVUsecase) ListSkillGaps(ctx context.Context, userID string) ([]model.SkillGap, error) {
+	c, cancel := context.WithTimeout(ctx, uc.timeout)
+	defer cancel()
+
+	return uc.skillGapRepo.ListByUserID(c, userID)
+}
+
+// DiffSkillGaps compares the skill gaps of two of the user's analyses. With no IDs given
+// it compares the two most recent ones.
+func (uc *CVUsecase) DiffSkillGaps(ctx context.Context, userID, fromAnalysisID, toAnalysisID string) (*model.SkillGapDiff, error) {
+	c, cancel := context.WithTimeout(ctx, uc.timeout)
+	defer cancel()
+
+	var from, to *model.CVAnalysisJob
+	if fromAnalysisID == "" && toAnalysisID == "" {
+		latest, err := uc.analysisRepo.ListSucceededByUserID(c, userID, 2)
+		if err != nil {
+			return nil, err
+		}
+		if len(latest) < 2 {
+			return nil, domain.ErrCVAnalysisNotFound
+		}
+		from, to = &latest[1], &latest[0]
+	} else {
+		var err error
+		if from, err = uc.ownedAnalysis(c, userID, fromAnalysisID); err != nil {
+			return nil, err
+		}
+		if to, err = uc.ownedAnalysis(c, userID, toAnalysisID); err != nil {
+			return nil, err
+		}
+	}
+
+	diff := &model.SkillGapDiff{
+		FromAnalysisID: from.ID,
+		ToAnalysisID:   to.ID,
+		FromCVID:       from.CVID,
+		ToCVID:         to.CVID,
+		Entries:        diffSkillGaps(from.Result, to.Result),
+	}
+	if from.FinishedAt != nil {
+		diff.FromAt = *from.FinishedAt
+	}
+	if to.FinishedAt != nil {
+		diff.ToAt = *to.FinishedAt
+	}
+	return diff, nil
+}
+
+// Tailor compares a CV with a catalog job, or with a pasted job description when jobID is empty.
+// Keyword overlap is found with the skill taxonomy; the rest comes from the AI.
+func (uc *CVUsecase) Tailor(ctx context.Context, userID, cvID, jobID, jobDescription string) (*model.CVTailoring, error) {
+	c, cancel := context.WithTimeout(ctx, uc.timeout)
+	defer cancel()
+
+	cv, err := uc.ownedCV(c, userID, cvID)
+	if err != nil {
+		return nil, err
+	}
+	job := &model.Job{Description: jobDescription}
+	if jobID != "" {
+		if job, err = uc.jobCatalogRepo.GetByID(c, jobID); err != nil {
+			return nil, err
+		}
+	}
+
+	cvSkills := cv.ExtractedSkills
+	if len(cvSkills) == 0 {
+		cvSkills = uc.cvParser.Parse(cv.OriginalText).Skills
+	}
+	keywords := skills.JobKeywords(job.Requirements, job.Title+"\n"+job.Description)
+	matched, missing := skills.Overlap(cv.OriginalText, cvSkills, keywords)
+
+	// the AI call gets the analysis timeout rather than the one for database calls
+	aiCtx, aiCancel := context.WithTimeout(ctx, uc.analysisTimeout)
+	defer aiCancel()
+	suggestions, err := uc.aiService.Tailor(domain.WithAICaller(aiCtx, userID, model.AIFeatureCVTailoring), cv.OriginalText, job, missing)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	tailoring := &model.CVTailoring{
+		CVID:                cv.ID,
+		JobID:               job.ID,
+		JobTitle:            job.Title,
+		MatchedKeywords:     matched,
+		MissingKeywords:     missing,
+		MissingRequirements: suggestions.MissingRequirements,
+		BulletSuggestions:   suggestions.BulletSuggestions,
+		TailoredSummary:     suggestions.TailoredSummary,
+		SkillGaps:           suggestions.SkillGaps,
+		GeneratedAt:         now,
+	}
+	if len(keywords) > 0 {
+		tailoring.KeywordCoverage = len(matched) * 100 / len(keywords)
+	}
+	// the gaps are specific to this job, so they are returned without replacing the user's own
+	for i := range tailoring.SkillGaps {
+		g := &tailoring.SkillGaps[i]
+		g.UserID, g.CVID, g.CreatedAt, g.UpdatedAt = userID, cv.ID, now, now
+	}
+	return tailoring, nil
+}
+
+// ownedAnalysis loads a successful analysis of the user, reporting anything else as not found
+func (uc *CVUsecase) ownedAnalysis(ctx context.Context, userID, analysisID string) (*model.CVAnalysisJob, error) {
+	if analysisID == "" {
+		return nil, domain.ErrCVAnalysisNotFound
+	}
+	job, err := uc.analysisRepo.GetByID(ctx, analysisID)
+	if err != nil {
+		return nil, err
+	}
+	if job.UserID != userID || job.Status != model.CVAnalysisSucceeded {
+		return nil, domain.ErrCVAnalysisNotFound
+	}
+	return job, nil
+}
+
+// diffSkillGaps matches gaps by normalized skill name. A gap is improved when the distance
+// between current and recommended level shrank and regressed when it grew.
+func diffSkillGaps(from, to *model.AISuggestions) []model.SkillGapDiffEntry {
+	type gap struct {
+		name                 string
+		current, recommended int
+		importance           model.Importance
+	}
+	index := func(s *model.AISuggestions) (map[string]gap, []string) {
+		gaps := make(map[string]gap)
+		var order []string
+		if s == nil {
+			return gaps, order
+		}
+		for _, g := range s.SkillGaps {
+			key := skills.Normalize(g.SkillName)
+			if _, dup := gaps[key]; key == "" || dup {
+				continue
+			}
+			gaps[key] = gap{g.SkillName, g.CurrentLevel, g.RecommendedLevel, model.Importance(g.Importance)}
+			order = append(order, key)
+		}
+		return gaps, order
+	}
+	before, beforeOrder := index(from)
+	after, afterOrder := index(to)
+
+	entries := make([]model.SkillGapDiffEntry, 0, len(before)+len(after))
+	for _, key := range afterOrder {
+		a := after[key]
+		entry := model.SkillGapDiffEntry{
+			SkillName:          a.name,
+			Change:             model.SkillGapAdded,
+			ToCurrentLevel:     a.current,
+			ToRecommendedLevel: a.recommended,
+			ToImportance:       a.importance,
+		}
+		if b, ok := before[key]; ok {
+			entry.FromCurrentLevel = b.current
+			entry.FromRecommendedLevel = b.recommended
+			entry.FromImportance = b.importance
+			switch oldGap, newGap := b.recommended-b.current, a.recommended-a.current; {
+			case newGap < oldGap:
+				entry.Change = model.SkillGapImproved
+			case newGap > oldGap:
+				entry.Change = model.SkillGapRegressed
+			default:
+				entry.Change = model.SkillGapUnchanged
+			}
+		}
+		entries = append(entries, entry)
+	}
+	for _, key := range beforeOrder {
+		if _, ok := after[key]; ok {
+			continue
+		}
+		b := before[key]
+		entries = append(entries, model.SkillGapDiffEntry{
+			SkillName:            b.name,
+			Change:               model.SkillGapResolved,
+			FromCurrentLevel:     b.current,
+			FromRecommendedLevel: b.recommended,
+			FromImportance:       b.importance,
+		})
+	}
+	return entries
+}
+
+func (uc *CVUsecase) SuggestProfile(ctx context.Context, userID string) (*model.ProfileSuggestion, error) {
+	c, cancel := context.WithTimeout(ctx, uc.timeout)
+	defer cancel()
+
+	cvs, err := uc.cvRepo.ListByUserID(c, userID)
+	if err != nil {
+		return nil, err
+	}
+	if len(cvs) == 0 {
+		return nil, domain.ErrCVNotFound
+	}
+	cv := cvs[0]
+	for _, candidate := range cvs {
+		if candidate.IsActive {
+			cv = candidate
+			break
+		}
+	}
+	return suggestProfile(&cv, time.Now()), nil
+}
+
+// suggestProfile reads profile values from the CV's structured experience and education
+func suggestProfile(cv *model.CV, now time.Time) *model.ProfileSuggestion {
+	suggestion := &model.ProfileSuggestion{CVID: cv.ID}
+	if months, ok := experienceMonths(cv.Experience, now); ok {
+		years := months / 12
+		suggestion.YearsExperience = &years
+	}
+
+	var best *model.EducationEntry
+	for i := range cv.Education {
+		e := &cv.Education[i]
+		if e.Level.Rank() == 0 {
+			continue
+		}
+		if best == nil || e.Level.Rank() > best.Level.Rank() ||
+			e.Level == best.Level && e.GraduationYear > best.GraduationYear {
+			best = e
+		}
+	}
+	if best != nil {
+		level := best.Level
+		suggestion.EducationLevel = &level
+		if best.FieldOfStudy != "" {
+			field := best.FieldOfStudy
+			suggestion.FieldOfStudy = &field
+		}
+	}
+	return suggestion
+}
+
+// experienceMonths adds up the months covered by dated positions, counting overlapping
+// positions once. A missing month is taken as mid-year. ok is false when no position is dated.
+func experienceMonths(entries []model.ExperienceEntry, now time.Time) (months int, ok bool) {
+	monthIndex := func(d model.YearMonth) int {
+		if d.Month == 0 {
+			return d.Year*12 + 6
+		}
+		return d.Year*12 + d.Month - 1
+	}
+
+	type span struct{ start, end int }
+	var spans []span
+	for _, e := range entries {
+		if e.Start.Year == 0 {
+			continue
+		}
+		end := now.Year()*12 + int(now.Month()) - 1
+		if !e.Current {
+			if e.End.Year == 0 {
+				continue
+			}
+			end = monthIndex(e.End)
+		}
+		if start := monthIndex(e.Start); end >= start {
+			spans = append(spans, span{start, end})
+		}
+	}
+	if len(spans) == 0 {
+		return 0, false
+	}
+
+	sort.Slice(spans, func(i, j int) bool { return spans[i].start < spans[j].start })
+	current := spans[0]
+	for _, s := range spans[1:] {
+		if s.start <= current.end {
+			current.end = max(current.end, s.end)
+			continue
+		}
+		months += current.end - current.start
+		current = s
+	}
+	months += current.end - current.start
+	return months, true
+}
+
+// ownedCV loads a CV, reporting other users' CVs as not found
+func (uc *CVUsecase) ownedCV(ctx context.Context, userID, cvID string) (*model.CV, error) {
+	cv, err := uc.cvRepo.GetByID(ctx, cvID)
+	if err != nil {
+		return nil, err
+	}
+	if cv.UserID != userID {
+		return nil, domain.ErrCVNotFound
+	}
+	return cv, nil
+}
+
+// analyze runs the AI analysis for a job and stores what it found on the CV, its feedback and skill gaps.
+// The CV is also scored, against the job's target when it has one.
+func (uc *CVUsecase) analyze(c context.Context, job *model.CVAnalysisJob) (*model.AISuggestions, *model.CVScore, error) {
+	cv, err := uc.ownedCV(c, job.UserID, job.CVID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	parsed := uc.cvParser.Parse(cv.OriginalText)
+
+	// Generate AI suggestions
+	suggestions, err := uc.aiService.Analyze(domain.WithAICaller(c, cv.UserID, model.AIFeatureCVAnalysis), cv.OriginalText)
+	if err != nil {
+		// CVs uploaded before rule-based parsing existed still get what the rules find
+		if fillFromParsed(cv, parsed) {
+			cv.UpdatedAt = time.Now()
+			if updateErr := uc.cvRepo.Update(c, cv); updateErr != nil {
+				log.Printf("failed to save parsed CV %s: %v", cv.ID, updateErr)
+			}
+		}
+		return nil, nil, err
+	}
+	// Update CV, keeping skills the taxonomy found that the AI left out
+	cv.ExtractedSkills = skills.Dedupe(append(suggestions.CVs.ExtractedSkills, parsed.Skills...))
 	cv.ExtractedExperience = suggestions.CVs.ExtractedExperience
 	cv.ExtractedEducation = suggestions.CVs.ExtractedEducation
+	cv.Experience = suggestions.CVs.Experience
+	cv.Education = suggestions.CVs.Education
 	cv.Summary = suggestions.CVs.Summary
+	fillFromParsed(cv, parsed)
 	cv.UpdatedAt = time.Now()
 
 	if err := uc.cvRepo.Update(c, cv); err != nil {
-		return nil, domain.ErrCVUpdateFailed
+		return nil, nil, domain.ErrCVUpdateFailed
 	}
 
+	var target *model.Job
+	if job.TargetJobID != "" {
+		// a posting that expired since the request is still scored without keywords
+		if target, err = uc.jobCatalogRepo.GetByID(c, job.TargetJobID); err != nil {
+			log.Printf("failed to load target job %s for CV analysis %s: %v", job.TargetJobID, job.ID, err)
+		}
+	}
+	score := uc.cvScorer.Score(cv.OriginalText, parsed, target)
+
 	// Save feedback
 	feedback := &model.CVFeedback{
 		UserID:                 cv.UserID,
 		CVID:                   cv.ID,
+		AnalysisID:             job.ID,
 		Strengths:              suggestions.CVFeedback.Strengths,
 		Weaknesses:             suggestions.CVFeedback.Weaknesses,
 		ImprovementSuggestions: suggestions.CVFeedback.ImprovementSuggestions,
+		Score:                  score,
 		GeneratedAt:            time.Now(),
 	}
 
@@ -125,6 +731,7 @@ func (uc *CVUsecase) Analyze(ctx context.Context, cvID string) (*model.AISuggest
 	for _, g := range suggestions.SkillGaps {
 		gaps = append(gaps, &model.SkillGap{
 			UserID:                 cv.UserID,
+			CVID:                   cv.ID,
 			SkillName:              g.SkillName,
 			CurrentLevel:           g.CurrentLevel,
 			RecommendedLevel:       g.RecommendedLevel,
@@ -135,11 +742,89 @@ func (uc *CVUsecase) Analyze(ctx context.Context, cvID string) (*model.AISuggest
 		})
 	}
 
-	if len(gaps) > 0 {
-		if err := uc.skillGapRepo.CreateMany(c, gaps); err != nil {
-			log.Printf("failed to save skill gaps: %v", err)
+	// the newest analysis is the user's current picture, so gaps it no longer reports are dropped
+	if err := uc.skillGapRepo.ReplaceForUser(c, cv.UserID, gaps); err != nil {
+		log.Printf("failed to save skill gaps: %v", err)
+	}
+
+	return suggestions, score, nil
+}
+
+// fillFromParsed fills the CV fields that are still empty from rule-based parsing and
+// reports whether any changed
+func fillFromParsed(cv *model.CV, parsed *model.ParsedCV) bool {
+	changed := false
+	if len(cv.ExtractedSkills) == 0 && len(parsed.Skills) > 0 {
+		cv.ExtractedSkills = parsed.Skills
+		changed = true
+	}
+	if len(cv.Experience) == 0 && len(parsed.Experience) > 0 {
+		cv.Experience = parsed.Experience
+		changed = true
+	}
+	if len(cv.Education) == 0 && len(parsed.Education) > 0 {
+		cv.Education = parsed.Education
+		changed = true
+	}
+	if len(cv.ExtractedExperience) == 0 && len(cv.Experience) > 0 {
+		for _, e := range cv.Experience {
+			cv.ExtractedExperience = append(cv.ExtractedExperience, describeExperience(e))
+		}
+		changed = true
+	}
+	if len(cv.ExtractedEducation) == 0 && len(cv.Education) > 0 {
+		for _, e := range cv.Education {
+			cv.ExtractedEducation = append(cv.ExtractedEducation, describeEducation(e))
 		}
+		changed = true
+	}
+	if cv.Summary == "" && parsed.Summary != "" {
+		cv.Summary = parsed.Summary
+		changed = true
+	}
+	return changed
+}
+
+// describeExperience writes an entry the way the AI lists experience,
+// e.g. "Software Engineer, Ethio Telecom (2019 - present)"
+func describeExperience(e model.ExperienceEntry) string {
+	text := joinNonEmpty(", ", e.Title, e.Organization)
+	end := "present"
+	if !e.Current {
+		end = formatYearMonth(e.End)
+	}
+	if period := joinNonEmpty(" - ", formatYearMonth(e.Start), end); period != "" {
+		text = joinNonEmpty(" ", text, "("+period+")")
 	}
+	return text
+}
 
-	return suggestions, nil
+// describeEducation writes an entry as e.g. "BSc Computer Science, Addis Ababa University (2016)"
+func describeEducation(e model.EducationEntry) string {
+	text := joinNonEmpty(", ", joinNonEmpty(" ", e.Degree, e.FieldOfStudy), e.Institution)
+	if e.GraduationYear > 0 {
+		text = joinNonEmpty(" ", text, fmt.Sprintf("(%d)", e.GraduationYear))
+	}
+	return text
+}
+
+func formatYearMonth(d model.YearMonth) string {
+	switch {
+	case d.Year == 0:
+		return ""
+	case d.Month == 0:
+		return fmt.Sprint(d.Year)
+	default:
+		return time.Date(d.Year, time.Month(d.Month), 1, 0, 0, 0, 0, time.UTC).Format("Jan 2006")
+	}
+}
+
+func joinNonEmpty(sep string, parts ...string) string {
+	var out []string
+	for _, p := range parts {
+		if p != "" {
+			out = append(out, p)
+		}
+	}
+	return strings.Join(out, sep)
 }
@@ -0,0 +1,99 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/tsigemariamzewdu/JobMate-backend/domain"
+	usecase "github.com/tsigemariamzewdu/JobMate-backend/domain/interfaces/usecases"
+	model "github.com/tsigemariamzewdu/JobMate-backend/domain/models"
+)
+
+func TestCVUsecaseRejectsOtherUsers(t *testing.T) {
+	created := time.Date(2025, 3, 1, 9, 0, 0, 0, time.UTC)
+	owned := model.CV{
+		ID:              "cv-1",
+		UserID:          ownerID,
+		Version:         1,
+		FileName:        "cv.pdf",
+		File:            &model.BlobInfo{Key: "cvs/owner/cv.pdf", ContentType: "application/pdf"},
+		OriginalText:    "Experienced accountant",
+		ExtractedSkills: []string{"accounting"},
+		IsActive:        true,
+		CreatedAt:       created,
+		UpdatedAt:       created,
+	}
+	// the intruder's own CV, so activating someone else's cannot be mistaken for a no-op
+	intruders := model.CV{ID: "cv-2", UserID: intruderID, Version: 1, IsActive: true, CreatedAt: created, UpdatedAt: created}
+
+	// every dependency but the CV repository is nil: the ownership check must fail first
+	tests := []struct {
+		name string
+		call func(uc usecase.ICVUsecase) error
+	}{
+		{"get", func(uc usecase.ICVUsecase) error {
+			_, err := uc.Get(context.Background(), intruderID, owned.ID)
+			return err
+		}},
+		{"activate", func(uc usecase.ICVUsecase) error {
+			_, err := uc.Activate(context.Background(), intruderID, owned.ID)
+			return err
+		}},
+		{"delete", func(uc usecase.ICVUsecase) error {
+			return uc.Delete(context.Background(), intruderID, owned.ID)
+		}},
+		{"download", func(uc usecase.ICVUsecase) error {
+			_, _, err := uc.OpenFile(context.Background(), intruderID, owned.ID)
+			return err
+		}},
+		{"request analysis", func(uc usecase.ICVUsecase) error {
+			_, _, err := uc.RequestAnalysis(context.Background(), intruderID, owned.ID, "")
+			return err
+		}},
+		{"get analysis", func(uc usecase.ICVUsecase) error {
+			_, err := uc.GetAnalysis(context.Background(), intruderID, owned.ID)
+			return err
+		}},
+		{"list feedback", func(uc usecase.ICVUsecase) error {
+			_, err := uc.ListFeedback(context.Background(), intruderID, owned.ID)
+			return err
+		}},
+		{"tailor", func(uc usecase.ICVUsecase) error {
+			_, err := uc.Tailor(context.Background(), intruderID, owned.ID, "", "We need an accountant")
+			return err
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := &fakeCVRepo{cvs: map[string]model.CV{owned.ID: cloneCV(owned), intruders.ID: intruders}}
+			uc := NewCVUsecase(store, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, time.Second, time.Second)
+
+			if err := tt.call(uc); !errors.Is(err, domain.ErrCVNotFound) {
+				t.Fatalf("got error %v, want %v", err, domain.ErrCVNotFound)
+			}
+			if got := store.cvs[owned.ID]; !reflect.DeepEqual(got, owned) {
+				t.Errorf("owner's CV changed:\ngot  %+v\nwant %+v", got, owned)
+			}
+			if got := store.cvs[intruders.ID]; !reflect.DeepEqual(got, intruders) {
+				t.Errorf("intruder's own CV changed:\ngot  %+v\nwant %+v", got, intruders)
+			}
+		})
+	}
+
+	t.Run("list", func(t *testing.T) {
+		store := &fakeCVRepo{cvs: map[string]model.CV{owned.ID: cloneCV(owned)}}
+		uc := NewCVUsecase(store, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, time.Second, time.Second)
+
+		cvs, err := uc.List(context.Background(), intruderID)
+		if err != nil {
+			t.Fatalf("List: %v", err)
+		}
+		if len(cvs) != 0 {
+			t.Errorf("intruder listed %d CVs, want 0", len(cvs))
+		}
+	})
+}
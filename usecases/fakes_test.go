@@ -0,0 +1,209 @@
+package usecases
+
+import (
+	"context"
+	"slices"
+
+	"github.com/tsigemariamzewdu/JobMate-backend/domain"
+	repo "github.com/tsigemariamzewdu/JobMate-backend/domain/interfaces/repositories"
+	model "github.com/tsigemariamzewdu/JobMate-backend/domain/models"
+)
+
+// The fakes below keep documents in memory and scope them to their owner the way the Mongo
+// repositories do; repositories/ownership_test.go checks the real ones. Methods a test does
+// not expect to be called are left to the embedded interface and panic.
+
+const (
+	ownerID    = "owner"
+	intruderID = "intruder"
+)
+
+type fakeApplicationRepo struct {
+	repo.IJobApplicationRepository
+	apps map[string]model.JobApplication
+}
+
+func cloneApplication(a model.JobApplication) model.JobApplication {
+	a.History = slices.Clone(a.History)
+	return a
+}
+
+func (r *fakeApplicationRepo) GetByID(ctx context.Context, userID, id string) (*model.JobApplication, error) {
+	app, ok := r.apps[id]
+	if !ok || app.UserID != userID {
+		return nil, domain.ErrApplicationNotFound
+	}
+	app = cloneApplication(app)
+	return &app, nil
+}
+
+func (r *fakeApplicationRepo) ListByUser(ctx context.Context, userID string, status model.ApplicationStatus) ([]model.JobApplication, error) {
+	var out []model.JobApplication
+	for _, app := range r.apps {
+		if app.UserID == userID && (status == "" || app.Status == status) {
+			out = append(out, cloneApplication(app))
+		}
+	}
+	return out, nil
+}
+
+func (r *fakeApplicationRepo) Update(ctx context.Context, app *model.JobApplication) error {
+	stored, ok := r.apps[app.ID]
+	if !ok || stored.UserID != app.UserID {
+		return domain.ErrApplicationNotFound
+	}
+	r.apps[app.ID] = cloneApplication(*app)
+	return nil
+}
+
+func (r *fakeApplicationRepo) Delete(ctx context.Context, userID, id string) error {
+	app, ok := r.apps[id]
+	if !ok || app.UserID != userID {
+		return domain.ErrApplicationNotFound
+	}
+	delete(r.apps, id)
+	return nil
+}
+
+type fakeJobChatRepo struct {
+	repo.IJobChatRepository
+	chats map[string]model.JobChat
+}
+
+func cloneJobChat(c model.JobChat) model.JobChat {
+	c.Messages = slices.Clone(c.Messages)
+	c.JobResults = slices.Clone(c.JobResults)
+	return c
+}
+
+func (r *fakeJobChatRepo) GetJobChatByID(ctx context.Context, userID, chatID string) (*model.JobChat, error) {
+	chat, ok := r.chats[chatID]
+	if !ok || chat.UserID != userID {
+		return nil, domain.ErrJobChatNotFound
+	}
+	chat = cloneJobChat(chat)
+	return &chat, nil
+}
+
+func (r *fakeJobChatRepo) GetJobChatsByUserID(ctx context.Context, userID string) ([]*model.JobChat, error) {
+	var out []*model.JobChat
+	for _, chat := range r.chats {
+		if chat.UserID == userID {
+			chat = cloneJobChat(chat)
+			out = append(out, &chat)
+		}
+	}
+	return out, nil
+}
+
+func (r *fakeJobChatRepo) AppendTurn(ctx context.Context, userID, chatID string, messages []model.JobChatMessage, query map[string]any, jobResults []model.Job) error {
+	chat, ok := r.chats[chatID]
+	if !ok || chat.UserID != userID {
+		return domain.ErrJobChatNotFound
+	}
+	chat.Messages = append(slices.Clone(chat.Messages), messages...)
+	chat.JobSearchQuery, chat.JobResults = query, jobResults
+	r.chats[chatID] = chat
+	return nil
+}
+
+func (r *fakeJobChatRepo) RenameJobChat(ctx context.Context, userID, chatID, title string) error {
+	chat, ok := r.chats[chatID]
+	if !ok || chat.UserID != userID {
+		return domain.ErrJobChatNotFound
+	}
+	chat.Title = title
+	r.chats[chatID] = chat
+	return nil
+}
+
+func (r *fakeJobChatRepo) DeleteJobChat(ctx context.Context, userID, chatID string) error {
+	chat, ok := r.chats[chatID]
+	if !ok || chat.UserID != userID {
+		return domain.ErrJobChatNotFound
+	}
+	delete(r.chats, chatID)
+	return nil
+}
+
+type fakeCVRepo struct {
+	repo.CVRepository
+	cvs map[string]model.CV
+}
+
+func cloneCV(cv model.CV) model.CV {
+	cv.ExtractedSkills = slices.Clone(cv.ExtractedSkills)
+	cv.Experience = slices.Clone(cv.Experience)
+	cv.Education = slices.Clone(cv.Education)
+	return cv
+}
+
+// GetByID is not scoped to a user, like the real repository; the usecase checks ownership
+func (r *fakeCVRepo) GetByID(ctx context.Context, id string) (*model.CV, error) {
+	cv, ok := r.cvs[id]
+	if !ok || cv.DeletedAt != nil {
+		return nil, domain.ErrCVNotFound
+	}
+	cv = cloneCV(cv)
+	return &cv, nil
+}
+
+func (r *fakeCVRepo) ListByUserID(ctx context.Context, userID string) ([]model.CV, error) {
+	var out []model.CV
+	for _, cv := range r.cvs {
+		if cv.UserID == userID && cv.DeletedAt == nil {
+			out = append(out, cloneCV(cv))
+		}
+	}
+	return out, nil
+}
+
+func (r *fakeCVRepo) SetActive(ctx context.Context, userID, cvID string) error {
+	cv, ok := r.cvs[cvID]
+	if !ok || cv.UserID != userID || cv.DeletedAt != nil {
+		return domain.ErrCVNotFound
+	}
+	for id, other := range r.cvs {
+		if other.UserID == userID {
+			other.IsActive = id == cvID
+			r.cvs[id] = other
+		}
+	}
+	return nil
+}
+
+func (r *fakeCVRepo) SoftDelete(ctx context.Context, userID, cvID string) error {
+	cv, ok := r.cvs[cvID]
+	if !ok || cv.UserID != userID || cv.DeletedAt != nil {
+		return domain.ErrCVNotFound
+	}
+	now := cv.UpdatedAt
+	cv.IsActive, cv.DeletedAt = false, &now
+	r.cvs[cvID] = cv
+	return nil
+}
+
+type fakeAlertRepo struct {
+	repo.IJobAlertRepository
+	alerts map[string]model.JobAlert
+}
+
+func (r *fakeAlertRepo) ListByUser(ctx context.Context, userID string) ([]model.JobAlert, error) {
+	var out []model.JobAlert
+	for _, alert := range r.alerts {
+		if alert.UserID == userID {
+			alert.Skills = slices.Clone(alert.Skills)
+			out = append(out, alert)
+		}
+	}
+	return out, nil
+}
+
+func (r *fakeAlertRepo) Delete(ctx context.Context, userID, id string) error {
+	alert, ok := r.alerts[id]
+	if !ok || alert.UserID != userID {
+		return domain.ErrAlertNotFound
+	}
+	delete(r.alerts, id)
+	return nil
+}
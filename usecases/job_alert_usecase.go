@@ -0,0 +1,300 @@
+package usecases
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"html"
+	"log"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/tsigemariamzewdu/JobMate-backend/domain"
+	repo "github.com/tsigemariamzewdu/JobMate-backend/domain/interfaces/repositories"
+	svc "github.com/tsigemariamzewdu/JobMate-backend/domain/interfaces/services"
+	usecase "github.com/tsigemariamzewdu/JobMate-backend/domain/interfaces/usecases"
+	model "github.com/tsigemariamzewdu/JobMate-backend/domain/models"
+)
+
+// maxDigestJobs caps how many postings go into one digest
+const maxDigestJobs = 10
+
+// digestClaimLease is how long a claimed alert is kept from other instances; an instance
+// that dies mid-send leaves the alert due again once it passes
+const digestClaimLease = 15 * time.Minute
+
+type JobAlertUsecase struct {
+	alertRepo    repo.IJobAlertRepository
+	jobRepo      repo.IJobRepository
+	ranker       svc.IJobRanker
+	userRepo     repo.IAuthRepository
+	emailService svc.IEmailService
+	smsSender    svc.ISMSSender
+	baseURL      string
+	timeout      time.Duration
+}
+
+func NewJobAlertUsecase(
+	alertRepo repo.IJobAlertRepository,
+	jobRepo repo.IJobRepository,
+	ranker svc.IJobRanker,
+	userRepo repo.IAuthRepository,
+	emailService svc.IEmailService,
+	smsSender svc.ISMSSender,
+	baseURL string,
+	timeout time.Duration,
+) usecase.IJobAlertUsecase {
+	return &JobAlertUsecase{
+		alertRepo:    alertRepo,
+		jobRepo:      jobRepo,
+		ranker:       ranker,
+		userRepo:     userRepo,
+		emailService: emailService,
+		smsSender:    smsSender,
+		baseURL:      strings.TrimSuffix(baseURL, "/"),
+		timeout:      timeout,
+	}
+}
+
+func (uc *JobAlertUsecase) Subscribe(ctx context.Context, alert *model.JobAlert) (*model.JobAlert, error) {
+	c, cancel := context.WithTimeout(ctx, uc.timeout)
+	defer cancel()
+
+	if alert.UserID == "" {
+		return nil, domain.ErrInvalidUserID
+	}
+	if len(alert.Channels) == 0 {
+		alert.Channels = []model.AlertChannel{model.AlertChannelEmail}
+	}
+
+	// digests only go to the contact details on the user's own account, so nobody can sign a
+	// third party up for email or SMS they never asked for
+	user, err := uc.userRepo.FindByID(c, alert.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load user contact details: %w", err)
+	}
+	alert.Email, alert.Phone = "", ""
+	if user.Email != nil {
+		alert.Email = *user.Email
+	}
+	if user.Phone != nil {
+		alert.Phone = *user.Phone
+	}
+	for _, ch := range alert.Channels {
+		if (ch == model.AlertChannelEmail && alert.Email == "") || (ch == model.AlertChannelSMS && alert.Phone == "") {
+			return nil, domain.ErrAlertNoContactMethod
+		}
+	}
+
+	token, err := generateUnsubscribeToken()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate unsubscribe token: %w", err)
+	}
+
+	now := time.Now()
+	alert.UnsubscribeToken = token
+	alert.Active = true
+	alert.NextRunAt = now.Add(alert.Frequency.Interval())
+	alert.CreatedAt = now
+	alert.UpdatedAt = now
+
+	id, err := uc.alertRepo.Create(c, alert)
+	if err != nil {
+		return nil, err
+	}
+	alert.ID = id
+	return alert, nil
+}
+
+func (uc *JobAlertUsecase) List(ctx context.Context, userID string) ([]model.JobAlert, error) {
+	c, cancel := context.WithTimeout(ctx, uc.timeout)
+	defer cancel()
+
+	return uc.alertRepo.ListByUser(c, userID)
+}
+
+func (uc *JobAlertUsecase) Delete(ctx context.Context, userID, id string) error {
+	c, cancel := context.WithTimeout(ctx, uc.timeout)
+	defer cancel()
+
+	return uc.alertRepo.Delete(c, userID, id)
+}
+
+func (uc *JobAlertUsecase) Unsubscribe(ctx context.Context, token string) error {
+	c, cancel := context.WithTimeout(ctx, uc.timeout)
+	defer cancel()
+
+	if token == "" {
+		return domain.ErrInvalidUnsubscribe
+	}
+	return uc.alertRepo.DeactivateByToken(c, token)
+}
+
+// SendDueDigests claims due alerts one at a time, so instances sharing the database never
+// send the same digest twice
+func (uc *JobAlertUsecase) SendDueDigests(ctx context.Context) error {
+	for ctx.Err() == nil {
+		now := time.Now()
+		alert, err := uc.alertRepo.ClaimDue(ctx, now, now.Add(digestClaimLease))
+		if errors.Is(err, domain.ErrAlertNotFound) {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("failed to claim due job alert: %w", err)
+		}
+		if err := uc.sendDigest(ctx, *alert); err != nil {
+			log.Printf("job alert %s: %v", alert.ID, err)
+		}
+	}
+	return ctx.Err()
+}
+
+// sendDigest sends the alert's unsent matching jobs and schedules its next run
+func (uc *JobAlertUsecase) sendDigest(ctx context.Context, alert model.JobAlert) error {
+	c, cancel := context.WithTimeout(ctx, uc.timeout)
+	defer cancel()
+
+	nextRun := time.Now().Add(alert.Frequency.Interval())
+
+	jobs, _, _, err := uc.jobRepo.GetCuratedJobs(c, alert.Field, alert.LookingFor, "", alert.Skills, alert.Language)
+	if err != nil {
+		return uc.alertRepo.MarkRun(c, alert.ID, nil, nextRun)
+	}
+	if jobs = uc.matchSkills(jobs, alert.Skills); len(jobs) == 0 {
+		return uc.alertRepo.MarkRun(c, alert.ID, nil, nextRun)
+	}
+
+	byKey := make(map[string]model.Job, len(jobs))
+	var keys []string
+	for _, j := range jobs {
+		key := digestJobKey(j)
+		if _, dup := byKey[key]; dup {
+			continue
+		}
+		byKey[key] = j
+		keys = append(keys, key)
+	}
+
+	unsent, err := uc.alertRepo.FilterUnsent(c, alert.ID, keys)
+	if err != nil {
+		return err
+	}
+	if len(unsent) == 0 {
+		return uc.alertRepo.MarkRun(c, alert.ID, nil, nextRun)
+	}
+	if len(unsent) > maxDigestJobs {
+		unsent = unsent[:maxDigestJobs]
+	}
+
+	digest := make([]model.Job, 0, len(unsent))
+	for _, k := range unsent {
+		digest = append(digest, byKey[k])
+	}
+
+	unsubscribeURL := uc.baseURL + "/alerts/unsubscribe?token=" + url.QueryEscape(alert.UnsubscribeToken)
+	delivered := false
+	for _, ch := range alert.Channels {
+		var sendErr error
+		switch ch {
+		case model.AlertChannelEmail:
+			subject := fmt.Sprintf("JobMate: %d new %s jobs", len(digest), alert.Field)
+			if alert.Language == "am" {
+				subject = fmt.Sprintf("JobMate: %d አዳዲስ የ%s ስራዎች", len(digest), alert.Field)
+			}
+			sendErr = uc.emailService.SendEmail(alert.Email, subject, generateJobDigestEmailBody(alert, digest, unsubscribeURL))
+		case model.AlertChannelSMS:
+			sendErr = uc.smsSender.SendSMS(alert.Phone, generateJobDigestSMS(alert, digest, unsubscribeURL))
+		}
+		if sendErr != nil {
+			log.Printf("job alert %s: failed to send %s digest: %v", alert.ID, ch, sendErr)
+			continue
+		}
+		delivered = true
+	}
+
+	if !delivered {
+		// move on to the next run anyway, or a bounced address would be retried on every tick;
+		// the jobs stay unsent and go out with the next digest
+		if err := uc.alertRepo.MarkRun(c, alert.ID, nil, nextRun); err != nil {
+			return err
+		}
+		return fmt.Errorf("no digest channel succeeded")
+	}
+	if err := uc.alertRepo.MarkSent(c, alert.ID, unsent); err != nil {
+		return err
+	}
+	sentAt := time.Now()
+	return uc.alertRepo.MarkRun(c, alert.ID, &sentAt, nextRun)
+}
+
+// matchSkills keeps the jobs that ask for at least one of the alert's skills, best match
+// first; the job sources only search by field, so the skills are applied here
+func (uc *JobAlertUsecase) matchSkills(jobs []model.Job, alertSkills []string) []model.Job {
+	if len(alertSkills) == 0 || len(jobs) == 0 {
+		return jobs
+	}
+	var matched []model.Job
+	for _, r := range uc.ranker.Rank(jobs, model.CandidateProfile{Skills: alertSkills}) {
+		if len(r.MatchedSkills) > 0 {
+			matched = append(matched, r.Job)
+		}
+	}
+	return matched
+}
+
+// digestJobKey identifies a job across digest runs
+func digestJobKey(j model.Job) string {
+	if j.ID != "" {
+		return j.ID
+	}
+	return j.Link
+}
+
+func generateUnsubscribeToken() (string, error) {
+	b := make([]byte, 24)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+func generateJobDigestEmailBody(alert model.JobAlert, jobs []model.Job, unsubscribeURL string) string {
+	heading := "New jobs matching your alert"
+	intro := fmt.Sprintf("Here are the latest <strong>%s</strong> opportunities we found for you:", html.EscapeString(alert.Field))
+	unsubscribe := "Unsubscribe from this alert"
+	if alert.Language == "am" {
+		heading = "ለእርስዎ ማንቂያ የሚስማሙ አዳዲስ ስራዎች"
+		intro = fmt.Sprintf("ለእርስዎ ያገኘናቸው አዳዲስ የ<strong>%s</strong> ስራዎች እነሆ፦", html.EscapeString(alert.Field))
+		unsubscribe = "ከዚህ ማንቂያ ይውጡ"
+	}
+
+	var items strings.Builder
+	for _, j := range jobs {
+		fmt.Fprintf(&items, `<li style="margin-bottom: 12px;"><a href="%s" style="color: #1a73e8; font-weight: bold;">%s</a><br>%s · %s</li>`,
+			html.EscapeString(j.Link), html.EscapeString(j.Title), html.EscapeString(j.Company), html.EscapeString(j.Location))
+	}
+
+	return fmt.Sprintf(`
+<html>
+  <body style="font-family: Arial, sans-serif; line-height: 1.6; background-color: #f9f9f9; padding: 20px;">
+    <div style="max-width: 600px; margin: auto; background: white; border-radius: 8px; padding: 20px; box-shadow: 0 2px 6px rgba(0,0,0,0.1);">
+      <h2 style="color: #333;">%s</h2>
+      <p>%s</p>
+      <ul style="padding-left: 20px;">%s</ul>
+      <p style="margin-top: 40px; font-size: 12px; color: #888;"><a href="%s" style="color: #888;">%s</a></p>
+    </div>
+  </body>
+</html>
+`, heading, intro, items.String(), html.EscapeString(unsubscribeURL), unsubscribe)
+}
+
+func generateJobDigestSMS(alert model.JobAlert, jobs []model.Job, unsubscribeURL string) string {
+	top := jobs[0]
+	if alert.Language == "am" {
+		return fmt.Sprintf("JobMate: %d አዳዲስ የ%s ስራዎች። %s - %s %s ለማቆም: %s", len(jobs), alert.Field, top.Title, top.Company, top.Link, unsubscribeURL)
+	}
+	return fmt.Sprintf("JobMate: %d new %s jobs. Top: %s at %s %s Stop: %s", len(jobs), alert.Field, top.Title, top.Company, top.Link, unsubscribeURL)
+}
@@ -0,0 +1,59 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/tsigemariamzewdu/JobMate-backend/domain"
+	model "github.com/tsigemariamzewdu/JobMate-backend/domain/models"
+)
+
+func TestJobAlertUsecaseRejectsOtherUsers(t *testing.T) {
+	created := time.Date(2025, 3, 1, 9, 0, 0, 0, time.UTC)
+	original := model.JobAlert{
+		ID:               "alert-1",
+		UserID:           ownerID,
+		Field:            "accounting",
+		Skills:           []string{"ifrs", "peachtree"},
+		Frequency:        model.AlertDaily,
+		Channels:         []model.AlertChannel{model.AlertChannelEmail},
+		Email:            "owner@example.com",
+		UnsubscribeToken: "token",
+		Active:           true,
+		NextRunAt:        created.Add(24 * time.Hour),
+		CreatedAt:        created,
+		UpdatedAt:        created,
+	}
+
+	newUsecase := func() (*fakeAlertRepo, *JobAlertUsecase) {
+		store := &fakeAlertRepo{alerts: map[string]model.JobAlert{original.ID: original}}
+		uc := NewJobAlertUsecase(store, nil, nil, nil, nil, nil, "https://jobmate.example", time.Second)
+		return store, uc.(*JobAlertUsecase)
+	}
+
+	t.Run("delete", func(t *testing.T) {
+		store, uc := newUsecase()
+
+		if err := uc.Delete(context.Background(), intruderID, original.ID); !errors.Is(err, domain.ErrAlertNotFound) {
+			t.Fatalf("got error %v, want %v", err, domain.ErrAlertNotFound)
+		}
+		if got := store.alerts[original.ID]; !reflect.DeepEqual(got, original) {
+			t.Errorf("owner's alert changed:\ngot  %+v\nwant %+v", got, original)
+		}
+	})
+
+	t.Run("list", func(t *testing.T) {
+		_, uc := newUsecase()
+
+		alerts, err := uc.List(context.Background(), intruderID)
+		if err != nil {
+			t.Fatalf("List: %v", err)
+		}
+		if len(alerts) != 0 {
+			t.Errorf("intruder listed %d alerts, want 0", len(alerts))
+		}
+	})
+}
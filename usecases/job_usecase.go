@@ -2,34 +2,87 @@ package usecases
 
 import (
 	"context"
+	"log"
+	"strings"
 	"time"
 
+	"github.com/tsigemariamzewdu/JobMate-backend/domain"
+	repo "github.com/tsigemariamzewdu/JobMate-backend/domain/interfaces/repositories"
+	svc "github.com/tsigemariamzewdu/JobMate-backend/domain/interfaces/services"
 	"github.com/tsigemariamzewdu/JobMate-backend/domain/models"
-	"github.com/tsigemariamzewdu/JobMate-backend/infrastructure/ai"
-	"github.com/tsigemariamzewdu/JobMate-backend/infrastructure/job_service"
-	"github.com/tsigemariamzewdu/JobMate-backend/repositories"
+)
+
+const (
+	fallbackDefaultPageSize = 20
+	fallbackMaxPageSize     = 100
 )
 
 type JobUsecase struct {
-	JobService  *job_service.JobService
-	JobChatRepo *repositories.JobChatRepository
-	GroqClient  *ai.GroqClient
+	JobService      repo.IJobRepository
+	JobChatRepo     repo.IJobChatRepository
+	AIClient        svc.IAIClient
+	CVRepo          repo.CVRepository
+	Ranker          svc.IJobRanker
+	JobCatalog      repo.IJobCatalogRepository
+	DefaultPageSize int
+	MaxPageSize     int
 }
 
-func NewJobUsecase(jobService *job_service.JobService, jobChatRepo *repositories.JobChatRepository, groqClient *ai.GroqClient) *JobUsecase {
+func NewJobUsecase(jobService repo.IJobRepository, jobChatRepo repo.IJobChatRepository, aiClient svc.IAIClient, cvRepo repo.CVRepository, ranker svc.IJobRanker, jobCatalog repo.IJobCatalogRepository, defaultPageSize, maxPageSize int) *JobUsecase {
+	if maxPageSize <= 0 {
+		maxPageSize = fallbackMaxPageSize
+	}
+	if defaultPageSize <= 0 || defaultPageSize > maxPageSize {
+		defaultPageSize = min(fallbackDefaultPageSize, maxPageSize)
+	}
 	return &JobUsecase{
-		JobService:  jobService,
-		JobChatRepo: jobChatRepo,
-		GroqClient:  groqClient,
+		JobService:      jobService,
+		JobChatRepo:     jobChatRepo,
+		AIClient:        aiClient,
+		CVRepo:          cvRepo,
+		Ranker:          ranker,
+		JobCatalog:      jobCatalog,
+		DefaultPageSize: defaultPageSize,
+		MaxPageSize:     maxPageSize,
+	}
+}
+
+// SearchJobs pages through the job catalog. A missing limit uses the default page size
+// and larger limits are capped at the maximum.
+func (uc *JobUsecase) SearchJobs(ctx context.Context, filter models.JobFilter) (jobs []models.Job, nextCursor string, err error) {
+	if filter.Limit <= 0 {
+		filter.Limit = uc.DefaultPageSize
+	}
+	if filter.Limit > uc.MaxPageSize {
+		filter.Limit = uc.MaxPageSize
 	}
+	return uc.JobCatalog.Find(ctx, filter)
 }
 
-// SuggestJobs handles the full job chat flow: fetch jobs, store chat, call AI, return all
-func (uc *JobUsecase) SuggestJobs(ctx context.Context, userID string, req models.JobSuggestionRequest, chatMsgs []models.JobChatMessage) (jobs []models.Job, aiMessage string, msg string, chatID string, err error) {
+// SuggestJobs handles the full job chat flow: fetch jobs, rank them, store chat, call AI, return all.
+// With a chatID the turn is added to that chat; without one a new chat is started.
+func (uc *JobUsecase) SuggestJobs(ctx context.Context, userID, chatID string, req models.JobSuggestionRequest, newMsgs []models.JobChatMessage) (ranked []models.RankedJob, failures []models.JobSourceFailure, aiMessage string, msg string, outChatID string, err error) {
+	// Load the conversation being continued before doing any work
+	var history []models.JobChatMessage
+	if chatID != "" {
+		chat, err := uc.JobChatRepo.GetJobChatByID(ctx, userID, chatID)
+		if err != nil {
+			return nil, nil, "", "Job chat not found", "", err
+		}
+		history = chat.Messages
+	}
+
 	// Fetch jobs
-	jobs, msg, err = uc.JobService.GetCuratedJobs(req.Field, req.LookingFor, req.Experience, req.Skills, req.Language)
+	fetched, failures, msg, err := uc.JobService.GetCuratedJobs(ctx, req.Field, req.LookingFor, req.Experience, req.Skills, req.Language)
 	if err != nil {
-		return nil, "", "No jobs found for your criteria", "", err
+		return nil, failures, "", "No jobs found for your criteria", "", err
+	}
+
+	// Rank jobs against the request and the user's analyzed CV
+	ranked = uc.Ranker.Rank(fetched, uc.candidateProfile(ctx, userID, req))
+	jobs := make([]models.Job, 0, len(ranked))
+	for _, r := range ranked {
+		jobs = append(jobs, r.Job)
 	}
 
 	// Save or update job chat
@@ -38,14 +91,27 @@ func (uc *JobUsecase) SuggestJobs(ctx context.Context, userID string, req models
 		"field":       req.Field,
 		"skills":      req.Skills,
 		"experience":  req.Experience,
+		"location":    req.Location,
 		"language":    req.Language,
 	}
-	// Always create a new chat for now (could be updated to upsert by chatID)
-	chatID, _ = uc.JobChatRepo.CreateJobChat(ctx, userID, query, jobs, chatMsgs)
+	if chatID == "" {
+		chatID, err = uc.JobChatRepo.CreateJobChat(ctx, &models.JobChat{
+			UserID:         userID,
+			Title:          defaultJobChatTitle(req),
+			Messages:       newMsgs,
+			JobSearchQuery: query,
+			JobResults:     jobs,
+		})
+	} else {
+		err = uc.JobChatRepo.AppendTurn(ctx, userID, chatID, newMsgs, query, jobs)
+	}
+	if err != nil {
+		log.Printf("failed to save job chat for user %s: %v", userID, err)
+	}
 
-	// Prepare context for Groq AI
+	// Prepare context for the AI
 	var aiMessages []models.AIMessage
-	for _, m := range chatMsgs {
+	for _, m := range append(history, newMsgs...) {
 		aiMessages = append(aiMessages, models.AIMessage{
 			Role:    m.Role,
 			Content: m.Message,
@@ -63,15 +129,88 @@ func (uc *JobUsecase) SuggestJobs(ctx context.Context, userID string, req models
 		})
 	}
 
-	// Call Groq AI
-	aiResp, _ := uc.GroqClient.GetChatCompletion(context.Background(), aiMessages)
+	// Call the AI
+	aiResp, err := uc.AIClient.GetChatCompletion(domain.WithAICaller(ctx, userID, models.AIFeatureJobChat), aiMessages)
+	if err != nil {
+		// the user's turn is already saved, so the chat is returned for them to retry in
+		return ranked, failures, "", "Failed to get AI response", chatID, err
+	}
 
 	// Save AI response to chat
-	_ = uc.JobChatRepo.AppendMessage(ctx, chatID, models.JobChatMessage{
-		Role:      "assistant",
-		Message:   aiResp,
-		Timestamp: time.Now(),
-	})
+	if chatID != "" {
+		_ = uc.JobChatRepo.AppendMessage(ctx, chatID, models.JobChatMessage{
+			Role:      "assistant",
+			Message:   aiResp,
+			Timestamp: time.Now(),
+		})
+	}
+
+	return ranked, failures, aiResp, msg, chatID, nil
+}
 
-	return jobs, aiResp, msg, chatID, nil
+// ListChats returns the user's job chats, most recently active first
+func (uc *JobUsecase) ListChats(ctx context.Context, userID string) ([]*models.JobChat, error) {
+	if userID == "" {
+		return nil, domain.ErrInvalidUserID
+	}
+	return uc.JobChatRepo.GetJobChatsByUserID(ctx, userID)
+}
+
+func (uc *JobUsecase) GetChat(ctx context.Context, userID, chatID string) (*models.JobChat, error) {
+	if userID == "" {
+		return nil, domain.ErrInvalidUserID
+	}
+	return uc.JobChatRepo.GetJobChatByID(ctx, userID, chatID)
+}
+
+func (uc *JobUsecase) RenameChat(ctx context.Context, userID, chatID, title string) error {
+	if userID == "" {
+		return domain.ErrInvalidUserID
+	}
+	title = strings.TrimSpace(title)
+	if title == "" {
+		return domain.ErrInvalidInput
+	}
+	return uc.JobChatRepo.RenameJobChat(ctx, userID, chatID, title)
+}
+
+func (uc *JobUsecase) DeleteChat(ctx context.Context, userID, chatID string) error {
+	if userID == "" {
+		return domain.ErrInvalidUserID
+	}
+	return uc.JobChatRepo.DeleteJobChat(ctx, userID, chatID)
+}
+
+// defaultJobChatTitle names a new chat after what the user is searching for
+func defaultJobChatTitle(req models.JobSuggestionRequest) string {
+	title := strings.TrimSpace(req.Field)
+	if title == "" {
+		title = "Job search"
+	}
+	if req.LookingFor != "" {
+		title += " (" + req.LookingFor + ")"
+	}
+	return title
+}
+
+// candidateProfile combines the request skills with the skills extracted from the user's latest analyzed CV
+func (uc *JobUsecase) candidateProfile(ctx context.Context, userID string, req models.JobSuggestionRequest) models.CandidateProfile {
+	profile := models.CandidateProfile{
+		Skills:     append([]string{}, req.Skills...),
+		Experience: req.Experience,
+		Location:   req.Location,
+	}
+	if userID == "" || uc.CVRepo == nil {
+		return profile
+	}
+
+	cv, err := uc.CVRepo.GetLatestAnalyzedByUserID(ctx, userID)
+	if err != nil {
+		if err != domain.ErrCVNotFound {
+			log.Printf("failed to load CV skills for user %s: %v", userID, err)
+		}
+		return profile
+	}
+	profile.Skills = append(profile.Skills, cv.ExtractedSkills...)
+	return profile
 }
@@ -0,0 +1,76 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/tsigemariamzewdu/JobMate-backend/domain"
+	model "github.com/tsigemariamzewdu/JobMate-backend/domain/models"
+)
+
+func TestJobUsecaseRejectsOtherUsersChats(t *testing.T) {
+	created := time.Date(2025, 3, 1, 9, 0, 0, 0, time.UTC)
+	original := model.JobChat{
+		ID:     "chat-1",
+		UserID: ownerID,
+		Title:  "Accounting jobs in Addis Ababa",
+		Messages: []model.JobChatMessage{
+			{Role: "user", Message: "accounting jobs", Timestamp: created},
+		},
+		JobSearchQuery: map[string]any{"field": "accounting"},
+		CreatedAt:      created,
+		UpdatedAt:      created,
+	}
+
+	tests := []struct {
+		name string
+		call func(uc *JobUsecase) error
+	}{
+		{"get", func(uc *JobUsecase) error {
+			_, err := uc.GetChat(context.Background(), intruderID, original.ID)
+			return err
+		}},
+		{"rename", func(uc *JobUsecase) error {
+			return uc.RenameChat(context.Background(), intruderID, original.ID, "mine now")
+		}},
+		{"delete", func(uc *JobUsecase) error {
+			return uc.DeleteChat(context.Background(), intruderID, original.ID)
+		}},
+		{"continue", func(uc *JobUsecase) error {
+			// fails before any job source or AI call; both are nil here
+			msgs := []model.JobChatMessage{{Role: "user", Message: "show me more", Timestamp: created}}
+			_, _, _, _, _, err := uc.SuggestJobs(context.Background(), intruderID, original.ID, model.JobSuggestionRequest{Field: "accounting"}, msgs)
+			return err
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := &fakeJobChatRepo{chats: map[string]model.JobChat{original.ID: cloneJobChat(original)}}
+			uc := NewJobUsecase(nil, store, nil, nil, nil, nil, 20, 50)
+
+			if err := tt.call(uc); !errors.Is(err, domain.ErrJobChatNotFound) {
+				t.Fatalf("got error %v, want %v", err, domain.ErrJobChatNotFound)
+			}
+			if got := store.chats[original.ID]; !reflect.DeepEqual(got, original) {
+				t.Errorf("owner's chat changed:\ngot  %+v\nwant %+v", got, original)
+			}
+		})
+	}
+
+	t.Run("list", func(t *testing.T) {
+		store := &fakeJobChatRepo{chats: map[string]model.JobChat{original.ID: cloneJobChat(original)}}
+		uc := NewJobUsecase(nil, store, nil, nil, nil, nil, 20, 50)
+
+		chats, err := uc.ListChats(context.Background(), intruderID)
+		if err != nil {
+			t.Fatalf("ListChats: %v", err)
+		}
+		if len(chats) != 0 {
+			t.Errorf("intruder listed %d chats, want 0", len(chats))
+		}
+	})
+}